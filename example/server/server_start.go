@@ -1,7 +1,12 @@
 package main
 
 import (
+	"context"
+	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	flag "github.com/spf13/pflag"
 
@@ -11,12 +16,101 @@ import (
 
 func main() {
 	flag.StringP("port", "p", "50051", "Port for the gRPC server")
+	leagues := flag.IntSliceP("leagues", "l", []int{}, "Leagues to keep fresh in the background")
+	flag.Duration("cache-ttl", server.DefaultCacheTTL, "How long data for leagues that aren't watched is cached")
+	flag.Duration("shutdown-timeout", server.DefaultShutdownTimeout, "How long in-flight calls get to finish on shutdown")
+	flag.String("metrics-port", "", "Port serving Prometheus metrics at /metrics, disabled when empty")
+	flag.String("dashboard-port", "", "Port serving the web dashboard, disabled when empty")
+	flag.String("log-level", "info", "Log level, one of debug, info, warn or error")
+	flag.String("log-format", "text", "Log format, text or json")
+	flag.String("trace-file", "", "File to write traces to as JSON, tracing is disabled when empty")
+	flag.Bool("health", true, "Serve the gRPC health service")
+	flag.Duration("health-interval", server.DefaultHealthCheckInterval, "How often the FPL site is probed for the health service")
+	flag.Duration("unhealthy-after", server.DefaultUnhealthyAfter, "How long the FPL site can be unreachable before reporting NOT_SERVING")
+	flag.Bool("reflection", true, "Serve gRPC server reflection")
+	flag.String("tls-cert", "", "Certificate of the server as PEM, gRPC is served without TLS when empty")
+	flag.String("tls-key", "", "Key of the server certificate as PEM")
+	flag.String("tls-client-ca", "", "CA bundle verifying client certificates, which are then required (mutual TLS)")
+	flag.String("api-keys", "", "JSON file of API keys callers have to authenticate with, any caller is served when empty")
+	flag.Duration("quota-period", server.DefaultQuotaPeriod, "Period API key quotas are counted over")
+	flag.String("cohorts", "", "JSON file of cohorts of entries that can be looked at instead of a league")
 	flag.Parse()
 	viper.BindPFlags(flag.CommandLine)
 
-	myFPLServer := server.New()
-	err := myFPLServer.Start(viper.GetString("port"))
+	if err := run(*leagues); err != nil {
+		log.Fatalf("Error running gRPC server! %v", err)
+	}
+}
+
+//run serves until the server is shut down, returning once the deferred clean ups, like flushing the spans still
+//buffered, have run even when serving failed
+func run(leagues []int) error {
+	logger, err := server.NewLogger(viper.GetString("log-level"), viper.GetString("log-format"))
 	if err != nil {
-		log.Fatalf("Error starting gRPC server! %v", err)
+		return fmt.Errorf("error creating logger : %v", err)
+	}
+
+	options := []server.Option{server.WithLogger(logger)}
+	if traceFile := viper.GetString("trace-file"); traceFile != "" {
+		file, err := os.Create(traceFile)
+		if err != nil {
+			return fmt.Errorf("error creating trace file : %v", err)
+		}
+		defer file.Close()
+		tracerProvider, err := server.NewTracerProvider(file)
+		if err != nil {
+			return fmt.Errorf("error creating tracer provider : %v", err)
+		}
+		defer tracerProvider.Shutdown(context.Background())
+		options = append(options, server.WithTracerProvider(tracerProvider))
+	}
+
+	if certFile := viper.GetString("tls-cert"); certFile != "" {
+		tlsConfig, err := server.LoadTLSConfig(certFile, viper.GetString("tls-key"), viper.GetString("tls-client-ca"))
+		if err != nil {
+			return fmt.Errorf("error loading TLS configuration : %v", err)
+		}
+		options = append(options, server.WithTLSConfig(tlsConfig))
 	}
+
+	if keysFile := viper.GetString("api-keys"); keysFile != "" {
+		keyStore, err := server.LoadKeyStore(keysFile)
+		if err != nil {
+			return fmt.Errorf("error loading API keys : %v", err)
+		}
+		options = append(options, server.WithKeyStore(keyStore), server.WithQuotaPeriod(viper.GetDuration("quota-period")))
+	}
+
+	if cohortsFile := viper.GetString("cohorts"); cohortsFile != "" {
+		cohorts, err := server.LoadCohortStore(cohortsFile)
+		if err != nil {
+			return fmt.Errorf("error loading cohorts : %v", err)
+		}
+		options = append(options, server.WithCohorts(cohorts))
+	}
+
+	myFPLServer := server.New(append(options,
+		server.WithWatchedLeagues(leagues...),
+		server.WithCacheTTL(viper.GetDuration("cache-ttl")),
+		server.WithShutdownTimeout(viper.GetDuration("shutdown-timeout")),
+		server.WithMetricsPort(viper.GetString("metrics-port")),
+		server.WithDashboardPort(viper.GetString("dashboard-port")),
+		server.WithHealthCheck(viper.GetBool("health"), viper.GetDuration("health-interval"), viper.GetDuration("unhealthy-after")),
+		server.WithReflection(viper.GetBool("reflection")),
+	)...)
+
+	//SIGINT or SIGTERM shut the server down gracefully, a second one stops it straight away
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-signals
+		logger.WithField("signal", sig.String()).Info("received signal, shutting down")
+		cancel()
+		<-signals
+		logger.Warn("received a second signal, stopping now")
+		myFPLServer.Stop()
+	}()
+
+	return myFPLServer.Start(ctx, viper.GetString("port"))
 }
@@ -0,0 +1,186 @@
+package cli_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-fantasy/fpl/cli"
+	"github.com/go-fantasy/fpl/mock"
+	"github.com/go-fantasy/fpl/sdk"
+	"github.com/go-fantasy/fpl/server"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+)
+
+//serve serves myFPLServer on a random local port, returning its address and a function stopping it
+func serve(t *testing.T, myFPLServer *server.MyFPLServer) (string, func()) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.Nil(t, err)
+	ctx, cancel := context.WithCancel(context.Background())
+	go myFPLServer.Serve(ctx, lis)
+	return lis.Addr().String(), cancel
+}
+
+//run runs the fpl command with args, returning what it wrote and its exit code
+func run(address string, args ...string) (string, int) {
+	command := cli.NewCommand()
+	var out bytes.Buffer
+	command.SetOut(&out)
+	command.SetErr(&out)
+	command.SetArgs(append([]string{"--address", address}, args...))
+	err := command.Execute()
+	return out.String(), cli.ExitCode(err)
+}
+
+func TestCommands(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	cohorts, err := server.NewCohortStore()
+	require.Nil(t, err)
+	address, stop := serve(t, &server.MyFPLServer{Scraper: mock_server.NewLeagueScraper(mockCtrl), Store: server.NewMemoryStore(), Cohorts: cohorts})
+	defer stop()
+
+	out, code := run(address, "players", "-f", "csv")
+	assert.Equal(t, cli.ExitOK, code, out)
+	assert.Equal(t, "players\n3\n", out)
+
+	out, code = run(address, "league", "-l", "313", "-f", "json")
+	assert.Equal(t, cli.ExitOK, code, out)
+	assert.JSONEq(t, `{"league": 313, "participants": 3}`, out)
+
+	out, code = run(address, "gameweek", "-l", "313", "-g", "1-2", "-f", "csv")
+	assert.Equal(t, cli.ExitOK, code, out)
+	assert.Equal(t, "gameweek,player,owners\n1,Messi,3\n1,Ronaldo,1\n2,Ronaldo,2\n2,Salah,2\n2,Messi,1\n", out)
+
+	out, code = run(address, "all-gameweeks", "-l", "313")
+	assert.Equal(t, cli.ExitOK, code, out)
+	assert.Equal(t, []string{
+		"PLAYER   GAMEWEEK 1  GAMEWEEK 2",
+		"Messi    3           1",
+		"Ronaldo  1           2",
+		"Salah    0           2",
+	}, strings.Split(strings.TrimSpace(out), "\n"))
+
+	out, code = run(address, "all-gameweeks", "-l", "313", "-g", "2", "-f", "json")
+	assert.Equal(t, cli.ExitOK, code, out)
+	var matrix sdk.OwnershipMatrix
+	assert.Nil(t, json.Unmarshal([]byte(out), &matrix))
+	assert.Equal(t, []int{2}, matrix.Gameweeks)
+	assert.Equal(t, []int{1}, matrix.Owners["Messi"])
+
+	out, code = run(address, "all-gameweeks", "-l", "313", "--last", "1", "-f", "csv")
+	assert.Equal(t, cli.ExitOK, code, out)
+	assert.Equal(t, "player,gameweek 2\nRonaldo,2\nSalah,2\nMessi,1\n", out)
+	_, code = run(address, "all-gameweeks", "-l", "313", "--last", "1", "-g", "2")
+	assert.Equal(t, cli.ExitUsage, code)
+
+	out, code = run(address, "template", "-l", "313", "--top", "2", "-f", "csv")
+	assert.Equal(t, cli.ExitOK, code, out)
+	assert.Equal(t, "player,owners\nRonaldo,2\nSalah,2\n", out)
+
+	out, code = run(address, "diff", "-l", "313", "--from", "1", "--to", "2", "-f", "csv")
+	assert.Equal(t, cli.ExitOK, code, out)
+	assert.Equal(t, "player,before,after,change\nSalah,0,2,2\nRonaldo,1,2,1\nMessi,3,1,-2\n", out)
+
+	out, code = run(address, "compare", "313", "314", "-g", "1", "-f", "csv")
+	assert.Equal(t, cli.ExitOK, code, out)
+	assert.Equal(t, "gameweek,player,owners 313,share 313,owners 314,share 314,delta 314\n1,Messi,3,100.00,2,100.00,+0.00\n1,Ronaldo,1,33.33,0,0.00,-33.33\n", out)
+	_, code = run(address, "compare", "313")
+	assert.Equal(t, cli.ExitUsage, code)
+
+	out, code = run(address, "similarity", "-l", "313", "-g", "1", "--threshold", "0.8", "-f", "csv")
+	assert.Equal(t, cli.ExitOK, code, out)
+	assert.Equal(t, "cluster,managers,representative,cohesion,template\n1,2 3,2,1.00,Messi\n2,1,1,1.00,Messi Ronaldo\n", out)
+	out, code = run(address, "similarity", "-l", "313", "-g", "1", "--matrix", "-f", "csv")
+	assert.Equal(t, cli.ExitOK, code, out)
+	assert.Equal(t, "entry,1,2,3\n1,1.00,0.50,0.50\n2,0.50,1.00,1.00\n3,0.50,1.00,1.00\n", out)
+	_, code = run(address, "similarity", "-l", "313", "--threshold", "2")
+	assert.Equal(t, cli.ExitUsage, code)
+
+	out, code = run(address, "cohort", "define", "creators", "3", "1", "-f", "csv")
+	assert.Equal(t, cli.ExitOK, code, out)
+	assert.Equal(t, "cohort,entries\ncreators,1 3\n", out)
+	out, code = run(address, "all-gameweeks", "--cohort", "creators", "-g", "1", "-f", "csv")
+	assert.Equal(t, cli.ExitOK, code, out)
+	assert.Equal(t, "player,gameweek 1\nMessi,3\nRonaldo,1\n", out)
+	_, code = run(address, "all-gameweeks", "--cohort", "creators", "-l", "313")
+	assert.Equal(t, cli.ExitUsage, code)
+	out, code = run(address, "cohort", "delete", "creators")
+	assert.Equal(t, cli.ExitOK, code, out)
+	_, code = run(address, "cohort", "delete", "creators")
+	assert.Equal(t, cli.ExitNotFound, code)
+
+	dir, err := ioutil.TempDir("", "fpl-cli")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "league.csv")
+	out, code = run(address, "export", "-l", "313", "-o", file)
+	assert.Equal(t, cli.ExitOK, code, out)
+	exported, err := ioutil.ReadFile(file)
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(string(exported), "# League: 313\n# Sample size: 10\n"), "Unexpected export %v", string(exported))
+	assert.Contains(t, string(exported), "Player,Gameweek 1,Gameweek 2,Total,Average,Peak gameweek\nMessi,3,1,4,2.00,1\n")
+
+	out, code = run(address, "export", "-l", "313", "-f", "markdown")
+	assert.Equal(t, cli.ExitOK, code, out)
+	assert.Equal(t, "| Player | Gameweek 1 | Gameweek 2 | Total | Average | Peak gameweek |\n| --- | ---: | ---: | ---: | ---: | ---: |\n| Messi | 3 | 1 | 4 | 2.00 | 1 |\n| Ronaldo | 1 | 2 | 3 | 1.50 | 2 |\n| Salah | 0 | 2 | 2 | 1.00 | 2 |\n", out)
+	out, code = run(address, "export", "-l", "313", "-f", "markdown", "--sort", "latest")
+	assert.Equal(t, cli.ExitOK, code, out)
+	assert.Equal(t, "| Player | Gameweek 1 | Gameweek 2 | Total | Average | Peak gameweek |\n| --- | ---: | ---: | ---: | ---: | ---: |\n| Ronaldo | 1 | 2 | 3 | 1.50 | 2 |\n| Salah | 0 | 2 | 2 | 1.00 | 2 |\n| Messi | 3 | 1 | 4 | 2.00 | 1 |\n", out)
+	_, code = run(address, "export", "-l", "313", "-f", "pdf")
+	assert.Equal(t, cli.ExitUsage, code)
+	_, code = run(address, "export", "-l", "313", "--sort", "name")
+	assert.Equal(t, cli.ExitUsage, code)
+
+	out, code = run(address, "heatmap", "-l", "313", "--top", "2", "-f", "svg")
+	assert.Equal(t, cli.ExitOK, code, out)
+	assert.True(t, strings.HasPrefix(out, "<svg "), "Unexpected heatmap %v", out)
+	assert.NotContains(t, out, "Salah", "Only the top players should be shown")
+}
+
+func TestExitCodes(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	keyStore, err := server.NewKeyStore(server.APIKey{Name: "cli", Key: "cli-key"})
+	require.Nil(t, err)
+	address, stop := serve(t, &server.MyFPLServer{Scraper: mock_server.NewLeagueScraper(mockCtrl), KeyStore: keyStore})
+	defer stop()
+
+	_, code := run(address, "league")
+	assert.Equal(t, cli.ExitUsage, code, "A league is required")
+	_, code = run(address, "gameweek", "-l", "313", "-g", "5-1")
+	assert.Equal(t, cli.ExitUsage, code, "Ranges should go up")
+	_, code = run(address, "players", "--format", "xml")
+	assert.Equal(t, cli.ExitUsage, code)
+	_, code = run(address, "players", "--unknown")
+	assert.Equal(t, cli.ExitUsage, code)
+
+	_, code = run(address, "players")
+	assert.Equal(t, cli.ExitUnauthorized, code, "Calls without an API key should be rejected")
+	out, code := run(address, "players", "--api-key", "cli-key")
+	assert.Equal(t, cli.ExitOK, code, out)
+
+	assert.Equal(t, cli.ExitNotFound, cli.ExitCode(&sdk.Error{Code: codes.NotFound}))
+	assert.Equal(t, cli.ExitQuotaExceeded, cli.ExitCode(&sdk.Error{Code: codes.ResourceExhausted}))
+	assert.Equal(t, cli.ExitUnavailable, cli.ExitCode(&sdk.Error{Code: codes.Unavailable}))
+	assert.Equal(t, cli.ExitError, cli.ExitCode(errors.New("disk full")))
+}
+
+func TestCompletion(t *testing.T) {
+	command := cli.NewCommand()
+	var out bytes.Buffer
+	command.SetOut(&out)
+	command.SetArgs([]string{"completion", "bash"})
+	assert.Nil(t, command.Execute())
+	assert.Contains(t, out.String(), "bash completion")
+}
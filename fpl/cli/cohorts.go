@@ -0,0 +1,96 @@
+package cli
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/go-fantasy/fpl/sdk"
+	"github.com/spf13/cobra"
+)
+
+func newCohortCommand(o *globalOptions) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "cohort",
+		Short: "Manage the cohorts of entries the other commands look at with --cohort",
+		Example: `  fpl cohort define creators 1234 5678 9012
+  fpl cohort list
+  fpl all-gameweeks --cohort creators --last 5
+  fpl cohort delete creators`,
+	}
+	cmd.AddCommand(
+		&cobra.Command{
+			Use:   "define NAME ENTRY...",
+			Short: "Define a cohort of entries, replacing the cohort with the same name",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				if len(args) < 2 {
+					return usageErrorf("a cohort needs a name and at least one entry")
+				}
+				entries := make([]int64, 0, len(args)-1)
+				for _, arg := range args[1:] {
+					entry, err := strconv.ParseInt(arg, 10, 64)
+					if err != nil || entry <= 0 {
+						return usageErrorf("invalid entry %q", arg)
+					}
+					entries = append(entries, entry)
+				}
+				return o.run(func(ctx context.Context, fpl *sdk.Client) error {
+					cohort, err := fpl.DefineCohort(ctx, args[0], entries...)
+					if err != nil {
+						return err
+					}
+					return o.write(cmd, cohortsResult([]sdk.Cohort{cohort}))
+				})
+			},
+		},
+		&cobra.Command{
+			Use:   "list",
+			Short: "List the cohorts of the server",
+			Args:  cobra.NoArgs,
+			RunE: func(cmd *cobra.Command, args []string) error {
+				return o.run(func(ctx context.Context, fpl *sdk.Client) error {
+					cohorts, err := fpl.Cohorts(ctx)
+					if err != nil {
+						return err
+					}
+					return o.write(cmd, cohortsResult(cohorts))
+				})
+			},
+		},
+		&cobra.Command{
+			Use:   "delete NAME",
+			Short: "Delete a cohort",
+			RunE: func(cmd *cobra.Command, args []string) error {
+				if len(args) != 1 {
+					return usageErrorf("the name of the cohort to delete is required")
+				}
+				return o.run(func(ctx context.Context, fpl *sdk.Client) error {
+					cohort, err := fpl.DeleteCohort(ctx, args[0])
+					if err != nil {
+						return err
+					}
+					return o.write(cmd, cohortsResult([]sdk.Cohort{cohort}))
+				})
+			},
+		},
+	)
+	return cmd
+}
+
+//cohortsResult has a row for every cohort, with its entries separated by spaces
+func cohortsResult(cohorts []sdk.Cohort) *result {
+	r := &result{header: []string{"cohort", "entries"}, value: cohorts}
+	for _, cohort := range cohorts {
+		r.rows = append(r.rows, []string{cohort.Name, joinEntries(cohort.Entries)})
+	}
+	return r
+}
+
+//joinEntries separates entries by spaces
+func joinEntries(entries []int64) string {
+	values := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		values = append(values, strconv.FormatInt(entry, 10))
+	}
+	return strings.Join(values, " ")
+}
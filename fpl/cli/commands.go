@@ -0,0 +1,550 @@
+package cli
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/go-fantasy/fpl/sdk"
+	"github.com/spf13/cobra"
+)
+
+//requireLeague fails commands that need a league when none was given
+func (o *globalOptions) requireLeague() error {
+	if o.league <= 0 {
+		return usageErrorf("a league code is required, use --league")
+	}
+	return nil
+}
+
+//requireLeagueOrCohort fails commands that look at the participants of a league or the entries of a cohort when
+//neither or both were given
+func (o *globalOptions) requireLeagueOrCohort() error {
+	if o.cohort != "" {
+		if o.league != 0 {
+			return usageErrorf("--league and --cohort can't be used together")
+		}
+		return nil
+	}
+	if o.league <= 0 {
+		return usageErrorf("a league code or a cohort is required, use --league or --cohort")
+	}
+	return nil
+}
+
+func newPlayersCommand(o *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "players",
+		Short: "Show how many players there are in FPL, or the players owned with --league or --cohort",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if o.league > 0 || o.cohort != "" {
+				if err := o.requireLeagueOrCohort(); err != nil {
+					return err
+				}
+			}
+			return o.run(func(ctx context.Context, fpl *sdk.Client) error {
+				if o.league <= 0 && o.cohort == "" {
+					numPlayers, err := fpl.NumberOfPlayers(ctx)
+					if err != nil {
+						return err
+					}
+					return o.write(cmd, &result{
+						header: []string{"players"},
+						rows:   [][]string{{strconv.Itoa(numPlayers)}},
+						value:  map[string]int{"players": numPlayers},
+					})
+				}
+
+				matrix, err := fpl.OwnershipMatrix(ctx, o.league, append(o.requestOptions(), sdk.WithSort(grpc_fpl.OwnershipSort_LATEST))...)
+				if err != nil {
+					return err
+				}
+				latest := len(matrix.Gameweeks) - 1
+				ownership := make([]sdk.PlayerOwnership, 0, len(matrix.Players))
+				for _, player := range matrix.Players {
+					if latest < 0 {
+						break
+					}
+					if matrix.Owners[player][latest] == 0 {
+						continue
+					}
+					ownership = append(ownership, sdk.PlayerOwnership{Player: player, Owners: matrix.Owners[player][latest]})
+				}
+				return o.write(cmd, ownershipResult(ownership))
+			})
+		},
+	}
+}
+
+func newLeagueCommand(o *globalOptions) *cobra.Command {
+	return &cobra.Command{
+		Use:   "league",
+		Short: "Show how many participants there are in a league",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.requireLeague(); err != nil {
+				return err
+			}
+			return o.run(func(ctx context.Context, fpl *sdk.Client) error {
+				participants, err := fpl.LeagueSize(ctx, o.league)
+				if err != nil {
+					return err
+				}
+				return o.write(cmd, &result{
+					header: []string{"league", "participants"},
+					rows:   [][]string{{strconv.Itoa(o.league), strconv.Itoa(participants)}},
+					value:  map[string]int{"league": o.league, "participants": participants},
+				})
+			})
+		},
+	}
+}
+
+func newGameweekCommand(o *globalOptions) *cobra.Command {
+	var gameweeks string
+	cmd := &cobra.Command{
+		Use:   "gameweek",
+		Short: "Show the players owned in a league in one or more gameweeks",
+		Example: `  fpl gameweek -l 313 -g 5
+  fpl gameweek -l 313 -g 1-3,7 -f json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.requireLeagueOrCohort(); err != nil {
+				return err
+			}
+			selected, err := parseGameweeks(gameweeks)
+			if err != nil {
+				return err
+			}
+			return o.run(func(ctx context.Context, fpl *sdk.Client) error {
+				type gameweekOwnership struct {
+					Gameweek int                   `json:"gameweek"`
+					Players  []sdk.PlayerOwnership `json:"players"`
+				}
+				r := &result{header: []string{"gameweek", "player", "owners"}}
+				var value []gameweekOwnership
+				for _, gameweek := range selected {
+					ownership, err := fpl.Gameweek(ctx, o.league, gameweek, o.requestOptions()...)
+					if err != nil {
+						return err
+					}
+					for _, player := range ownership {
+						r.rows = append(r.rows, []string{strconv.Itoa(gameweek), player.Player, strconv.Itoa(player.Owners)})
+					}
+					value = append(value, gameweekOwnership{Gameweek: gameweek, Players: ownership})
+				}
+				r.value = value
+				return o.write(cmd, r)
+			})
+		},
+	}
+	cmd.Flags().StringVarP(&gameweeks, "gameweek", "g", "1", "Gameweeks, like 5, 1-5 or 1-3,7")
+	return cmd
+}
+
+func newAllGameweeksCommand(o *globalOptions) *cobra.Command {
+	var selection gameweekFlags
+	cmd := &cobra.Command{
+		Use:   "all-gameweeks",
+		Short: "Show the ownership of every player of a league in every gameweek",
+		Example: `  fpl all-gameweeks -l 313
+  fpl all-gameweeks -l 313 -g 1-3,7
+  fpl all-gameweeks -l 313 --last 5`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.requireLeagueOrCohort(); err != nil {
+				return err
+			}
+			opts, err := selection.requestOptions(o)
+			if err != nil {
+				return err
+			}
+			return o.run(func(ctx context.Context, fpl *sdk.Client) error {
+				matrix, err := fpl.OwnershipMatrix(ctx, o.league, opts...)
+				if err != nil {
+					return err
+				}
+				return o.write(cmd, matrixResult(matrix))
+			})
+		},
+	}
+	selection.register(cmd, "show")
+	return cmd
+}
+
+func newTemplateCommand(o *globalOptions) *cobra.Command {
+	var gameweek, top int
+	cmd := &cobra.Command{
+		Use:   "template",
+		Short: "Show the template team of a league, its most owned players in a gameweek",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.requireLeagueOrCohort(); err != nil {
+				return err
+			}
+			if top <= 0 {
+				return usageErrorf("--top should be positive")
+			}
+			return o.run(func(ctx context.Context, fpl *sdk.Client) error {
+				if gameweek <= 0 {
+					latest, err := o.latestGameweek(ctx, fpl)
+					if err != nil {
+						return err
+					}
+					gameweek = latest
+				}
+				ownership, err := fpl.Gameweek(ctx, o.league, gameweek, o.requestOptions()...)
+				if err != nil {
+					return err
+				}
+				if len(ownership) > top {
+					ownership = ownership[:top]
+				}
+				return o.write(cmd, ownershipResult(ownership))
+			})
+		},
+	}
+	cmd.Flags().IntVarP(&gameweek, "gameweek", "g", 0, "Gameweek, the latest one when 0")
+	cmd.Flags().IntVar(&top, "top", 15, "Number of players in the template")
+	return cmd
+}
+
+//latestGameweek returns the latest gameweek with data for the league or cohort of the flags
+func (o *globalOptions) latestGameweek(ctx context.Context, fpl *sdk.Client) (int, error) {
+	matrix, err := fpl.OwnershipMatrix(ctx, o.league, append(o.requestOptions(), sdk.WithLastGameweeks(1))...)
+	if err != nil {
+		return 0, err
+	}
+	if len(matrix.Gameweeks) == 0 {
+		return 0, fmt.Errorf("league %v has no gameweeks yet", o.league)
+	}
+	return matrix.Gameweeks[len(matrix.Gameweeks)-1], nil
+}
+
+func newSimilarityCommand(o *globalOptions) *cobra.Command {
+	var gameweek int
+	var threshold float64
+	var matrix bool
+	cmd := &cobra.Command{
+		Use:   "similarity",
+		Short: "Group the sampled managers of a league into clusters of similar squads in a gameweek",
+		Long: `Group the sampled managers of a league into clusters of similar squads in a gameweek, with the template of
+each cluster and the manager closest to the others. Squads are compared with the Jaccard similarity of their picks,
+captains weighing double.`,
+		Example: `  fpl similarity -l 313 -g 5
+  fpl similarity -l 313 --threshold 0.7
+  fpl similarity --cohort creators --matrix -f csv`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.requireLeagueOrCohort(); err != nil {
+				return err
+			}
+			if threshold < 0 || threshold > 1 {
+				return usageErrorf("--threshold should be between 0 and 1")
+			}
+			return o.run(func(ctx context.Context, fpl *sdk.Client) error {
+				if gameweek <= 0 {
+					latest, err := o.latestGameweek(ctx, fpl)
+					if err != nil {
+						return err
+					}
+					gameweek = latest
+				}
+				opts := o.requestOptions()
+				if cmd.Flags().Changed("threshold") {
+					opts = append(opts, sdk.WithThreshold(threshold))
+				}
+				similarity, err := fpl.Similarity(ctx, o.league, gameweek, opts...)
+				if err != nil {
+					return err
+				}
+				if matrix {
+					return o.write(cmd, similarityMatrixResult(similarity))
+				}
+				return o.write(cmd, clustersResult(similarity))
+			})
+		},
+	}
+	cmd.Flags().IntVarP(&gameweek, "gameweek", "g", 0, "Gameweek, the latest one when 0")
+	cmd.Flags().Float64Var(&threshold, "threshold", 0, "Average similarity the managers of a cluster have at least, the server default when not set")
+	cmd.Flags().BoolVar(&matrix, "matrix", false, "Show the similarity of every pair of managers rather than the clusters")
+	return cmd
+}
+
+func newDiffCommand(o *globalOptions) *cobra.Command {
+	var from, to int
+	cmd := &cobra.Command{
+		Use:   "diff",
+		Short: "Show how the ownership of players in a league changed between two gameweeks",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.requireLeagueOrCohort(); err != nil {
+				return err
+			}
+			if from < 1 || to < 1 || from > maxGameweek || to > maxGameweek || from == to {
+				return usageErrorf("--from and --to should be two different gameweeks between 1 and %v", maxGameweek)
+			}
+			return o.run(func(ctx context.Context, fpl *sdk.Client) error {
+				before, err := fpl.Gameweek(ctx, o.league, from, o.requestOptions()...)
+				if err != nil {
+					return err
+				}
+				after, err := fpl.Gameweek(ctx, o.league, to, o.requestOptions()...)
+				if err != nil {
+					return err
+				}
+				return o.write(cmd, diffResult(before, after))
+			})
+		},
+	}
+	cmd.Flags().IntVar(&from, "from", 0, "Gameweek to compare from")
+	cmd.Flags().IntVar(&to, "to", 0, "Gameweek to compare to")
+	return cmd
+}
+
+func newExportCommand(o *globalOptions) *cobra.Command {
+	var sortBy string
+	var selection gameweekFlags
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export the ownership of every player of a league in every gameweek, as the server writes it",
+		Long: `Export the ownership of every player of a league in every gameweek, as the server writes it.
+--format is one of ` + strings.Join(exportFormats, ", ") + `, table exporting CSV.
+--sort is one of ` + strings.Join(exportSorts, ", ") + `.`,
+		Example: `  fpl export -l 313 -o league-313.csv
+  fpl export -l 313 -f xlsx -o league-313.xlsx
+  fpl export -l 313 --sort latest
+  fpl export -l 313 --last 5 -f json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.requireLeagueOrCohort(); err != nil {
+				return err
+			}
+			format, err := exportFormat(o.format)
+			if err != nil {
+				return err
+			}
+			order, err := exportSort(sortBy)
+			if err != nil {
+				return err
+			}
+			opts, err := selection.requestOptions(o)
+			if err != nil {
+				return err
+			}
+			return o.connect(func(ctx context.Context, fpl *sdk.Client) error {
+				//The export is written as the server sends it, without holding it in memory
+				return o.writeWith(cmd, func(w io.Writer) error {
+					_, err := fpl.Export(ctx, o.league, format, w, append(opts, sdk.WithSort(order))...)
+					return err
+				})
+			})
+		},
+	}
+	cmd.Flags().StringVar(&sortBy, "sort", "total", "Order of the players, by their total, latest or peak ownership")
+	selection.register(cmd, "export")
+	return cmd
+}
+
+func newHeatmapCommand(o *globalOptions) *cobra.Command {
+	var top int
+	cmd := &cobra.Command{
+		Use:   "heatmap",
+		Short: "Render the ownership of the most owned players of a league over every gameweek as an image",
+		Long: `Render the ownership of the most owned players of a league over every gameweek as an image.
+--format is one of ` + strings.Join(imageFormats, ", ") + `, table rendering PNG.`,
+		Example: `  fpl heatmap -l 313 -o league-313.png
+  fpl heatmap -l 313 --top 30 -f svg -o league-313.svg`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if err := o.requireLeagueOrCohort(); err != nil {
+				return err
+			}
+			if top < 0 {
+				return usageErrorf("--top should not be negative")
+			}
+			format, err := imageFormat(o.format)
+			if err != nil {
+				return err
+			}
+			return o.connect(func(ctx context.Context, fpl *sdk.Client) error {
+				heatmap, err := fpl.Heatmap(ctx, o.league, format, top, o.requestOptions()...)
+				if err != nil {
+					return err
+				}
+				return o.writeWith(cmd, func(w io.Writer) error {
+					_, err := w.Write(heatmap)
+					return err
+				})
+			})
+		},
+	}
+	cmd.Flags().IntVar(&top, "top", 20, "Number of players in the heatmap, every player when 0")
+	return cmd
+}
+
+func newCompareCommand(o *globalOptions) *cobra.Command {
+	var selection gameweekFlags
+	cmd := &cobra.Command{
+		Use:   "compare LEAGUE LEAGUE...",
+		Short: "Compare the ownership of players among the top participants of several leagues",
+		Long: `Compare the ownership of players among the top participants of several leagues, side by side in every
+gameweek. Shares are the percentage of the sample of each league owning a player, and deltas the difference to the
+first league in percentage points.`,
+		Example: `  fpl compare 313 1234
+  fpl compare 313 1234 5678 --last 3 -f csv`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 2 {
+				return usageErrorf("at least two leagues should be compared")
+			}
+			leagueCodes := make([]int, len(args))
+			for i, arg := range args {
+				leagueCode, err := strconv.Atoi(arg)
+				if err != nil || leagueCode <= 0 {
+					return usageErrorf("invalid league %q", arg)
+				}
+				leagueCodes[i] = leagueCode
+			}
+			opts, err := selection.requestOptions(o)
+			if err != nil {
+				return err
+			}
+			return o.run(func(ctx context.Context, fpl *sdk.Client) error {
+				comparison, err := fpl.CompareLeagues(ctx, leagueCodes, opts...)
+				if err != nil {
+					return err
+				}
+				return o.write(cmd, comparisonResult(comparison))
+			})
+		},
+	}
+	selection.register(cmd, "compare")
+	return cmd
+}
+
+func ownershipResult(ownership []sdk.PlayerOwnership) *result {
+	r := &result{header: []string{"player", "owners"}, value: ownership}
+	for _, player := range ownership {
+		r.rows = append(r.rows, []string{player.Player, strconv.Itoa(player.Owners)})
+	}
+	return r
+}
+
+func matrixResult(matrix *sdk.OwnershipMatrix) *result {
+	r := &result{header: []string{"player"}, value: matrix}
+	for _, gameweek := range matrix.Gameweeks {
+		r.header = append(r.header, "gameweek "+strconv.Itoa(gameweek))
+	}
+	for _, player := range matrix.Players {
+		row := []string{player}
+		for _, owners := range matrix.Owners[player] {
+			row = append(row, strconv.Itoa(owners))
+		}
+		r.rows = append(r.rows, row)
+	}
+	return r
+}
+
+//playerChange is how the ownership of a player changed between two gameweeks
+type playerChange struct {
+	Player string `json:"player"`
+	Before int    `json:"before"`
+	After  int    `json:"after"`
+	Change int    `json:"change"`
+}
+
+//diffResult lists the players whose ownership changed, the biggest risers first
+func diffResult(before, after []sdk.PlayerOwnership) *result {
+	owners := make(map[string]*playerChange)
+	for _, player := range before {
+		owners[player.Player] = &playerChange{Player: player.Player, Before: player.Owners}
+	}
+	for _, player := range after {
+		if _, ok := owners[player.Player]; !ok {
+			owners[player.Player] = &playerChange{Player: player.Player}
+		}
+		owners[player.Player].After = player.Owners
+	}
+
+	changes := []playerChange{}
+	for _, change := range owners {
+		change.Change = change.After - change.Before
+		if change.Change != 0 {
+			changes = append(changes, *change)
+		}
+	}
+	sort.Slice(changes, func(i, j int) bool {
+		if changes[i].Change != changes[j].Change {
+			return changes[i].Change > changes[j].Change
+		}
+		return changes[i].Player < changes[j].Player
+	})
+
+	r := &result{header: []string{"player", "before", "after", "change"}, value: changes}
+	for _, change := range changes {
+		r.rows = append(r.rows, []string{change.Player, strconv.Itoa(change.Before), strconv.Itoa(change.After), strconv.Itoa(change.Change)})
+	}
+	return r
+}
+
+//comparisonResult has a row for every player owned in a gameweek, with the owners and share of each league and the
+//delta of the leagues after the first one
+func comparisonResult(comparison *sdk.LeagueComparison) *result {
+	r := &result{header: []string{"gameweek", "player"}, value: comparison}
+	for i, leagueCode := range comparison.LeagueCodes {
+		league := strconv.Itoa(leagueCode)
+		r.header = append(r.header, "owners "+league, "share "+league)
+		if i > 0 {
+			r.header = append(r.header, "delta "+league)
+		}
+	}
+	for _, gameweek := range comparison.Gameweeks {
+		for _, player := range gameweek.Players {
+			row := []string{strconv.Itoa(gameweek.Gameweek), player.Player}
+			for i := range player.Owners {
+				row = append(row, strconv.Itoa(player.Owners[i]), fmt.Sprintf("%.2f", player.Percentages[i]))
+				if i > 0 {
+					row = append(row, fmt.Sprintf("%+.2f", player.Deltas[i]))
+				}
+			}
+			r.rows = append(r.rows, row)
+		}
+	}
+	return r
+}
+
+//clustersResult has a row for every cluster of managers, the biggest first
+func clustersResult(similarity *sdk.Similarity) *result {
+	r := &result{header: []string{"cluster", "managers", "representative", "cohesion", "template"}, value: similarity}
+	for i, cluster := range similarity.Clusters {
+		r.rows = append(r.rows, []string{
+			strconv.Itoa(i + 1),
+			joinEntries(cluster.Entries),
+			strconv.FormatInt(cluster.Representative, 10),
+			strconv.FormatFloat(cluster.Cohesion, 'f', 2, 64),
+			strings.Join(cluster.Template, " "),
+		})
+	}
+	return r
+}
+
+//similarityMatrixResult has a row and a column for every manager, with the similarity of their squads
+func similarityMatrixResult(similarity *sdk.Similarity) *result {
+	r := &result{header: []string{"entry"}, value: similarity}
+	for _, entry := range similarity.Entries {
+		r.header = append(r.header, strconv.FormatInt(entry, 10))
+	}
+	for i, entry := range similarity.Entries {
+		row := []string{strconv.FormatInt(entry, 10)}
+		for _, value := range similarity.Matrix[i] {
+			row = append(row, strconv.FormatFloat(value, 'f', 2, 64))
+		}
+		r.rows = append(r.rows, row)
+	}
+	return r
+}
@@ -0,0 +1,55 @@
+package cli
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-fantasy/fpl/sdk"
+)
+
+//Exit codes of the fpl command, by type of error
+const (
+	ExitOK              = 0
+	ExitError           = 1
+	ExitUsage           = 2
+	ExitInvalidArgument = 3
+	ExitNotFound        = 4
+	ExitUnauthorized    = 5
+	ExitQuotaExceeded   = 6
+	ExitUnavailable     = 7
+)
+
+//usageError is returned for invalid flags and arguments
+type usageError struct {
+	message string
+}
+
+func (u *usageError) Error() string {
+	return u.message
+}
+
+func usageErrorf(format string, args ...interface{}) error {
+	return &usageError{message: fmt.Sprintf(format, args...)}
+}
+
+//ExitCode returns the exit code of the fpl command when it failed with err
+func ExitCode(err error) int {
+	var usage *usageError
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.As(err, &usage):
+		return ExitUsage
+	case errors.Is(err, sdk.ErrInvalidArgument):
+		return ExitInvalidArgument
+	case errors.Is(err, sdk.ErrNotFound):
+		return ExitNotFound
+	case errors.Is(err, sdk.ErrUnauthenticated), errors.Is(err, sdk.ErrPermissionDenied):
+		return ExitUnauthorized
+	case errors.Is(err, sdk.ErrResourceExhausted):
+		return ExitQuotaExceeded
+	case errors.Is(err, sdk.ErrUnavailable), errors.Is(err, sdk.ErrDeadlineExceeded):
+		return ExitUnavailable
+	}
+	return ExitError
+}
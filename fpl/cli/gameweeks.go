@@ -0,0 +1,74 @@
+package cli
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/go-fantasy/fpl/sdk"
+	"github.com/spf13/cobra"
+)
+
+//maxGameweek is the last gameweek of a season
+const maxGameweek = 38
+
+//parseGameweeks parses gameweeks like 5, 1-5 or 1-3,7,9-10, returning them in order and without duplicates
+func parseGameweeks(value string) ([]int, error) {
+	seen := make(map[int]bool)
+	var gameweeks []int
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		from, to := part, part
+		if i := strings.Index(part, "-"); i > 0 {
+			from, to = part[:i], part[i+1:]
+		}
+		first, errFirst := strconv.Atoi(strings.TrimSpace(from))
+		last, errLast := strconv.Atoi(strings.TrimSpace(to))
+		if errFirst != nil || errLast != nil || first > last {
+			return nil, usageErrorf("invalid gameweeks %q, use a gameweek like 5, a range like 1-5 or a list like 1-3,7", part)
+		}
+		if first < 1 || last > maxGameweek {
+			return nil, usageErrorf("gameweeks %q should be between 1 and %v", part, maxGameweek)
+		}
+		for gameweek := first; gameweek <= last; gameweek++ {
+			if !seen[gameweek] {
+				seen[gameweek] = true
+				gameweeks = append(gameweeks, gameweek)
+			}
+		}
+	}
+	sort.Ints(gameweeks)
+	return gameweeks, nil
+}
+
+//gameweekFlags select the gameweeks of the commands going through every gameweek, which the server then scrapes alone
+type gameweekFlags struct {
+	gameweeks string
+	last      int
+}
+
+func (f *gameweekFlags) register(cmd *cobra.Command, verb string) {
+	cmd.Flags().StringVarP(&f.gameweeks, "gameweeks", "g", "", fmt.Sprintf("Gameweeks to %v, like 1-5 or 1-3,7, all of them when empty", verb))
+	cmd.Flags().IntVar(&f.last, "last", 0, fmt.Sprintf("Number of gameweeks to %v, up to the current one", verb))
+}
+
+//requestOptions are the options of the calls made for the flags of o and the selected gameweeks
+func (f *gameweekFlags) requestOptions(o *globalOptions) ([]sdk.RequestOption, error) {
+	opts := o.requestOptions()
+	switch {
+	case f.gameweeks != "" && f.last != 0:
+		return nil, usageErrorf("--gameweeks and --last can't be used together")
+	case f.last < 0 || f.last > maxGameweek:
+		return nil, usageErrorf("--last should be between 1 and %v", maxGameweek)
+	case f.last > 0:
+		return append(opts, sdk.WithLastGameweeks(f.last)), nil
+	case f.gameweeks != "":
+		selected, err := parseGameweeks(f.gameweeks)
+		if err != nil {
+			return nil, err
+		}
+		return append(opts, sdk.WithGameweeks(selected...)), nil
+	}
+	return opts, nil
+}
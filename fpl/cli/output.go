@@ -0,0 +1,103 @@
+package cli
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+)
+
+const (
+	formatTable = "table"
+	formatCSV   = "csv"
+	formatJSON  = "json"
+)
+
+var formats = []string{formatTable, formatCSV, formatJSON}
+
+//exportFormats are the formats of the export command, which the server writes
+var exportFormats = []string{"csv", "json", "ndjson", "markdown", "xlsx", "parquet"}
+
+//exportSorts are the orders the export command can ask for
+var exportSorts = []string{"total", "latest", "peak"}
+
+//imageFormats are the formats of the heatmap command
+var imageFormats = []string{"png", "svg"}
+
+func imageFormat(format string) (grpc_fpl.ImageFormat, error) {
+	if format == formatTable {
+		return grpc_fpl.ImageFormat_PNG, nil
+	}
+	for _, f := range imageFormats {
+		if format == f {
+			return grpc_fpl.ImageFormat(grpc_fpl.ImageFormat_value[strings.ToUpper(f)]), nil
+		}
+	}
+	return 0, usageErrorf("invalid image format %q, it should be one of %v", format, strings.Join(imageFormats, ", "))
+}
+
+func exportFormat(format string) (grpc_fpl.ExportFormat, error) {
+	if format == formatTable {
+		return grpc_fpl.ExportFormat_CSV, nil
+	}
+	for _, f := range exportFormats {
+		if format == f {
+			return grpc_fpl.ExportFormat(grpc_fpl.ExportFormat_value[strings.ToUpper(f)]), nil
+		}
+	}
+	return 0, usageErrorf("invalid export format %q, it should be one of %v", format, strings.Join(exportFormats, ", "))
+}
+
+func exportSort(sort string) (grpc_fpl.OwnershipSort, error) {
+	for _, s := range exportSorts {
+		if sort == s {
+			return grpc_fpl.OwnershipSort(grpc_fpl.OwnershipSort_value[strings.ToUpper(s)]), nil
+		}
+	}
+	return 0, usageErrorf("invalid sort %q, it should be one of %v", sort, strings.Join(exportSorts, ", "))
+}
+
+func checkFormat(format string) error {
+	for _, f := range formats {
+		if format == f {
+			return nil
+		}
+	}
+	return usageErrorf("invalid format %q, it should be one of %v", format, strings.Join(formats, ", "))
+}
+
+//result is what a command outputs, as rows under a header for tables and CSV, and as value for JSON
+type result struct {
+	header []string
+	rows   [][]string
+	value  interface{}
+}
+
+func (r *result) write(w io.Writer, format string) error {
+	switch format {
+	case formatJSON:
+		encoder := json.NewEncoder(w)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(r.value)
+	case formatCSV:
+		writer := csv.NewWriter(w)
+		if err := writer.Write(r.header); err != nil {
+			return err
+		}
+		if err := writer.WriteAll(r.rows); err != nil {
+			return err
+		}
+		return writer.Error()
+	default:
+		writer := tabwriter.NewWriter(w, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(writer, strings.ToUpper(strings.Join(r.header, "\t")))
+		for _, row := range r.rows {
+			fmt.Fprintln(writer, strings.Join(row, "\t"))
+		}
+		return writer.Flush()
+	}
+}
@@ -0,0 +1,153 @@
+//Package cli is the fpl command line tool, calling the FPL server through the sdk package
+package cli
+
+import (
+	"context"
+	"io"
+	"os"
+	"time"
+
+	"github.com/go-fantasy/fpl/client"
+	"github.com/go-fantasy/fpl/sdk"
+	"github.com/spf13/cobra"
+)
+
+//globalOptions are the flags shared by every command
+type globalOptions struct {
+	address    string
+	ca         string
+	cert       string
+	key        string
+	serverName string
+	apiKey     string
+	timeout    time.Duration
+
+	league     int
+	cohort     string
+	sampleSize int
+	output     string
+	format     string
+}
+
+//NewCommand creates the fpl command with all its subcommands
+func NewCommand() *cobra.Command {
+	o := &globalOptions{}
+	root := &cobra.Command{
+		Use:           "fpl",
+		Short:         "Query the FPL server for the players owned in fantasy premier league leagues",
+		SilenceUsage:  true,
+		SilenceErrors: true,
+	}
+	root.SetFlagErrorFunc(func(cmd *cobra.Command, err error) error {
+		return usageErrorf("%v", err)
+	})
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&o.address, "address", client.DefaultAddress, "Address of the FPL server, host:port")
+	flags.StringVar(&o.ca, "ca", "", "CA bundle verifying the server certificate, connects over TLS when set")
+	flags.StringVar(&o.cert, "cert", "", "Client certificate for servers requiring mutual TLS")
+	flags.StringVar(&o.key, "key", "", "Key of the client certificate")
+	flags.StringVar(&o.serverName, "server-name", "", "Name the server certificate is issued for, when it isn't the host")
+	flags.StringVar(&o.apiKey, "api-key", os.Getenv("FPL_API_KEY"), "API key, for servers requiring one (default $FPL_API_KEY)")
+	flags.DurationVar(&o.timeout, "timeout", 5*time.Minute, "How long a command can take, scraping every gameweek of a league takes a while")
+	flags.IntVarP(&o.league, "league", "l", 0, "League code")
+	flags.StringVarP(&o.cohort, "cohort", "c", "", "Cohort of entries defined on the server, looked at instead of a league")
+	flags.IntVar(&o.sampleSize, "sample-size", 0, "Number of top participants of the league to look at, the server default when 0")
+	flags.StringVarP(&o.output, "output", "o", "", "File to write to, stdout when empty")
+	flags.StringVarP(&o.format, "format", "f", formatTable, "Output format, one of table, csv or json, see export and heatmap for theirs")
+	root.RegisterFlagCompletionFunc("format", func(cmd *cobra.Command, _ []string, _ string) ([]string, cobra.ShellCompDirective) {
+		switch cmd.Name() {
+		case "export":
+			return exportFormats, cobra.ShellCompDirectiveNoFileComp
+		case "heatmap":
+			return imageFormats, cobra.ShellCompDirectiveNoFileComp
+		}
+		return formats, cobra.ShellCompDirectiveNoFileComp
+	})
+
+	root.AddCommand(
+		newPlayersCommand(o),
+		newLeagueCommand(o),
+		newGameweekCommand(o),
+		newAllGameweeksCommand(o),
+		newTemplateCommand(o),
+		newDiffCommand(o),
+		newCompareCommand(o),
+		newCohortCommand(o),
+		newSimilarityCommand(o),
+		newExportCommand(o),
+		newHeatmapCommand(o),
+	)
+	return root
+}
+
+//run checks the output format, then runs f like connect
+func (o *globalOptions) run(f func(ctx context.Context, fpl *sdk.Client) error) error {
+	if err := checkFormat(o.format); err != nil {
+		return err
+	}
+	return o.connect(f)
+}
+
+//connect connects to the server and runs f with a context ending after the timeout
+func (o *globalOptions) connect(f func(ctx context.Context, fpl *sdk.Client) error) error {
+	options := []client.Option{
+		client.WithAddress(o.address),
+		client.WithRetry(client.DefaultRetryPolicy),
+	}
+	if o.ca != "" {
+		options = append(options, client.WithCA(o.ca))
+	}
+	if o.cert != "" {
+		options = append(options, client.WithClientCertificate(o.cert, o.key))
+	}
+	if o.serverName != "" {
+		options = append(options, client.WithServerName(o.serverName))
+	}
+	if o.apiKey != "" {
+		options = append(options, client.WithAPIKey(o.apiKey))
+	}
+	fpl, err := sdk.New(options...)
+	if err != nil {
+		return err
+	}
+	defer fpl.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), o.timeout)
+	defer cancel()
+	return f(ctx, fpl)
+}
+
+//requestOptions are the options of the calls made for the flags
+func (o *globalOptions) requestOptions() []sdk.RequestOption {
+	var opts []sdk.RequestOption
+	if o.sampleSize > 0 {
+		opts = append(opts, sdk.WithSampleSize(o.sampleSize))
+	}
+	if o.cohort != "" {
+		opts = append(opts, sdk.WithCohort(o.cohort))
+	}
+	return opts
+}
+
+//write writes the result of a command to the output file, or to the output of cmd
+func (o *globalOptions) write(cmd *cobra.Command, result *result) error {
+	return o.writeWith(cmd, func(w io.Writer) error {
+		return result.write(w, o.format)
+	})
+}
+
+func (o *globalOptions) writeWith(cmd *cobra.Command, write func(io.Writer) error) error {
+	if o.output == "" {
+		return write(cmd.OutOrStdout())
+	}
+	file, err := os.Create(o.output)
+	if err != nil {
+		return err
+	}
+	if err := write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -1,19 +1,52 @@
 package client
 
 import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/json"
 	"fmt"
+	"io/ioutil"
+	"strconv"
+	"time"
 
 	grpc_fpl "github.com/go-fantasy/fpl/grpc"
-	"github.com/spf13/viper"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 )
 
-//New creates a new FPL client object
-func New() (grpc_fpl.FPLClient, func(), error) {
-	port := viper.GetString("port")
-	conn, err := grpc.Dial(fmt.Sprintf("localhost:%v", port), grpc.WithInsecure())
+const (
+	//DefaultAddress is the address of the server when WithAddress isn't given
+	DefaultAddress = "localhost:50051"
+	//apiKeyHeader is the metadata key servers read the API key from
+	apiKeyHeader = "x-api-key"
+	//serviceName is the name of the FPL service in the service config
+	serviceName = "grpc.FPL"
+)
+
+//New creates a new FPL client object, connected to DefaultAddress without TLS unless options say otherwise.
+//The returned function closes the connection
+func New(opts ...Option) (grpc_fpl.FPLClient, func(), error) {
+	o := options{address: DefaultAddress}
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	dialOptions, err := o.dialOptionList()
 	if err != nil {
-		return nil, nil, fmt.Errorf("error while connecting to gRPC server at port %v: %v", port, err)
+		return nil, nil, err
+	}
+
+	ctx := context.Background()
+	if o.dialTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, o.dialTimeout)
+		defer cancel()
+		dialOptions = append(dialOptions, grpc.WithBlock())
+	}
+	conn, err := grpc.DialContext(ctx, o.address, dialOptions...)
+	if err != nil {
+		return nil, nil, fmt.Errorf("error while connecting to gRPC server at %v: %v", o.address, err)
 	}
 
 	client := grpc_fpl.NewFPLClient(conn)
@@ -25,3 +58,136 @@ func New() (grpc_fpl.FPLClient, func(), error) {
 	}
 	return client, cleanup, nil
 }
+
+func (o *options) dialOptionList() ([]grpc.DialOption, error) {
+	transport := grpc.WithInsecure()
+	if o.tls {
+		config, err := o.tlsConfig()
+		if err != nil {
+			return nil, err
+		}
+		transport = grpc.WithTransportCredentials(credentials.NewTLS(config))
+	}
+	dialOptions := []grpc.DialOption{transport}
+
+	if o.apiKey != "" {
+		dialOptions = append(dialOptions, grpc.WithPerRPCCredentials(apiKeyCredentials{key: o.apiKey, secure: o.tls}))
+	}
+	if o.keepalive != nil {
+		dialOptions = append(dialOptions, grpc.WithKeepaliveParams(*o.keepalive))
+	}
+	if o.retry != nil {
+		serviceConfig, err := o.retry.serviceConfig()
+		if err != nil {
+			return nil, err
+		}
+		dialOptions = append(dialOptions, grpc.WithDefaultServiceConfig(serviceConfig))
+	}
+
+	unaryInterceptors := o.unaryInterceptors
+	if o.callTimeout > 0 {
+		unaryInterceptors = append([]grpc.UnaryClientInterceptor{callTimeoutInterceptor(o.callTimeout)}, unaryInterceptors...)
+	}
+	if len(unaryInterceptors) > 0 {
+		dialOptions = append(dialOptions, grpc.WithChainUnaryInterceptor(unaryInterceptors...))
+	}
+	if len(o.streamInterceptors) > 0 {
+		dialOptions = append(dialOptions, grpc.WithChainStreamInterceptor(o.streamInterceptors...))
+	}
+	return append(dialOptions, o.dialOptions...), nil
+}
+
+func (o *options) tlsConfig() (*tls.Config, error) {
+	config := &tls.Config{
+		ServerName: o.serverName,
+		MinVersion: tls.VersionTLS12,
+	}
+	if o.caFile != "" {
+		pem, err := ioutil.ReadFile(o.caFile)
+		if err != nil {
+			return nil, fmt.Errorf("error reading CA bundle %v: %v", o.caFile, err)
+		}
+		config.RootCAs = x509.NewCertPool()
+		if !config.RootCAs.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("no certificate found in CA bundle %v", o.caFile)
+		}
+	}
+	if o.certFile != "" || o.keyFile != "" {
+		certificate, err := tls.LoadX509KeyPair(o.certFile, o.keyFile)
+		if err != nil {
+			return nil, fmt.Errorf("error loading client certificate %v: %v", o.certFile, err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+	return config, nil
+}
+
+//serviceConfig returns the service config retrying calls to the FPL service with the policy
+func (r RetryPolicy) serviceConfig() (string, error) {
+	if r.MaxAttempts < 2 || r.InitialBackoff <= 0 || r.MaxBackoff <= 0 || r.BackoffMultiplier <= 0 || len(r.Codes) == 0 {
+		return "", fmt.Errorf("invalid retry policy %+v, it needs at least 2 attempts, backoffs, a multiplier and codes to retry", r)
+	}
+	type retryPolicy struct {
+		MaxAttempts          int      `json:"maxAttempts"`
+		InitialBackoff       string   `json:"initialBackoff"`
+		MaxBackoff           string   `json:"maxBackoff"`
+		BackoffMultiplier    float64  `json:"backoffMultiplier"`
+		RetryableStatusCodes []uint32 `json:"retryableStatusCodes"`
+	}
+	type methodConfig struct {
+		Name        []map[string]string `json:"name"`
+		RetryPolicy retryPolicy         `json:"retryPolicy"`
+	}
+
+	policy := retryPolicy{
+		MaxAttempts:       r.MaxAttempts,
+		InitialBackoff:    seconds(r.InitialBackoff),
+		MaxBackoff:        seconds(r.MaxBackoff),
+		BackoffMultiplier: r.BackoffMultiplier,
+	}
+	for _, code := range r.Codes {
+		policy.RetryableStatusCodes = append(policy.RetryableStatusCodes, uint32(code))
+	}
+	serviceConfig, err := json.Marshal(map[string][]methodConfig{
+		"methodConfig": {{
+			Name:        []map[string]string{{"service": serviceName}},
+			RetryPolicy: policy,
+		}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("error creating service config: %v", err)
+	}
+	return string(serviceConfig), nil
+}
+
+//seconds formats d the way durations are written in service configs
+func seconds(d time.Duration) string {
+	return strconv.FormatFloat(d.Seconds(), 'f', -1, 64) + "s"
+}
+
+//callTimeoutInterceptor gives unary calls without a deadline one of timeout
+func callTimeoutInterceptor(timeout time.Duration) grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		if _, ok := ctx.Deadline(); !ok {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, timeout)
+			defer cancel()
+		}
+		return invoker(ctx, method, req, reply, cc, opts...)
+	}
+}
+
+//apiKeyCredentials adds the API key to the metadata of every call
+type apiKeyCredentials struct {
+	key    string
+	secure bool
+}
+
+func (a apiKeyCredentials) GetRequestMetadata(context.Context, ...string) (map[string]string, error) {
+	return map[string]string{apiKeyHeader: a.key}, nil
+}
+
+//RequireTransportSecurity lets keys be sent without TLS when the client doesn't use it, like to a server on localhost
+func (a apiKeyCredentials) RequireTransportSecurity() bool {
+	return a.secure
+}
@@ -0,0 +1,124 @@
+package client_test
+
+import (
+	"context"
+	"net"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-fantasy/fpl/client"
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/go-fantasy/fpl/mock"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//serve serves fplServer on a random local port, returning its address and a function stopping the server
+func serve(t *testing.T, fplServer grpc_fpl.FPLServer) (string, func()) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.Nil(t, err)
+	grpcServer := grpc.NewServer()
+	grpc_fpl.RegisterFPLServer(grpcServer, fplServer)
+	go grpcServer.Serve(lis)
+	return lis.Addr().String(), grpcServer.Stop
+}
+
+func TestRetry(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testObj := mock_server.NewMockFPLServer(mockCtrl)
+
+	var calls int32
+	testObj.EXPECT().GetNumberOfPlayers(gomock.Any(), gomock.Any()).DoAndReturn(func(context.Context, *grpc_fpl.NumPlayerRequest) (*grpc_fpl.NumPlayers, error) {
+		if atomic.AddInt32(&calls, 1)%3 != 0 {
+			return nil, status.Errorf(codes.Unavailable, "try again")
+		}
+		return &grpc_fpl.NumPlayers{NumPlayers: 500}, nil
+	}).AnyTimes()
+	address, stop := serve(t, testObj)
+	defer stop()
+
+	grpcClient, cleanup, err := client.New(client.WithAddress(address), client.WithRetry(client.DefaultRetryPolicy))
+	require.Nil(t, err)
+	defer cleanup()
+	numPlayers, err := grpcClient.GetNumberOfPlayers(context.Background(), &grpc_fpl.NumPlayerRequest{})
+	if assert.Nil(t, err, "Error %v was supposed to be nil ", err) {
+		assert.Equal(t, int64(500), numPlayers.NumPlayers)
+	}
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls), "The call should have been retried twice")
+
+	grpcClient, cleanup, err = client.New(client.WithAddress(address))
+	require.Nil(t, err)
+	defer cleanup()
+	_, err = grpcClient.GetNumberOfPlayers(context.Background(), &grpc_fpl.NumPlayerRequest{})
+	assert.Equal(t, codes.Unavailable, status.Code(err), "Calls shouldn't be retried by default")
+
+	_, _, err = client.New(client.WithAddress(address), client.WithRetry(client.RetryPolicy{MaxAttempts: 3}))
+	assert.NotNil(t, err, "A retry policy without backoffs or codes is invalid")
+}
+
+func TestCallTimeout(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testObj := mock_server.NewMockFPLServer(mockCtrl)
+	testObj.EXPECT().GetNumberOfPlayers(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, req *grpc_fpl.NumPlayerRequest) (*grpc_fpl.NumPlayers, error) {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}).AnyTimes()
+	address, stop := serve(t, testObj)
+	defer stop()
+
+	grpcClient, cleanup, err := client.New(client.WithAddress(address), client.WithCallTimeout(time.Millisecond*50))
+	require.Nil(t, err)
+	defer cleanup()
+
+	start := time.Now()
+	_, err = grpcClient.GetNumberOfPlayers(context.Background(), &grpc_fpl.NumPlayerRequest{})
+	assert.Equal(t, codes.DeadlineExceeded, status.Code(err))
+	assert.True(t, time.Since(start) < time.Second*5, "The call should have been given the default timeout")
+}
+
+func TestInterceptors(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testObj := mock_server.NewMockFPLServer(mockCtrl)
+	testObj.EXPECT().GetNumberOfPlayers(gomock.Any(), gomock.Any()).Return(&grpc_fpl.NumPlayers{NumPlayers: 1}, nil)
+	address, stop := serve(t, testObj)
+	defer stop()
+
+	var methods []string
+	record := func(name string) grpc.UnaryClientInterceptor {
+		return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+			methods = append(methods, name+" "+method)
+			return invoker(ctx, method, req, reply, cc, opts...)
+		}
+	}
+
+	grpcClient, cleanup, err := client.New(client.WithAddress(address), client.WithUnaryInterceptors(record("first"), record("second")))
+	require.Nil(t, err)
+	defer cleanup()
+	_, err = grpcClient.GetNumberOfPlayers(context.Background(), &grpc_fpl.NumPlayerRequest{})
+	assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, []string{"first /grpc.FPL/getNumberOfPlayers", "second /grpc.FPL/getNumberOfPlayers"}, methods)
+}
+
+func TestDialTimeout(t *testing.T) {
+	lis, err := net.Listen("tcp", "localhost:0")
+	require.Nil(t, err)
+	address := lis.Addr().String()
+	lis.Close()
+
+	start := time.Now()
+	_, _, err = client.New(client.WithAddress(address), client.WithDialTimeout(time.Millisecond*100))
+	assert.NotNil(t, err, "Connecting to a closed port should fail")
+	assert.True(t, time.Since(start) < time.Second*5)
+
+	_, cleanup, err := client.New(client.WithAddress(address))
+	assert.Nil(t, err, "Without a dial timeout, the connection should be made in the background")
+	cleanup()
+}
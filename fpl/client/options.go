@@ -0,0 +1,148 @@
+package client
+
+import (
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/keepalive"
+)
+
+//Option configures the connection made by New
+type Option func(*options)
+
+type options struct {
+	address string
+
+	tls        bool
+	caFile     string
+	certFile   string
+	keyFile    string
+	serverName string
+	apiKey     string
+
+	dialTimeout        time.Duration
+	callTimeout        time.Duration
+	keepalive          *keepalive.ClientParameters
+	retry              *RetryPolicy
+	unaryInterceptors  []grpc.UnaryClientInterceptor
+	streamInterceptors []grpc.StreamClientInterceptor
+	dialOptions        []grpc.DialOption
+}
+
+//RetryPolicy retries calls failing with one of Codes, up to MaxAttempts attempts in total, waiting a random
+//backoff between attempts that starts under InitialBackoff and grows by BackoffMultiplier up to MaxBackoff
+type RetryPolicy struct {
+	MaxAttempts       int
+	InitialBackoff    time.Duration
+	MaxBackoff        time.Duration
+	BackoffMultiplier float64
+	Codes             []codes.Code
+}
+
+//DefaultRetryPolicy retries calls that failed because the server couldn't be reached, up to 4 attempts
+var DefaultRetryPolicy = RetryPolicy{
+	MaxAttempts:       4,
+	InitialBackoff:    100 * time.Millisecond,
+	MaxBackoff:        time.Second,
+	BackoffMultiplier: 2,
+	Codes:             []codes.Code{codes.Unavailable},
+}
+
+//WithAddress connects to the server at address, host:port, rather than DefaultAddress
+func WithAddress(address string) Option {
+	return func(o *options) {
+		o.address = address
+	}
+}
+
+//WithCA connects over TLS, trusting the server certificate only when it is signed by a CA of the PEM bundle
+//caFile. The system roots are trusted when TLS is enabled by another option without a CA bundle
+func WithCA(caFile string) Option {
+	return func(o *options) {
+		o.tls = true
+		o.caFile = caFile
+	}
+}
+
+//WithClientCertificate connects over TLS, presenting the certificate in certFile to servers requiring mutual TLS
+func WithClientCertificate(certFile, keyFile string) Option {
+	return func(o *options) {
+		o.tls = true
+		o.certFile = certFile
+		o.keyFile = keyFile
+	}
+}
+
+//WithServerName connects over TLS, expecting the server certificate to be issued for name rather than the host
+//of the address
+func WithServerName(name string) Option {
+	return func(o *options) {
+		o.tls = true
+		o.serverName = name
+	}
+}
+
+//WithAPIKey sends key with every call, for servers requiring API keys
+func WithAPIKey(key string) Option {
+	return func(o *options) {
+		o.apiKey = key
+	}
+}
+
+//WithDialTimeout makes New wait up to timeout for the connection to the server, and fail when it can't connect.
+//By default New returns straight away and connects in the background
+func WithDialTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.dialTimeout = timeout
+	}
+}
+
+//WithCallTimeout sets the deadline of unary calls made without one. Streams aren't given a deadline,
+//as some of them never end on their own
+func WithCallTimeout(timeout time.Duration) Option {
+	return func(o *options) {
+		o.callTimeout = timeout
+	}
+}
+
+//WithKeepalive pings the server after interval without activity, even without calls in flight, closing the
+//connection when a ping isn't answered within timeout, so that broken connections are noticed before the next call.
+//The server disconnects clients pinging more often than server.MinKeepaliveInterval
+func WithKeepalive(interval, timeout time.Duration) Option {
+	return func(o *options) {
+		o.keepalive = &keepalive.ClientParameters{
+			Time:                interval,
+			Timeout:             timeout,
+			PermitWithoutStream: true,
+		}
+	}
+}
+
+//WithRetry retries failed calls with policy, through the service config of the connection
+func WithRetry(policy RetryPolicy) Option {
+	return func(o *options) {
+		o.retry = &policy
+	}
+}
+
+//WithUnaryInterceptors runs interceptors around every unary call, the first one being the outermost
+func WithUnaryInterceptors(interceptors ...grpc.UnaryClientInterceptor) Option {
+	return func(o *options) {
+		o.unaryInterceptors = append(o.unaryInterceptors, interceptors...)
+	}
+}
+
+//WithStreamInterceptors runs interceptors around every stream, the first one being the outermost
+func WithStreamInterceptors(interceptors ...grpc.StreamClientInterceptor) Option {
+	return func(o *options) {
+		o.streamInterceptors = append(o.streamInterceptors, interceptors...)
+	}
+}
+
+//WithDialOptions passes opts to grpc.Dial, for anything the other options don't cover
+func WithDialOptions(opts ...grpc.DialOption) Option {
+	return func(o *options) {
+		o.dialOptions = append(o.dialOptions, opts...)
+	}
+}
@@ -0,0 +1,16 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/go-fantasy/fpl/cli"
+)
+
+func main() {
+	command := cli.NewCommand()
+	if err := command.Execute(); err != nil {
+		fmt.Fprintf(command.ErrOrStderr(), "Error: %v\n", err)
+		os.Exit(cli.ExitCode(err))
+	}
+}
@@ -22,6 +22,127 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion2 // please upgrade the proto package
 
+type ExportFormat int32
+
+const (
+	ExportFormat_CSV      ExportFormat = 0
+	ExportFormat_JSON     ExportFormat = 1
+	ExportFormat_NDJSON   ExportFormat = 2
+	ExportFormat_MARKDOWN ExportFormat = 3
+	ExportFormat_XLSX     ExportFormat = 4
+	ExportFormat_PARQUET  ExportFormat = 5
+)
+
+var ExportFormat_name = map[int32]string{
+	0: "CSV",
+	1: "JSON",
+	2: "NDJSON",
+	3: "MARKDOWN",
+	4: "XLSX",
+	5: "PARQUET",
+}
+
+var ExportFormat_value = map[string]int32{
+	"CSV":      0,
+	"JSON":     1,
+	"NDJSON":   2,
+	"MARKDOWN": 3,
+	"XLSX":     4,
+	"PARQUET":  5,
+}
+
+func (x ExportFormat) String() string {
+	return proto.EnumName(ExportFormat_name, int32(x))
+}
+
+func (ExportFormat) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{0}
+}
+
+type OwnershipSort int32
+
+const (
+	OwnershipSort_TOTAL  OwnershipSort = 0
+	OwnershipSort_LATEST OwnershipSort = 1
+	OwnershipSort_PEAK   OwnershipSort = 2
+)
+
+var OwnershipSort_name = map[int32]string{
+	0: "TOTAL",
+	1: "LATEST",
+	2: "PEAK",
+}
+
+var OwnershipSort_value = map[string]int32{
+	"TOTAL":  0,
+	"LATEST": 1,
+	"PEAK":   2,
+}
+
+func (x OwnershipSort) String() string {
+	return proto.EnumName(OwnershipSort_name, int32(x))
+}
+
+func (OwnershipSort) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{1}
+}
+
+type UpdateType int32
+
+const (
+	UpdateType_UNKNOWN           UpdateType = 0
+	UpdateType_NEW_GAMEWEEK      UpdateType = 1
+	UpdateType_OWNERSHIP_CHANGED UpdateType = 2
+	UpdateType_LIVE_POINTS       UpdateType = 3
+)
+
+var UpdateType_name = map[int32]string{
+	0: "UNKNOWN",
+	1: "NEW_GAMEWEEK",
+	2: "OWNERSHIP_CHANGED",
+	3: "LIVE_POINTS",
+}
+
+var UpdateType_value = map[string]int32{
+	"UNKNOWN":           0,
+	"NEW_GAMEWEEK":      1,
+	"OWNERSHIP_CHANGED": 2,
+	"LIVE_POINTS":       3,
+}
+
+func (x UpdateType) String() string {
+	return proto.EnumName(UpdateType_name, int32(x))
+}
+
+func (UpdateType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{2}
+}
+
+type ImageFormat int32
+
+const (
+	ImageFormat_PNG ImageFormat = 0
+	ImageFormat_SVG ImageFormat = 1
+)
+
+var ImageFormat_name = map[int32]string{
+	0: "PNG",
+	1: "SVG",
+}
+
+var ImageFormat_value = map[string]int32{
+	"PNG": 0,
+	"SVG": 1,
+}
+
+func (x ImageFormat) String() string {
+	return proto.EnumName(ImageFormat_name, int32(x))
+}
+
+func (ImageFormat) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{3}
+}
+
 type NumPlayerRequest struct {
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
@@ -173,6 +294,8 @@ func (m *NumParticipants) GetNumParticipants() int64 {
 type GameweekReq struct {
 	LeagueCode           int64    `protobuf:"varint,1,opt,name=LeagueCode,proto3" json:"LeagueCode,omitempty"`
 	Gameweek             int64    `protobuf:"varint,2,opt,name=Gameweek,proto3" json:"Gameweek,omitempty"`
+	SampleSize           int64    `protobuf:"varint,3,opt,name=sampleSize,proto3" json:"sampleSize,omitempty"`
+	Cohort               string   `protobuf:"bytes,4,opt,name=cohort,proto3" json:"cohort,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -217,6 +340,178 @@ func (m *GameweekReq) GetGameweek() int64 {
 	return 0
 }
 
+func (m *GameweekReq) GetSampleSize() int64 {
+	if m != nil {
+		return m.SampleSize
+	}
+	return 0
+}
+
+func (m *GameweekReq) GetCohort() string {
+	if m != nil {
+		return m.Cohort
+	}
+	return ""
+}
+
+type GameweekSelection struct {
+	From                 int64    `protobuf:"varint,1,opt,name=from,proto3" json:"from,omitempty"`
+	To                   int64    `protobuf:"varint,2,opt,name=to,proto3" json:"to,omitempty"`
+	Gameweeks            []int64  `protobuf:"varint,3,rep,packed,name=gameweeks,proto3" json:"gameweeks,omitempty"`
+	Last                 int64    `protobuf:"varint,4,opt,name=last,proto3" json:"last,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GameweekSelection) Reset()         { *m = GameweekSelection{} }
+func (m *GameweekSelection) String() string { return proto.CompactTextString(m) }
+func (*GameweekSelection) ProtoMessage()    {}
+func (*GameweekSelection) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{5}
+}
+
+func (m *GameweekSelection) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GameweekSelection.Unmarshal(m, b)
+}
+func (m *GameweekSelection) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GameweekSelection.Marshal(b, m, deterministic)
+}
+func (m *GameweekSelection) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GameweekSelection.Merge(m, src)
+}
+func (m *GameweekSelection) XXX_Size() int {
+	return xxx_messageInfo_GameweekSelection.Size(m)
+}
+func (m *GameweekSelection) XXX_DiscardUnknown() {
+	xxx_messageInfo_GameweekSelection.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GameweekSelection proto.InternalMessageInfo
+
+func (m *GameweekSelection) GetFrom() int64 {
+	if m != nil {
+		return m.From
+	}
+	return 0
+}
+
+func (m *GameweekSelection) GetTo() int64 {
+	if m != nil {
+		return m.To
+	}
+	return 0
+}
+
+func (m *GameweekSelection) GetGameweeks() []int64 {
+	if m != nil {
+		return m.Gameweeks
+	}
+	return nil
+}
+
+func (m *GameweekSelection) GetLast() int64 {
+	if m != nil {
+		return m.Last
+	}
+	return 0
+}
+
+type AllGameweeksReq struct {
+	LeagueCode           int64              `protobuf:"varint,1,opt,name=LeagueCode,proto3" json:"LeagueCode,omitempty"`
+	SampleSize           int64              `protobuf:"varint,2,opt,name=sampleSize,proto3" json:"sampleSize,omitempty"`
+	Format               ExportFormat       `protobuf:"varint,3,opt,name=format,proto3,enum=grpc.ExportFormat" json:"format,omitempty"`
+	Sort                 OwnershipSort      `protobuf:"varint,4,opt,name=sort,proto3,enum=grpc.OwnershipSort" json:"sort,omitempty"`
+	Offset               int64              `protobuf:"varint,5,opt,name=offset,proto3" json:"offset,omitempty"`
+	ResumeToken          string             `protobuf:"bytes,6,opt,name=resumeToken,proto3" json:"resumeToken,omitempty"`
+	Gameweeks            *GameweekSelection `protobuf:"bytes,7,opt,name=gameweeks,proto3" json:"gameweeks,omitempty"`
+	Cohort               string             `protobuf:"bytes,8,opt,name=cohort,proto3" json:"cohort,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *AllGameweeksReq) Reset()         { *m = AllGameweeksReq{} }
+func (m *AllGameweeksReq) String() string { return proto.CompactTextString(m) }
+func (*AllGameweeksReq) ProtoMessage()    {}
+func (*AllGameweeksReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{6}
+}
+
+func (m *AllGameweeksReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AllGameweeksReq.Unmarshal(m, b)
+}
+func (m *AllGameweeksReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AllGameweeksReq.Marshal(b, m, deterministic)
+}
+func (m *AllGameweeksReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AllGameweeksReq.Merge(m, src)
+}
+func (m *AllGameweeksReq) XXX_Size() int {
+	return xxx_messageInfo_AllGameweeksReq.Size(m)
+}
+func (m *AllGameweeksReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_AllGameweeksReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AllGameweeksReq proto.InternalMessageInfo
+
+func (m *AllGameweeksReq) GetLeagueCode() int64 {
+	if m != nil {
+		return m.LeagueCode
+	}
+	return 0
+}
+
+func (m *AllGameweeksReq) GetSampleSize() int64 {
+	if m != nil {
+		return m.SampleSize
+	}
+	return 0
+}
+
+func (m *AllGameweeksReq) GetFormat() ExportFormat {
+	if m != nil {
+		return m.Format
+	}
+	return ExportFormat_CSV
+}
+
+func (m *AllGameweeksReq) GetSort() OwnershipSort {
+	if m != nil {
+		return m.Sort
+	}
+	return OwnershipSort_TOTAL
+}
+
+func (m *AllGameweeksReq) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
+}
+
+func (m *AllGameweeksReq) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
+}
+
+func (m *AllGameweeksReq) GetGameweeks() *GameweekSelection {
+	if m != nil {
+		return m.Gameweeks
+	}
+	return nil
+}
+
+func (m *AllGameweeksReq) GetCohort() string {
+	if m != nil {
+		return m.Cohort
+	}
+	return ""
+}
+
 type PlayerOccuranceData struct {
 	PlayerOccurance      map[string]int32 `protobuf:"bytes,1,rep,name=playerOccurance,proto3" json:"playerOccurance,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
 	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
@@ -228,7 +523,7 @@ func (m *PlayerOccuranceData) Reset()         { *m = PlayerOccuranceData{} }
 func (m *PlayerOccuranceData) String() string { return proto.CompactTextString(m) }
 func (*PlayerOccuranceData) ProtoMessage()    {}
 func (*PlayerOccuranceData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00519c44ffdc0ef5, []int{5}
+	return fileDescriptor_00519c44ffdc0ef5, []int{7}
 }
 
 func (m *PlayerOccuranceData) XXX_Unmarshal(b []byte) error {
@@ -258,6 +553,9 @@ func (m *PlayerOccuranceData) GetPlayerOccurance() map[string]int32 {
 
 type AllGameweekData struct {
 	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	Offset               int64    `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`
+	ResumeToken          string   `protobuf:"bytes,3,opt,name=resumeToken,proto3" json:"resumeToken,omitempty"`
+	Digest               string   `protobuf:"bytes,4,opt,name=digest,proto3" json:"digest,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -267,7 +565,7 @@ func (m *AllGameweekData) Reset()         { *m = AllGameweekData{} }
 func (m *AllGameweekData) String() string { return proto.CompactTextString(m) }
 func (*AllGameweekData) ProtoMessage()    {}
 func (*AllGameweekData) Descriptor() ([]byte, []int) {
-	return fileDescriptor_00519c44ffdc0ef5, []int{6}
+	return fileDescriptor_00519c44ffdc0ef5, []int{8}
 }
 
 func (m *AllGameweekData) XXX_Unmarshal(b []byte) error {
@@ -295,146 +593,1711 @@ func (m *AllGameweekData) GetData() []byte {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*NumPlayerRequest)(nil), "grpc.NumPlayerRequest")
-	proto.RegisterType((*NumPlayers)(nil), "grpc.NumPlayers")
-	proto.RegisterType((*LeagueCode)(nil), "grpc.LeagueCode")
-	proto.RegisterType((*NumParticipants)(nil), "grpc.numParticipants")
-	proto.RegisterType((*GameweekReq)(nil), "grpc.GameweekReq")
-	proto.RegisterType((*PlayerOccuranceData)(nil), "grpc.PlayerOccuranceData")
-	proto.RegisterMapType((map[string]int32)(nil), "grpc.PlayerOccuranceData.PlayerOccuranceEntry")
-	proto.RegisterType((*AllGameweekData)(nil), "grpc.AllGameweekData")
+func (m *AllGameweekData) GetOffset() int64 {
+	if m != nil {
+		return m.Offset
+	}
+	return 0
 }
 
-func init() { proto.RegisterFile("grpc/fpl.proto", fileDescriptor_00519c44ffdc0ef5) }
-
-var fileDescriptor_00519c44ffdc0ef5 = []byte{
-	// 373 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x52, 0x4d, 0x6f, 0xda, 0x40,
-	0x10, 0xc5, 0x18, 0xaa, 0x76, 0xa8, 0x0a, 0x9d, 0x52, 0x4a, 0x7d, 0xa8, 0xd0, 0x4a, 0x95, 0x38,
-	0x54, 0x6e, 0x44, 0x2e, 0x51, 0x72, 0xc9, 0x07, 0x10, 0x21, 0x21, 0x40, 0x3e, 0xe5, 0xba, 0x98,
-	0xc1, 0x42, 0x18, 0xdb, 0xac, 0xd7, 0x89, 0xf8, 0x2b, 0xf9, 0x1b, 0xf9, 0x83, 0xd1, 0xda, 0x18,
-	0x3b, 0x0e, 0x28, 0xb7, 0x7d, 0x8f, 0x37, 0xc3, 0x9b, 0xf7, 0x0c, 0xdf, 0x1c, 0x11, 0xd8, 0xff,
-	0x97, 0x81, 0x6b, 0x06, 0xc2, 0x97, 0x3e, 0x56, 0x14, 0x66, 0x08, 0x8d, 0x49, 0xb4, 0x99, 0xb9,
-	0x7c, 0x47, 0xc2, 0xa2, 0x6d, 0x44, 0xa1, 0x64, 0xff, 0x00, 0x0e, 0x5c, 0x88, 0x7f, 0x00, 0xbc,
-	0x03, 0x6a, 0x6b, 0x1d, 0xad, 0xab, 0x5b, 0x39, 0x46, 0xa9, 0xc7, 0xc4, 0x9d, 0x88, 0xee, 0xfc,
-	0x05, 0x29, 0x75, 0x86, 0x52, 0x75, 0xc6, 0xb0, 0x2b, 0xa8, 0xab, 0x59, 0x2e, 0xe4, 0xca, 0x5e,
-	0x05, 0xdc, 0x93, 0x21, 0x76, 0xdf, 0x51, 0xfb, 0xb9, 0x22, 0xcd, 0x46, 0x50, 0xbb, 0xe7, 0x1b,
-	0x7a, 0x22, 0x5a, 0x5b, 0xb4, 0xfd, 0xe8, 0xbf, 0xd0, 0x80, 0xcf, 0xa9, 0xbc, 0x5d, 0x8e, 0x7f,
-	0x3d, 0x60, 0xf6, 0xa2, 0xc1, 0x8f, 0xe4, 0x82, 0xa9, 0x6d, 0x47, 0x82, 0x7b, 0x36, 0xf5, 0xb9,
-	0xe4, 0xf8, 0x00, 0xf5, 0xe0, 0x2d, 0xdd, 0xd6, 0x3a, 0x7a, 0xb7, 0xd6, 0x33, 0x4d, 0x95, 0x97,
-	0x79, 0x64, 0xa6, 0xc8, 0x0d, 0x3c, 0x29, 0x76, 0x56, 0x71, 0x8d, 0x71, 0x0b, 0xcd, 0x63, 0x42,
-	0x6c, 0x80, 0xbe, 0xa6, 0x5d, 0x6c, 0xff, 0x8b, 0xa5, 0x9e, 0xd8, 0x84, 0xea, 0x23, 0x77, 0x23,
-	0x8a, 0x4d, 0x57, 0xad, 0x04, 0x5c, 0x96, 0x2f, 0x34, 0xf6, 0x17, 0xea, 0x37, 0xae, 0x9b, 0x1e,
-	0x11, 0x1b, 0x46, 0xa8, 0x2c, 0xb8, 0xe4, 0xf1, 0xfc, 0x57, 0x2b, 0x7e, 0xf7, 0x9e, 0xcb, 0xa0,
-	0x0f, 0x67, 0x63, 0xbc, 0x06, 0x74, 0x48, 0x4e, 0xa2, 0xcd, 0x9c, 0xc4, 0x74, 0x99, 0x16, 0xda,
-	0x4a, 0x2e, 0x29, 0xd6, 0x6e, 0x34, 0x0a, 0x7c, 0xc8, 0x4a, 0xd8, 0x87, 0x5f, 0x0e, 0xc9, 0x7c,
-	0x09, 0x23, 0x2f, 0x49, 0x18, 0xf7, 0xf2, 0x2c, 0x6f, 0xe3, 0x67, 0xc2, 0x14, 0x5b, 0x53, 0x5b,
-	0x94, 0x0f, 0x65, 0x77, 0xe8, 0x8b, 0xd4, 0x3d, 0x7e, 0x4f, 0xe4, 0xb9, 0x46, 0x8d, 0xdf, 0x27,
-	0x43, 0x66, 0x25, 0x1c, 0x40, 0x2b, 0xdb, 0x92, 0x8b, 0x21, 0x3c, 0x6d, 0xa5, 0x10, 0x16, 0x2b,
-	0x9d, 0x69, 0xf3, 0x4f, 0xf1, 0xe7, 0x7f, 0xfe, 0x1a, 0x00, 0x00, 0xff, 0xff, 0x2f, 0x6d, 0x6e,
-	0x39, 0x10, 0x03, 0x00, 0x00,
+func (m *AllGameweekData) GetResumeToken() string {
+	if m != nil {
+		return m.ResumeToken
+	}
+	return ""
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConn
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion4
+func (m *AllGameweekData) GetDigest() string {
+	if m != nil {
+		return m.Digest
+	}
+	return ""
+}
 
-// FPLClient is the client API for FPL service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type FPLClient interface {
-	GetNumberOfPlayers(ctx context.Context, in *NumPlayerRequest, opts ...grpc.CallOption) (*NumPlayers, error)
-	GetParticipantsInLeague(ctx context.Context, in *LeagueCode, opts ...grpc.CallOption) (*NumParticipants, error)
-	GetDataForGameweek(ctx context.Context, in *GameweekReq, opts ...grpc.CallOption) (*PlayerOccuranceData, error)
-	GetDataForAllGameweeks(ctx context.Context, in *LeagueCode, opts ...grpc.CallOption) (FPL_GetDataForAllGameweeksClient, error)
+type LiveReq struct {
+	LeagueCode           int64    `protobuf:"varint,1,opt,name=LeagueCode,proto3" json:"LeagueCode,omitempty"`
+	Gameweek             int64    `protobuf:"varint,2,opt,name=Gameweek,proto3" json:"Gameweek,omitempty"`
+	SampleSize           int64    `protobuf:"varint,3,opt,name=sampleSize,proto3" json:"sampleSize,omitempty"`
+	RefreshSeconds       int64    `protobuf:"varint,4,opt,name=refreshSeconds,proto3" json:"refreshSeconds,omitempty"`
+	Cohort               string   `protobuf:"bytes,5,opt,name=cohort,proto3" json:"cohort,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-type fPLClient struct {
-	cc *grpc.ClientConn
+func (m *LiveReq) Reset()         { *m = LiveReq{} }
+func (m *LiveReq) String() string { return proto.CompactTextString(m) }
+func (*LiveReq) ProtoMessage()    {}
+func (*LiveReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{9}
 }
 
-func NewFPLClient(cc *grpc.ClientConn) FPLClient {
-	return &fPLClient{cc}
+func (m *LiveReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LiveReq.Unmarshal(m, b)
+}
+func (m *LiveReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LiveReq.Marshal(b, m, deterministic)
+}
+func (m *LiveReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LiveReq.Merge(m, src)
+}
+func (m *LiveReq) XXX_Size() int {
+	return xxx_messageInfo_LiveReq.Size(m)
+}
+func (m *LiveReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_LiveReq.DiscardUnknown(m)
 }
 
-func (c *fPLClient) GetNumberOfPlayers(ctx context.Context, in *NumPlayerRequest, opts ...grpc.CallOption) (*NumPlayers, error) {
-	out := new(NumPlayers)
-	err := c.cc.Invoke(ctx, "/grpc.FPL/getNumberOfPlayers", in, out, opts...)
-	if err != nil {
-		return nil, err
+var xxx_messageInfo_LiveReq proto.InternalMessageInfo
+
+func (m *LiveReq) GetLeagueCode() int64 {
+	if m != nil {
+		return m.LeagueCode
 	}
-	return out, nil
+	return 0
 }
 
-func (c *fPLClient) GetParticipantsInLeague(ctx context.Context, in *LeagueCode, opts ...grpc.CallOption) (*NumParticipants, error) {
-	out := new(NumParticipants)
-	err := c.cc.Invoke(ctx, "/grpc.FPL/getParticipantsInLeague", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *LiveReq) GetGameweek() int64 {
+	if m != nil {
+		return m.Gameweek
 	}
-	return out, nil
+	return 0
 }
 
-func (c *fPLClient) GetDataForGameweek(ctx context.Context, in *GameweekReq, opts ...grpc.CallOption) (*PlayerOccuranceData, error) {
-	out := new(PlayerOccuranceData)
-	err := c.cc.Invoke(ctx, "/grpc.FPL/getDataForGameweek", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *LiveReq) GetSampleSize() int64 {
+	if m != nil {
+		return m.SampleSize
 	}
-	return out, nil
+	return 0
 }
 
-func (c *fPLClient) GetDataForAllGameweeks(ctx context.Context, in *LeagueCode, opts ...grpc.CallOption) (FPL_GetDataForAllGameweeksClient, error) {
-	stream, err := c.cc.NewStream(ctx, &_FPL_serviceDesc.Streams[0], "/grpc.FPL/getDataForAllGameweeks", opts...)
-	if err != nil {
-		return nil, err
-	}
-	x := &fPLGetDataForAllGameweeksClient{stream}
-	if err := x.ClientStream.SendMsg(in); err != nil {
-		return nil, err
-	}
-	if err := x.ClientStream.CloseSend(); err != nil {
-		return nil, err
+func (m *LiveReq) GetRefreshSeconds() int64 {
+	if m != nil {
+		return m.RefreshSeconds
 	}
-	return x, nil
+	return 0
 }
 
-type FPL_GetDataForAllGameweeksClient interface {
-	Recv() (*AllGameweekData, error)
-	grpc.ClientStream
+func (m *LiveReq) GetCohort() string {
+	if m != nil {
+		return m.Cohort
+	}
+	return ""
 }
 
-type fPLGetDataForAllGameweeksClient struct {
-	grpc.ClientStream
+type LiveManager struct {
+	Entry                int64    `protobuf:"varint,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	LivePoints           int64    `protobuf:"varint,2,opt,name=livePoints,proto3" json:"livePoints,omitempty"`
+	TotalPoints          int64    `protobuf:"varint,3,opt,name=totalPoints,proto3" json:"totalPoints,omitempty"`
+	LiveRank             int64    `protobuf:"varint,4,opt,name=liveRank,proto3" json:"liveRank,omitempty"`
+	PreviousRank         int64    `protobuf:"varint,5,opt,name=previousRank,proto3" json:"previousRank,omitempty"`
+	Captain              string   `protobuf:"bytes,6,opt,name=captain,proto3" json:"captain,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func (x *fPLGetDataForAllGameweeksClient) Recv() (*AllGameweekData, error) {
-	m := new(AllGameweekData)
-	if err := x.ClientStream.RecvMsg(m); err != nil {
-		return nil, err
-	}
-	return m, nil
+func (m *LiveManager) Reset()         { *m = LiveManager{} }
+func (m *LiveManager) String() string { return proto.CompactTextString(m) }
+func (*LiveManager) ProtoMessage()    {}
+func (*LiveManager) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{10}
 }
 
-// FPLServer is the server API for FPL service.
-type FPLServer interface {
-	GetNumberOfPlayers(context.Context, *NumPlayerRequest) (*NumPlayers, error)
-	GetParticipantsInLeague(context.Context, *LeagueCode) (*NumParticipants, error)
-	GetDataForGameweek(context.Context, *GameweekReq) (*PlayerOccuranceData, error)
-	GetDataForAllGameweeks(*LeagueCode, FPL_GetDataForAllGameweeksServer) error
+func (m *LiveManager) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LiveManager.Unmarshal(m, b)
 }
-
-func RegisterFPLServer(s *grpc.Server, srv FPLServer) {
-	s.RegisterService(&_FPL_serviceDesc, srv)
+func (m *LiveManager) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LiveManager.Marshal(b, m, deterministic)
+}
+func (m *LiveManager) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LiveManager.Merge(m, src)
+}
+func (m *LiveManager) XXX_Size() int {
+	return xxx_messageInfo_LiveManager.Size(m)
+}
+func (m *LiveManager) XXX_DiscardUnknown() {
+	xxx_messageInfo_LiveManager.DiscardUnknown(m)
 }
 
-func _FPL_GetNumberOfPlayers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(NumPlayerRequest)
+var xxx_messageInfo_LiveManager proto.InternalMessageInfo
+
+func (m *LiveManager) GetEntry() int64 {
+	if m != nil {
+		return m.Entry
+	}
+	return 0
+}
+
+func (m *LiveManager) GetLivePoints() int64 {
+	if m != nil {
+		return m.LivePoints
+	}
+	return 0
+}
+
+func (m *LiveManager) GetTotalPoints() int64 {
+	if m != nil {
+		return m.TotalPoints
+	}
+	return 0
+}
+
+func (m *LiveManager) GetLiveRank() int64 {
+	if m != nil {
+		return m.LiveRank
+	}
+	return 0
+}
+
+func (m *LiveManager) GetPreviousRank() int64 {
+	if m != nil {
+		return m.PreviousRank
+	}
+	return 0
+}
+
+func (m *LiveManager) GetCaptain() string {
+	if m != nil {
+		return m.Captain
+	}
+	return ""
+}
+
+type LiveStandings struct {
+	Gameweek             int64          `protobuf:"varint,1,opt,name=Gameweek,proto3" json:"Gameweek,omitempty"`
+	Managers             []*LiveManager `protobuf:"bytes,2,rep,name=managers,proto3" json:"managers,omitempty"`
+	UpdatedAt            int64          `protobuf:"varint,3,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *LiveStandings) Reset()         { *m = LiveStandings{} }
+func (m *LiveStandings) String() string { return proto.CompactTextString(m) }
+func (*LiveStandings) ProtoMessage()    {}
+func (*LiveStandings) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{11}
+}
+
+func (m *LiveStandings) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LiveStandings.Unmarshal(m, b)
+}
+func (m *LiveStandings) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LiveStandings.Marshal(b, m, deterministic)
+}
+func (m *LiveStandings) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LiveStandings.Merge(m, src)
+}
+func (m *LiveStandings) XXX_Size() int {
+	return xxx_messageInfo_LiveStandings.Size(m)
+}
+func (m *LiveStandings) XXX_DiscardUnknown() {
+	xxx_messageInfo_LiveStandings.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LiveStandings proto.InternalMessageInfo
+
+func (m *LiveStandings) GetGameweek() int64 {
+	if m != nil {
+		return m.Gameweek
+	}
+	return 0
+}
+
+func (m *LiveStandings) GetManagers() []*LiveManager {
+	if m != nil {
+		return m.Managers
+	}
+	return nil
+}
+
+func (m *LiveStandings) GetUpdatedAt() int64 {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return 0
+}
+
+type SubscribeReq struct {
+	LeagueCode           int64    `protobuf:"varint,1,opt,name=LeagueCode,proto3" json:"LeagueCode,omitempty"`
+	Players              []string `protobuf:"bytes,2,rep,name=players,proto3" json:"players,omitempty"`
+	Cursor               int64    `protobuf:"varint,3,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SubscribeReq) Reset()         { *m = SubscribeReq{} }
+func (m *SubscribeReq) String() string { return proto.CompactTextString(m) }
+func (*SubscribeReq) ProtoMessage()    {}
+func (*SubscribeReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{12}
+}
+
+func (m *SubscribeReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SubscribeReq.Unmarshal(m, b)
+}
+func (m *SubscribeReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SubscribeReq.Marshal(b, m, deterministic)
+}
+func (m *SubscribeReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SubscribeReq.Merge(m, src)
+}
+func (m *SubscribeReq) XXX_Size() int {
+	return xxx_messageInfo_SubscribeReq.Size(m)
+}
+func (m *SubscribeReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_SubscribeReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SubscribeReq proto.InternalMessageInfo
+
+func (m *SubscribeReq) GetLeagueCode() int64 {
+	if m != nil {
+		return m.LeagueCode
+	}
+	return 0
+}
+
+func (m *SubscribeReq) GetPlayers() []string {
+	if m != nil {
+		return m.Players
+	}
+	return nil
+}
+
+func (m *SubscribeReq) GetCursor() int64 {
+	if m != nil {
+		return m.Cursor
+	}
+	return 0
+}
+
+type Update struct {
+	Cursor               int64            `protobuf:"varint,1,opt,name=cursor,proto3" json:"cursor,omitempty"`
+	Type                 UpdateType       `protobuf:"varint,2,opt,name=type,proto3,enum=grpc.UpdateType" json:"type,omitempty"`
+	LeagueCode           int64            `protobuf:"varint,3,opt,name=LeagueCode,proto3" json:"LeagueCode,omitempty"`
+	Gameweek             int64            `protobuf:"varint,4,opt,name=Gameweek,proto3" json:"Gameweek,omitempty"`
+	PlayerOccurance      map[string]int32 `protobuf:"bytes,5,rep,name=playerOccurance,proto3" json:"playerOccurance,omitempty" protobuf_key:"bytes,1,opt,name=key,proto3" protobuf_val:"varint,2,opt,name=value,proto3"`
+	ChangedPlayers       []string         `protobuf:"bytes,6,rep,name=changedPlayers,proto3" json:"changedPlayers,omitempty"`
+	Managers             []*LiveManager   `protobuf:"bytes,7,rep,name=managers,proto3" json:"managers,omitempty"`
+	UpdatedAt            int64            `protobuf:"varint,8,opt,name=updatedAt,proto3" json:"updatedAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *Update) Reset()         { *m = Update{} }
+func (m *Update) String() string { return proto.CompactTextString(m) }
+func (*Update) ProtoMessage()    {}
+func (*Update) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{13}
+}
+
+func (m *Update) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Update.Unmarshal(m, b)
+}
+func (m *Update) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Update.Marshal(b, m, deterministic)
+}
+func (m *Update) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Update.Merge(m, src)
+}
+func (m *Update) XXX_Size() int {
+	return xxx_messageInfo_Update.Size(m)
+}
+func (m *Update) XXX_DiscardUnknown() {
+	xxx_messageInfo_Update.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Update proto.InternalMessageInfo
+
+func (m *Update) GetCursor() int64 {
+	if m != nil {
+		return m.Cursor
+	}
+	return 0
+}
+
+func (m *Update) GetType() UpdateType {
+	if m != nil {
+		return m.Type
+	}
+	return UpdateType_UNKNOWN
+}
+
+func (m *Update) GetLeagueCode() int64 {
+	if m != nil {
+		return m.LeagueCode
+	}
+	return 0
+}
+
+func (m *Update) GetGameweek() int64 {
+	if m != nil {
+		return m.Gameweek
+	}
+	return 0
+}
+
+func (m *Update) GetPlayerOccurance() map[string]int32 {
+	if m != nil {
+		return m.PlayerOccurance
+	}
+	return nil
+}
+
+func (m *Update) GetChangedPlayers() []string {
+	if m != nil {
+		return m.ChangedPlayers
+	}
+	return nil
+}
+
+func (m *Update) GetManagers() []*LiveManager {
+	if m != nil {
+		return m.Managers
+	}
+	return nil
+}
+
+func (m *Update) GetUpdatedAt() int64 {
+	if m != nil {
+		return m.UpdatedAt
+	}
+	return 0
+}
+
+type UsageReq struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *UsageReq) Reset()         { *m = UsageReq{} }
+func (m *UsageReq) String() string { return proto.CompactTextString(m) }
+func (*UsageReq) ProtoMessage()    {}
+func (*UsageReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{14}
+}
+
+func (m *UsageReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_UsageReq.Unmarshal(m, b)
+}
+func (m *UsageReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_UsageReq.Marshal(b, m, deterministic)
+}
+func (m *UsageReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_UsageReq.Merge(m, src)
+}
+func (m *UsageReq) XXX_Size() int {
+	return xxx_messageInfo_UsageReq.Size(m)
+}
+func (m *UsageReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_UsageReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_UsageReq proto.InternalMessageInfo
+
+type KeyUsage struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Admin                bool     `protobuf:"varint,2,opt,name=admin,proto3" json:"admin,omitempty"`
+	Requests             int64    `protobuf:"varint,3,opt,name=requests,proto3" json:"requests,omitempty"`
+	Rejected             int64    `protobuf:"varint,4,opt,name=rejected,proto3" json:"rejected,omitempty"`
+	Quota                int64    `protobuf:"varint,5,opt,name=quota,proto3" json:"quota,omitempty"`
+	Remaining            int64    `protobuf:"varint,6,opt,name=remaining,proto3" json:"remaining,omitempty"`
+	QuotaResetsAt        int64    `protobuf:"varint,7,opt,name=quotaResetsAt,proto3" json:"quotaResetsAt,omitempty"`
+	LastUsedAt           int64    `protobuf:"varint,8,opt,name=lastUsedAt,proto3" json:"lastUsedAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *KeyUsage) Reset()         { *m = KeyUsage{} }
+func (m *KeyUsage) String() string { return proto.CompactTextString(m) }
+func (*KeyUsage) ProtoMessage()    {}
+func (*KeyUsage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{15}
+}
+
+func (m *KeyUsage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_KeyUsage.Unmarshal(m, b)
+}
+func (m *KeyUsage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_KeyUsage.Marshal(b, m, deterministic)
+}
+func (m *KeyUsage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_KeyUsage.Merge(m, src)
+}
+func (m *KeyUsage) XXX_Size() int {
+	return xxx_messageInfo_KeyUsage.Size(m)
+}
+func (m *KeyUsage) XXX_DiscardUnknown() {
+	xxx_messageInfo_KeyUsage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_KeyUsage proto.InternalMessageInfo
+
+func (m *KeyUsage) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *KeyUsage) GetAdmin() bool {
+	if m != nil {
+		return m.Admin
+	}
+	return false
+}
+
+func (m *KeyUsage) GetRequests() int64 {
+	if m != nil {
+		return m.Requests
+	}
+	return 0
+}
+
+func (m *KeyUsage) GetRejected() int64 {
+	if m != nil {
+		return m.Rejected
+	}
+	return 0
+}
+
+func (m *KeyUsage) GetQuota() int64 {
+	if m != nil {
+		return m.Quota
+	}
+	return 0
+}
+
+func (m *KeyUsage) GetRemaining() int64 {
+	if m != nil {
+		return m.Remaining
+	}
+	return 0
+}
+
+func (m *KeyUsage) GetQuotaResetsAt() int64 {
+	if m != nil {
+		return m.QuotaResetsAt
+	}
+	return 0
+}
+
+func (m *KeyUsage) GetLastUsedAt() int64 {
+	if m != nil {
+		return m.LastUsedAt
+	}
+	return 0
+}
+
+type Usage struct {
+	Keys                 []*KeyUsage `protobuf:"bytes,1,rep,name=keys,proto3" json:"keys,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *Usage) Reset()         { *m = Usage{} }
+func (m *Usage) String() string { return proto.CompactTextString(m) }
+func (*Usage) ProtoMessage()    {}
+func (*Usage) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{16}
+}
+
+func (m *Usage) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Usage.Unmarshal(m, b)
+}
+func (m *Usage) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Usage.Marshal(b, m, deterministic)
+}
+func (m *Usage) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Usage.Merge(m, src)
+}
+func (m *Usage) XXX_Size() int {
+	return xxx_messageInfo_Usage.Size(m)
+}
+func (m *Usage) XXX_DiscardUnknown() {
+	xxx_messageInfo_Usage.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Usage proto.InternalMessageInfo
+
+func (m *Usage) GetKeys() []*KeyUsage {
+	if m != nil {
+		return m.Keys
+	}
+	return nil
+}
+
+type HeatmapReq struct {
+	LeagueCode           int64       `protobuf:"varint,1,opt,name=LeagueCode,proto3" json:"LeagueCode,omitempty"`
+	SampleSize           int64       `protobuf:"varint,2,opt,name=sampleSize,proto3" json:"sampleSize,omitempty"`
+	Format               ImageFormat `protobuf:"varint,3,opt,name=format,proto3,enum=grpc.ImageFormat" json:"format,omitempty"`
+	Top                  int64       `protobuf:"varint,4,opt,name=top,proto3" json:"top,omitempty"`
+	Cohort               string      `protobuf:"bytes,5,opt,name=cohort,proto3" json:"cohort,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *HeatmapReq) Reset()         { *m = HeatmapReq{} }
+func (m *HeatmapReq) String() string { return proto.CompactTextString(m) }
+func (*HeatmapReq) ProtoMessage()    {}
+func (*HeatmapReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{17}
+}
+
+func (m *HeatmapReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HeatmapReq.Unmarshal(m, b)
+}
+func (m *HeatmapReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HeatmapReq.Marshal(b, m, deterministic)
+}
+func (m *HeatmapReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HeatmapReq.Merge(m, src)
+}
+func (m *HeatmapReq) XXX_Size() int {
+	return xxx_messageInfo_HeatmapReq.Size(m)
+}
+func (m *HeatmapReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_HeatmapReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HeatmapReq proto.InternalMessageInfo
+
+func (m *HeatmapReq) GetLeagueCode() int64 {
+	if m != nil {
+		return m.LeagueCode
+	}
+	return 0
+}
+
+func (m *HeatmapReq) GetSampleSize() int64 {
+	if m != nil {
+		return m.SampleSize
+	}
+	return 0
+}
+
+func (m *HeatmapReq) GetFormat() ImageFormat {
+	if m != nil {
+		return m.Format
+	}
+	return ImageFormat_PNG
+}
+
+func (m *HeatmapReq) GetTop() int64 {
+	if m != nil {
+		return m.Top
+	}
+	return 0
+}
+
+func (m *HeatmapReq) GetCohort() string {
+	if m != nil {
+		return m.Cohort
+	}
+	return ""
+}
+
+type Heatmap struct {
+	Image                []byte   `protobuf:"bytes,1,opt,name=image,proto3" json:"image,omitempty"`
+	ContentType          string   `protobuf:"bytes,2,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Heatmap) Reset()         { *m = Heatmap{} }
+func (m *Heatmap) String() string { return proto.CompactTextString(m) }
+func (*Heatmap) ProtoMessage()    {}
+func (*Heatmap) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{18}
+}
+
+func (m *Heatmap) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Heatmap.Unmarshal(m, b)
+}
+func (m *Heatmap) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Heatmap.Marshal(b, m, deterministic)
+}
+func (m *Heatmap) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Heatmap.Merge(m, src)
+}
+func (m *Heatmap) XXX_Size() int {
+	return xxx_messageInfo_Heatmap.Size(m)
+}
+func (m *Heatmap) XXX_DiscardUnknown() {
+	xxx_messageInfo_Heatmap.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Heatmap proto.InternalMessageInfo
+
+func (m *Heatmap) GetImage() []byte {
+	if m != nil {
+		return m.Image
+	}
+	return nil
+}
+
+func (m *Heatmap) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+type CompareReq struct {
+	LeagueCodes          []int64            `protobuf:"varint,1,rep,packed,name=leagueCodes,proto3" json:"leagueCodes,omitempty"`
+	SampleSize           int64              `protobuf:"varint,2,opt,name=sampleSize,proto3" json:"sampleSize,omitempty"`
+	Gameweeks            *GameweekSelection `protobuf:"bytes,3,opt,name=gameweeks,proto3" json:"gameweeks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}           `json:"-"`
+	XXX_unrecognized     []byte             `json:"-"`
+	XXX_sizecache        int32              `json:"-"`
+}
+
+func (m *CompareReq) Reset()         { *m = CompareReq{} }
+func (m *CompareReq) String() string { return proto.CompactTextString(m) }
+func (*CompareReq) ProtoMessage()    {}
+func (*CompareReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{19}
+}
+
+func (m *CompareReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CompareReq.Unmarshal(m, b)
+}
+func (m *CompareReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CompareReq.Marshal(b, m, deterministic)
+}
+func (m *CompareReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CompareReq.Merge(m, src)
+}
+func (m *CompareReq) XXX_Size() int {
+	return xxx_messageInfo_CompareReq.Size(m)
+}
+func (m *CompareReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CompareReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CompareReq proto.InternalMessageInfo
+
+func (m *CompareReq) GetLeagueCodes() []int64 {
+	if m != nil {
+		return m.LeagueCodes
+	}
+	return nil
+}
+
+func (m *CompareReq) GetSampleSize() int64 {
+	if m != nil {
+		return m.SampleSize
+	}
+	return 0
+}
+
+func (m *CompareReq) GetGameweeks() *GameweekSelection {
+	if m != nil {
+		return m.Gameweeks
+	}
+	return nil
+}
+
+type PlayerComparison struct {
+	Player               string    `protobuf:"bytes,1,opt,name=player,proto3" json:"player,omitempty"`
+	Owners               []int64   `protobuf:"varint,2,rep,packed,name=owners,proto3" json:"owners,omitempty"`
+	Percentages          []float64 `protobuf:"fixed64,3,rep,packed,name=percentages,proto3" json:"percentages,omitempty"`
+	Deltas               []float64 `protobuf:"fixed64,4,rep,packed,name=deltas,proto3" json:"deltas,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *PlayerComparison) Reset()         { *m = PlayerComparison{} }
+func (m *PlayerComparison) String() string { return proto.CompactTextString(m) }
+func (*PlayerComparison) ProtoMessage()    {}
+func (*PlayerComparison) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{20}
+}
+
+func (m *PlayerComparison) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PlayerComparison.Unmarshal(m, b)
+}
+func (m *PlayerComparison) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PlayerComparison.Marshal(b, m, deterministic)
+}
+func (m *PlayerComparison) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PlayerComparison.Merge(m, src)
+}
+func (m *PlayerComparison) XXX_Size() int {
+	return xxx_messageInfo_PlayerComparison.Size(m)
+}
+func (m *PlayerComparison) XXX_DiscardUnknown() {
+	xxx_messageInfo_PlayerComparison.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PlayerComparison proto.InternalMessageInfo
+
+func (m *PlayerComparison) GetPlayer() string {
+	if m != nil {
+		return m.Player
+	}
+	return ""
+}
+
+func (m *PlayerComparison) GetOwners() []int64 {
+	if m != nil {
+		return m.Owners
+	}
+	return nil
+}
+
+func (m *PlayerComparison) GetPercentages() []float64 {
+	if m != nil {
+		return m.Percentages
+	}
+	return nil
+}
+
+func (m *PlayerComparison) GetDeltas() []float64 {
+	if m != nil {
+		return m.Deltas
+	}
+	return nil
+}
+
+type GameweekComparison struct {
+	Gameweek             int64               `protobuf:"varint,1,opt,name=Gameweek,proto3" json:"Gameweek,omitempty"`
+	Players              []*PlayerComparison `protobuf:"bytes,2,rep,name=players,proto3" json:"players,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}            `json:"-"`
+	XXX_unrecognized     []byte              `json:"-"`
+	XXX_sizecache        int32               `json:"-"`
+}
+
+func (m *GameweekComparison) Reset()         { *m = GameweekComparison{} }
+func (m *GameweekComparison) String() string { return proto.CompactTextString(m) }
+func (*GameweekComparison) ProtoMessage()    {}
+func (*GameweekComparison) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{21}
+}
+
+func (m *GameweekComparison) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GameweekComparison.Unmarshal(m, b)
+}
+func (m *GameweekComparison) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GameweekComparison.Marshal(b, m, deterministic)
+}
+func (m *GameweekComparison) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GameweekComparison.Merge(m, src)
+}
+func (m *GameweekComparison) XXX_Size() int {
+	return xxx_messageInfo_GameweekComparison.Size(m)
+}
+func (m *GameweekComparison) XXX_DiscardUnknown() {
+	xxx_messageInfo_GameweekComparison.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GameweekComparison proto.InternalMessageInfo
+
+func (m *GameweekComparison) GetGameweek() int64 {
+	if m != nil {
+		return m.Gameweek
+	}
+	return 0
+}
+
+func (m *GameweekComparison) GetPlayers() []*PlayerComparison {
+	if m != nil {
+		return m.Players
+	}
+	return nil
+}
+
+type LeagueComparison struct {
+	LeagueCodes          []int64               `protobuf:"varint,1,rep,packed,name=leagueCodes,proto3" json:"leagueCodes,omitempty"`
+	SampleSizes          []int64               `protobuf:"varint,2,rep,packed,name=sampleSizes,proto3" json:"sampleSizes,omitempty"`
+	Gameweeks            []*GameweekComparison `protobuf:"bytes,3,rep,name=gameweeks,proto3" json:"gameweeks,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}              `json:"-"`
+	XXX_unrecognized     []byte                `json:"-"`
+	XXX_sizecache        int32                 `json:"-"`
+}
+
+func (m *LeagueComparison) Reset()         { *m = LeagueComparison{} }
+func (m *LeagueComparison) String() string { return proto.CompactTextString(m) }
+func (*LeagueComparison) ProtoMessage()    {}
+func (*LeagueComparison) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{22}
+}
+
+func (m *LeagueComparison) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_LeagueComparison.Unmarshal(m, b)
+}
+func (m *LeagueComparison) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_LeagueComparison.Marshal(b, m, deterministic)
+}
+func (m *LeagueComparison) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_LeagueComparison.Merge(m, src)
+}
+func (m *LeagueComparison) XXX_Size() int {
+	return xxx_messageInfo_LeagueComparison.Size(m)
+}
+func (m *LeagueComparison) XXX_DiscardUnknown() {
+	xxx_messageInfo_LeagueComparison.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_LeagueComparison proto.InternalMessageInfo
+
+func (m *LeagueComparison) GetLeagueCodes() []int64 {
+	if m != nil {
+		return m.LeagueCodes
+	}
+	return nil
+}
+
+func (m *LeagueComparison) GetSampleSizes() []int64 {
+	if m != nil {
+		return m.SampleSizes
+	}
+	return nil
+}
+
+func (m *LeagueComparison) GetGameweeks() []*GameweekComparison {
+	if m != nil {
+		return m.Gameweeks
+	}
+	return nil
+}
+
+type Cohort struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Entries              []int64  `protobuf:"varint,2,rep,packed,name=entries,proto3" json:"entries,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Cohort) Reset()         { *m = Cohort{} }
+func (m *Cohort) String() string { return proto.CompactTextString(m) }
+func (*Cohort) ProtoMessage()    {}
+func (*Cohort) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{23}
+}
+
+func (m *Cohort) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Cohort.Unmarshal(m, b)
+}
+func (m *Cohort) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Cohort.Marshal(b, m, deterministic)
+}
+func (m *Cohort) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Cohort.Merge(m, src)
+}
+func (m *Cohort) XXX_Size() int {
+	return xxx_messageInfo_Cohort.Size(m)
+}
+func (m *Cohort) XXX_DiscardUnknown() {
+	xxx_messageInfo_Cohort.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Cohort proto.InternalMessageInfo
+
+func (m *Cohort) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Cohort) GetEntries() []int64 {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+type CohortName struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CohortName) Reset()         { *m = CohortName{} }
+func (m *CohortName) String() string { return proto.CompactTextString(m) }
+func (*CohortName) ProtoMessage()    {}
+func (*CohortName) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{24}
+}
+
+func (m *CohortName) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CohortName.Unmarshal(m, b)
+}
+func (m *CohortName) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CohortName.Marshal(b, m, deterministic)
+}
+func (m *CohortName) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CohortName.Merge(m, src)
+}
+func (m *CohortName) XXX_Size() int {
+	return xxx_messageInfo_CohortName.Size(m)
+}
+func (m *CohortName) XXX_DiscardUnknown() {
+	xxx_messageInfo_CohortName.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CohortName proto.InternalMessageInfo
+
+func (m *CohortName) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+type CohortsReq struct {
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CohortsReq) Reset()         { *m = CohortsReq{} }
+func (m *CohortsReq) String() string { return proto.CompactTextString(m) }
+func (*CohortsReq) ProtoMessage()    {}
+func (*CohortsReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{25}
+}
+
+func (m *CohortsReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CohortsReq.Unmarshal(m, b)
+}
+func (m *CohortsReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CohortsReq.Marshal(b, m, deterministic)
+}
+func (m *CohortsReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CohortsReq.Merge(m, src)
+}
+func (m *CohortsReq) XXX_Size() int {
+	return xxx_messageInfo_CohortsReq.Size(m)
+}
+func (m *CohortsReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_CohortsReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CohortsReq proto.InternalMessageInfo
+
+type Cohorts struct {
+	Cohorts              []*Cohort `protobuf:"bytes,1,rep,name=cohorts,proto3" json:"cohorts,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *Cohorts) Reset()         { *m = Cohorts{} }
+func (m *Cohorts) String() string { return proto.CompactTextString(m) }
+func (*Cohorts) ProtoMessage()    {}
+func (*Cohorts) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{26}
+}
+
+func (m *Cohorts) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Cohorts.Unmarshal(m, b)
+}
+func (m *Cohorts) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Cohorts.Marshal(b, m, deterministic)
+}
+func (m *Cohorts) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Cohorts.Merge(m, src)
+}
+func (m *Cohorts) XXX_Size() int {
+	return xxx_messageInfo_Cohorts.Size(m)
+}
+func (m *Cohorts) XXX_DiscardUnknown() {
+	xxx_messageInfo_Cohorts.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Cohorts proto.InternalMessageInfo
+
+func (m *Cohorts) GetCohorts() []*Cohort {
+	if m != nil {
+		return m.Cohorts
+	}
+	return nil
+}
+
+type SimilarityReq struct {
+	LeagueCode           int64    `protobuf:"varint,1,opt,name=LeagueCode,proto3" json:"LeagueCode,omitempty"`
+	Gameweek             int64    `protobuf:"varint,2,opt,name=Gameweek,proto3" json:"Gameweek,omitempty"`
+	SampleSize           int64    `protobuf:"varint,3,opt,name=sampleSize,proto3" json:"sampleSize,omitempty"`
+	Cohort               string   `protobuf:"bytes,4,opt,name=cohort,proto3" json:"cohort,omitempty"`
+	Threshold            float64  `protobuf:"fixed64,5,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	HasThreshold         bool     `protobuf:"varint,6,opt,name=hasThreshold,proto3" json:"hasThreshold,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SimilarityReq) Reset()         { *m = SimilarityReq{} }
+func (m *SimilarityReq) String() string { return proto.CompactTextString(m) }
+func (*SimilarityReq) ProtoMessage()    {}
+func (*SimilarityReq) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{27}
+}
+
+func (m *SimilarityReq) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SimilarityReq.Unmarshal(m, b)
+}
+func (m *SimilarityReq) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SimilarityReq.Marshal(b, m, deterministic)
+}
+func (m *SimilarityReq) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SimilarityReq.Merge(m, src)
+}
+func (m *SimilarityReq) XXX_Size() int {
+	return xxx_messageInfo_SimilarityReq.Size(m)
+}
+func (m *SimilarityReq) XXX_DiscardUnknown() {
+	xxx_messageInfo_SimilarityReq.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SimilarityReq proto.InternalMessageInfo
+
+func (m *SimilarityReq) GetLeagueCode() int64 {
+	if m != nil {
+		return m.LeagueCode
+	}
+	return 0
+}
+
+func (m *SimilarityReq) GetGameweek() int64 {
+	if m != nil {
+		return m.Gameweek
+	}
+	return 0
+}
+
+func (m *SimilarityReq) GetSampleSize() int64 {
+	if m != nil {
+		return m.SampleSize
+	}
+	return 0
+}
+
+func (m *SimilarityReq) GetCohort() string {
+	if m != nil {
+		return m.Cohort
+	}
+	return ""
+}
+
+func (m *SimilarityReq) GetThreshold() float64 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func (m *SimilarityReq) GetHasThreshold() bool {
+	if m != nil {
+		return m.HasThreshold
+	}
+	return false
+}
+
+type ManagerSimilarity struct {
+	Entry                int64     `protobuf:"varint,1,opt,name=entry,proto3" json:"entry,omitempty"`
+	Similarities         []float64 `protobuf:"fixed64,2,rep,packed,name=similarities,proto3" json:"similarities,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}  `json:"-"`
+	XXX_unrecognized     []byte    `json:"-"`
+	XXX_sizecache        int32     `json:"-"`
+}
+
+func (m *ManagerSimilarity) Reset()         { *m = ManagerSimilarity{} }
+func (m *ManagerSimilarity) String() string { return proto.CompactTextString(m) }
+func (*ManagerSimilarity) ProtoMessage()    {}
+func (*ManagerSimilarity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{28}
+}
+
+func (m *ManagerSimilarity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ManagerSimilarity.Unmarshal(m, b)
+}
+func (m *ManagerSimilarity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ManagerSimilarity.Marshal(b, m, deterministic)
+}
+func (m *ManagerSimilarity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ManagerSimilarity.Merge(m, src)
+}
+func (m *ManagerSimilarity) XXX_Size() int {
+	return xxx_messageInfo_ManagerSimilarity.Size(m)
+}
+func (m *ManagerSimilarity) XXX_DiscardUnknown() {
+	xxx_messageInfo_ManagerSimilarity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ManagerSimilarity proto.InternalMessageInfo
+
+func (m *ManagerSimilarity) GetEntry() int64 {
+	if m != nil {
+		return m.Entry
+	}
+	return 0
+}
+
+func (m *ManagerSimilarity) GetSimilarities() []float64 {
+	if m != nil {
+		return m.Similarities
+	}
+	return nil
+}
+
+type ManagerCluster struct {
+	Entries              []int64  `protobuf:"varint,1,rep,packed,name=entries,proto3" json:"entries,omitempty"`
+	Representative       int64    `protobuf:"varint,2,opt,name=representative,proto3" json:"representative,omitempty"`
+	Template             []string `protobuf:"bytes,3,rep,name=template,proto3" json:"template,omitempty"`
+	Cohesion             float64  `protobuf:"fixed64,4,opt,name=cohesion,proto3" json:"cohesion,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ManagerCluster) Reset()         { *m = ManagerCluster{} }
+func (m *ManagerCluster) String() string { return proto.CompactTextString(m) }
+func (*ManagerCluster) ProtoMessage()    {}
+func (*ManagerCluster) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{29}
+}
+
+func (m *ManagerCluster) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ManagerCluster.Unmarshal(m, b)
+}
+func (m *ManagerCluster) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ManagerCluster.Marshal(b, m, deterministic)
+}
+func (m *ManagerCluster) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ManagerCluster.Merge(m, src)
+}
+func (m *ManagerCluster) XXX_Size() int {
+	return xxx_messageInfo_ManagerCluster.Size(m)
+}
+func (m *ManagerCluster) XXX_DiscardUnknown() {
+	xxx_messageInfo_ManagerCluster.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ManagerCluster proto.InternalMessageInfo
+
+func (m *ManagerCluster) GetEntries() []int64 {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *ManagerCluster) GetRepresentative() int64 {
+	if m != nil {
+		return m.Representative
+	}
+	return 0
+}
+
+func (m *ManagerCluster) GetTemplate() []string {
+	if m != nil {
+		return m.Template
+	}
+	return nil
+}
+
+func (m *ManagerCluster) GetCohesion() float64 {
+	if m != nil {
+		return m.Cohesion
+	}
+	return 0
+}
+
+type Similarity struct {
+	Gameweek             int64                `protobuf:"varint,1,opt,name=Gameweek,proto3" json:"Gameweek,omitempty"`
+	Entries              []int64              `protobuf:"varint,2,rep,packed,name=entries,proto3" json:"entries,omitempty"`
+	Managers             []*ManagerSimilarity `protobuf:"bytes,3,rep,name=managers,proto3" json:"managers,omitempty"`
+	Clusters             []*ManagerCluster    `protobuf:"bytes,4,rep,name=clusters,proto3" json:"clusters,omitempty"`
+	AverageSimilarity    float64              `protobuf:"fixed64,5,opt,name=averageSimilarity,proto3" json:"averageSimilarity,omitempty"`
+	Threshold            float64              `protobuf:"fixed64,6,opt,name=threshold,proto3" json:"threshold,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Similarity) Reset()         { *m = Similarity{} }
+func (m *Similarity) String() string { return proto.CompactTextString(m) }
+func (*Similarity) ProtoMessage()    {}
+func (*Similarity) Descriptor() ([]byte, []int) {
+	return fileDescriptor_00519c44ffdc0ef5, []int{30}
+}
+
+func (m *Similarity) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Similarity.Unmarshal(m, b)
+}
+func (m *Similarity) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Similarity.Marshal(b, m, deterministic)
+}
+func (m *Similarity) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Similarity.Merge(m, src)
+}
+func (m *Similarity) XXX_Size() int {
+	return xxx_messageInfo_Similarity.Size(m)
+}
+func (m *Similarity) XXX_DiscardUnknown() {
+	xxx_messageInfo_Similarity.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Similarity proto.InternalMessageInfo
+
+func (m *Similarity) GetGameweek() int64 {
+	if m != nil {
+		return m.Gameweek
+	}
+	return 0
+}
+
+func (m *Similarity) GetEntries() []int64 {
+	if m != nil {
+		return m.Entries
+	}
+	return nil
+}
+
+func (m *Similarity) GetManagers() []*ManagerSimilarity {
+	if m != nil {
+		return m.Managers
+	}
+	return nil
+}
+
+func (m *Similarity) GetClusters() []*ManagerCluster {
+	if m != nil {
+		return m.Clusters
+	}
+	return nil
+}
+
+func (m *Similarity) GetAverageSimilarity() float64 {
+	if m != nil {
+		return m.AverageSimilarity
+	}
+	return 0
+}
+
+func (m *Similarity) GetThreshold() float64 {
+	if m != nil {
+		return m.Threshold
+	}
+	return 0
+}
+
+func init() {
+	proto.RegisterType((*NumPlayerRequest)(nil), "grpc.NumPlayerRequest")
+	proto.RegisterType((*NumPlayers)(nil), "grpc.NumPlayers")
+	proto.RegisterType((*LeagueCode)(nil), "grpc.LeagueCode")
+	proto.RegisterType((*NumParticipants)(nil), "grpc.numParticipants")
+	proto.RegisterType((*GameweekReq)(nil), "grpc.GameweekReq")
+	proto.RegisterType((*GameweekSelection)(nil), "grpc.GameweekSelection")
+	proto.RegisterType((*AllGameweeksReq)(nil), "grpc.AllGameweeksReq")
+	proto.RegisterType((*PlayerOccuranceData)(nil), "grpc.PlayerOccuranceData")
+	proto.RegisterMapType((map[string]int32)(nil), "grpc.PlayerOccuranceData.PlayerOccuranceEntry")
+	proto.RegisterType((*AllGameweekData)(nil), "grpc.AllGameweekData")
+	proto.RegisterType((*LiveReq)(nil), "grpc.LiveReq")
+	proto.RegisterType((*LiveManager)(nil), "grpc.LiveManager")
+	proto.RegisterType((*LiveStandings)(nil), "grpc.LiveStandings")
+	proto.RegisterType((*SubscribeReq)(nil), "grpc.SubscribeReq")
+	proto.RegisterType((*Update)(nil), "grpc.Update")
+	proto.RegisterMapType((map[string]int32)(nil), "grpc.Update.PlayerOccuranceEntry")
+	proto.RegisterType((*UsageReq)(nil), "grpc.UsageReq")
+	proto.RegisterType((*KeyUsage)(nil), "grpc.KeyUsage")
+	proto.RegisterType((*Usage)(nil), "grpc.Usage")
+	proto.RegisterType((*HeatmapReq)(nil), "grpc.HeatmapReq")
+	proto.RegisterType((*Heatmap)(nil), "grpc.Heatmap")
+	proto.RegisterType((*CompareReq)(nil), "grpc.CompareReq")
+	proto.RegisterType((*PlayerComparison)(nil), "grpc.PlayerComparison")
+	proto.RegisterType((*GameweekComparison)(nil), "grpc.GameweekComparison")
+	proto.RegisterType((*LeagueComparison)(nil), "grpc.LeagueComparison")
+	proto.RegisterType((*Cohort)(nil), "grpc.Cohort")
+	proto.RegisterType((*CohortName)(nil), "grpc.CohortName")
+	proto.RegisterType((*CohortsReq)(nil), "grpc.CohortsReq")
+	proto.RegisterType((*Cohorts)(nil), "grpc.Cohorts")
+	proto.RegisterType((*SimilarityReq)(nil), "grpc.SimilarityReq")
+	proto.RegisterType((*ManagerSimilarity)(nil), "grpc.ManagerSimilarity")
+	proto.RegisterType((*ManagerCluster)(nil), "grpc.ManagerCluster")
+	proto.RegisterType((*Similarity)(nil), "grpc.Similarity")
+	proto.RegisterEnum("grpc.ExportFormat", ExportFormat_name, ExportFormat_value)
+	proto.RegisterEnum("grpc.OwnershipSort", OwnershipSort_name, OwnershipSort_value)
+	proto.RegisterEnum("grpc.UpdateType", UpdateType_name, UpdateType_value)
+	proto.RegisterEnum("grpc.ImageFormat", ImageFormat_name, ImageFormat_value)
+}
+
+func init() { proto.RegisterFile("grpc/fpl.proto", fileDescriptor_00519c44ffdc0ef5) }
+
+var fileDescriptor_00519c44ffdc0ef5 = []byte{
+	// 1783 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xc4, 0x58, 0xcd, 0x73, 0x1b, 0x49,
+	0x15, 0xd7, 0x68, 0xf4, 0xe5, 0x27, 0x59, 0x19, 0x77, 0xb2, 0x5e, 0xa1, 0xa2, 0x16, 0xd1, 0xb5,
+	0xb5, 0x78, 0x4d, 0xc8, 0x7a, 0xb5, 0xb5, 0xcb, 0x16, 0x70, 0x40, 0xd8, 0x8a, 0xe3, 0xb5, 0x23,
+	0x9b, 0x96, 0x9c, 0xe4, 0x16, 0xda, 0x52, 0x5b, 0x1e, 0x3c, 0x9a, 0x99, 0x4c, 0xb7, 0x9c, 0x35,
+	0xc5, 0x85, 0x03, 0x55, 0x14, 0x07, 0xfe, 0x07, 0x0e, 0x9c, 0x39, 0xf0, 0x07, 0x70, 0xe2, 0x5f,
+	0xe1, 0xce, 0x81, 0x3b, 0xd5, 0x1f, 0xa3, 0xe9, 0x19, 0xe5, 0x8b, 0xaa, 0x50, 0xdc, 0xe6, 0xbd,
+	0x7e, 0xaf, 0xbb, 0xdf, 0xef, 0x7d, 0xf6, 0x40, 0x7b, 0x9e, 0xc4, 0xd3, 0xcf, 0x2e, 0xe3, 0xe0,
+	0x41, 0x9c, 0x44, 0x22, 0x42, 0x15, 0x49, 0x63, 0x04, 0xde, 0x68, 0xb9, 0x38, 0x0b, 0xe8, 0x2d,
+	0x4b, 0x08, 0x7b, 0xb1, 0x64, 0x5c, 0xe0, 0xfb, 0x00, 0x2b, 0x1e, 0x47, 0x1f, 0x01, 0x84, 0x2b,
+	0xaa, 0xe3, 0xf4, 0x9c, 0x1d, 0x97, 0x58, 0x1c, 0x29, 0x7d, 0xc2, 0xe8, 0x7c, 0xc9, 0xf6, 0xa3,
+	0x19, 0x43, 0x1f, 0xd9, 0x54, 0x2a, 0x9d, 0x71, 0xf0, 0x4f, 0xe1, 0x8e, 0xd4, 0xa5, 0x89, 0xf0,
+	0xa7, 0x7e, 0x4c, 0x43, 0xc1, 0xd1, 0xce, 0x1a, 0xcb, 0xe8, 0x15, 0xd9, 0xf8, 0x77, 0x0e, 0x34,
+	0x0f, 0xe9, 0x82, 0xbd, 0x64, 0xec, 0x9a, 0xb0, 0x17, 0x6f, 0x3b, 0x0c, 0x75, 0xa1, 0x91, 0x8a,
+	0x77, 0xca, 0x6a, 0x75, 0x45, 0x4b, 0x5d, 0x4e, 0x17, 0x71, 0xc0, 0xc6, 0xfe, 0x6f, 0x58, 0xc7,
+	0xd5, 0xba, 0x19, 0x07, 0x6d, 0x43, 0x6d, 0x1a, 0x5d, 0x45, 0x89, 0xe8, 0x54, 0x7a, 0xce, 0xce,
+	0x06, 0x31, 0x14, 0xf6, 0x61, 0x2b, 0xdd, 0x63, 0xcc, 0x02, 0x36, 0x15, 0x7e, 0x14, 0x22, 0x04,
+	0x95, 0xcb, 0x24, 0x5a, 0x98, 0x2b, 0xa8, 0x6f, 0xd4, 0x86, 0xb2, 0x88, 0xcc, 0xb1, 0x65, 0x11,
+	0xa1, 0xef, 0xc2, 0xc6, 0xdc, 0x28, 0xf2, 0x8e, 0xdb, 0x73, 0x77, 0x5c, 0x92, 0x31, 0xe4, 0x0e,
+	0x01, 0xe5, 0xfa, 0x30, 0x97, 0xa8, 0x6f, 0xfc, 0xd7, 0x32, 0xdc, 0x19, 0x04, 0x41, 0x7a, 0x1c,
+	0x7f, 0x17, 0x93, 0xf3, 0x66, 0x95, 0xd7, 0xcc, 0xda, 0x85, 0xda, 0x65, 0x94, 0x2c, 0xa8, 0x50,
+	0x26, 0xb7, 0xfb, 0xe8, 0x81, 0x0c, 0x83, 0x07, 0xc3, 0x6f, 0xe3, 0x28, 0x11, 0x0f, 0xd5, 0x0a,
+	0x31, 0x12, 0xe8, 0x07, 0x50, 0xe1, 0x29, 0x00, 0xed, 0xfe, 0x5d, 0x2d, 0x79, 0xfa, 0x32, 0x64,
+	0x09, 0xbf, 0xf2, 0xe3, 0x71, 0x94, 0x08, 0xa2, 0x04, 0x24, 0x56, 0xd1, 0xe5, 0x25, 0x67, 0xa2,
+	0x53, 0x55, 0x07, 0x1a, 0x0a, 0xf5, 0xa0, 0x99, 0x30, 0xbe, 0x5c, 0xb0, 0x49, 0x74, 0xcd, 0xc2,
+	0x4e, 0x4d, 0x01, 0x69, 0xb3, 0xd0, 0x97, 0x36, 0x28, 0xf5, 0x9e, 0xb3, 0xd3, 0xec, 0x7f, 0xa8,
+	0xcf, 0x59, 0x03, 0xd9, 0x46, 0x2b, 0x73, 0x4e, 0x23, 0xe7, 0x9c, 0xbf, 0x39, 0x70, 0x57, 0xc7,
+	0xe5, 0xe9, 0x74, 0xba, 0x4c, 0x68, 0x38, 0x65, 0x07, 0x54, 0x50, 0xf4, 0x0c, 0xee, 0xc4, 0x79,
+	0x76, 0xc7, 0xe9, 0xb9, 0x3b, 0xcd, 0xfe, 0x03, 0x7d, 0xd8, 0x2b, 0x74, 0x8a, 0xbc, 0x61, 0x28,
+	0x92, 0x5b, 0x52, 0xdc, 0xa6, 0xfb, 0x0b, 0xb8, 0xf7, 0x2a, 0x41, 0xe4, 0x81, 0x7b, 0xcd, 0x6e,
+	0x95, 0x83, 0x36, 0x88, 0xfc, 0x44, 0xf7, 0xa0, 0x7a, 0x43, 0x83, 0xa5, 0x76, 0x4a, 0x95, 0x68,
+	0xe2, 0x27, 0xe5, 0xaf, 0x1d, 0xfc, 0x32, 0xe7, 0x66, 0x75, 0x61, 0x04, 0x95, 0x19, 0x15, 0x54,
+	0xe9, 0xb7, 0x88, 0xfa, 0xb6, 0x50, 0x2e, 0xbf, 0x09, 0x65, 0x77, 0x1d, 0xe5, 0x6d, 0xa8, 0xcd,
+	0xfc, 0x39, 0xe3, 0xab, 0x58, 0xd6, 0x14, 0xfe, 0x8b, 0x03, 0xf5, 0x13, 0xff, 0x86, 0xfd, 0xaf,
+	0x73, 0xe9, 0x13, 0x68, 0x27, 0xec, 0x32, 0x61, 0xfc, 0x6a, 0xcc, 0xa6, 0x51, 0x38, 0xe3, 0x26,
+	0xcc, 0x0b, 0x5c, 0xcb, 0xad, 0xd5, 0x9c, 0x5b, 0xff, 0xee, 0x40, 0x53, 0xde, 0xf3, 0x31, 0x0d,
+	0xe9, 0x9c, 0x25, 0x12, 0x4a, 0x26, 0x51, 0x36, 0xd7, 0xd4, 0x84, 0xbc, 0x45, 0xe0, 0xdf, 0xb0,
+	0xb3, 0xc8, 0x97, 0x25, 0xc4, 0x84, 0x7e, 0xc6, 0x91, 0x38, 0x89, 0x48, 0xd0, 0xc0, 0x08, 0xe8,
+	0x6b, 0xda, 0x2c, 0x69, 0xa3, 0x94, 0x27, 0x34, 0xbc, 0x36, 0x37, 0x5c, 0xd1, 0x08, 0x43, 0x2b,
+	0x4e, 0xd8, 0x8d, 0x1f, 0x2d, 0xb9, 0x5a, 0xd7, 0x91, 0x9e, 0xe3, 0xa1, 0x0e, 0xd4, 0xa7, 0x34,
+	0x16, 0xd4, 0x4f, 0x63, 0x3d, 0x25, 0xf1, 0xb7, 0xb0, 0x29, 0x0d, 0x18, 0x0b, 0x1a, 0xce, 0xfc,
+	0x70, 0xce, 0x73, 0x70, 0x3a, 0x05, 0x38, 0x7f, 0x04, 0x8d, 0x85, 0xb6, 0x54, 0x9a, 0x21, 0xc3,
+	0x74, 0x4b, 0x87, 0xa9, 0x85, 0x01, 0x59, 0x89, 0xc8, 0xc2, 0xb2, 0x8c, 0x67, 0x54, 0xb0, 0xd9,
+	0x40, 0x18, 0xab, 0x32, 0x06, 0xfe, 0x15, 0xb4, 0xc6, 0xcb, 0x0b, 0x3e, 0x4d, 0xfc, 0x8b, 0x77,
+	0xf2, 0x73, 0x07, 0xea, 0xb1, 0xa9, 0xf5, 0xf2, 0xec, 0x0d, 0x92, 0x92, 0xca, 0x3b, 0xcb, 0x84,
+	0x47, 0x89, 0x39, 0xc4, 0x50, 0xf8, 0x0f, 0x2e, 0xd4, 0xce, 0xd5, 0x79, 0x96, 0x88, 0x63, 0x8b,
+	0xa0, 0x8f, 0xa1, 0x22, 0x6e, 0x63, 0x1d, 0xfa, 0xed, 0xbe, 0xa7, 0xad, 0xd1, 0x3a, 0x93, 0xdb,
+	0x98, 0x11, 0xb5, 0x5a, 0xb8, 0x9a, 0xfb, 0xc6, 0x10, 0xac, 0x14, 0x30, 0x3b, 0x5e, 0xcf, 0xf0,
+	0xaa, 0x82, 0xee, 0xfb, 0xf6, 0x61, 0xef, 0x96, 0xd4, 0x32, 0x5e, 0xa7, 0x57, 0x34, 0x9c, 0xb3,
+	0x59, 0xda, 0xf6, 0x6a, 0x0a, 0x8a, 0x02, 0x37, 0xe7, 0xa8, 0xfa, 0x7f, 0xe9, 0xa8, 0x46, 0xc1,
+	0x51, 0xef, 0xa5, 0x92, 0x00, 0x34, 0xce, 0x39, 0x9d, 0x4b, 0x47, 0xe3, 0x7f, 0x3a, 0xd0, 0x38,
+	0x66, 0xb7, 0x8a, 0x96, 0xf5, 0x24, 0xa4, 0x0b, 0x66, 0x76, 0x51, 0xdf, 0x72, 0x1b, 0x3a, 0x5b,
+	0xf8, 0xa1, 0xda, 0xa6, 0x41, 0x34, 0x21, 0x41, 0x4e, 0xf4, 0x1c, 0x90, 0xa6, 0xc8, 0x8a, 0xd6,
+	0x6b, 0xbf, 0x66, 0x53, 0xc1, 0x66, 0xa9, 0x03, 0x52, 0x5a, 0xee, 0xf6, 0x62, 0x19, 0x09, 0x6a,
+	0x12, 0x43, 0x13, 0xd2, 0xe4, 0x84, 0x2d, 0xa8, 0x1f, 0xfa, 0xe1, 0x5c, 0xe5, 0x84, 0x4b, 0x32,
+	0x06, 0xfa, 0x18, 0x36, 0x95, 0x18, 0x61, 0x9c, 0x09, 0x3e, 0x10, 0xaa, 0x03, 0xb8, 0x24, 0xcf,
+	0x54, 0x79, 0x4d, 0xb9, 0x38, 0xe7, 0x16, 0x6e, 0x16, 0x07, 0xff, 0x10, 0xaa, 0xda, 0x48, 0x0c,
+	0x95, 0x6b, 0x76, 0xcb, 0x4d, 0x69, 0x6f, 0x6b, 0x57, 0xa4, 0x10, 0x10, 0xb5, 0x86, 0xff, 0xec,
+	0x00, 0x3c, 0x62, 0x54, 0x2c, 0x68, 0xfc, 0x3e, 0xda, 0xe9, 0xa7, 0x85, 0x76, 0x6a, 0xfc, 0x7f,
+	0xb4, 0xa0, 0x73, 0x56, 0xe8, 0xa6, 0x1e, 0xb8, 0x22, 0x8a, 0x0d, 0x6e, 0xf2, 0xf3, 0xb5, 0xe5,
+	0x6e, 0x00, 0x75, 0x73, 0x45, 0x89, 0xaa, 0x2f, 0xf7, 0x32, 0x8d, 0x40, 0x13, 0xb2, 0x92, 0x4d,
+	0xa3, 0x50, 0xb0, 0x50, 0x4c, 0xd2, 0xac, 0xda, 0x20, 0x36, 0x0b, 0xff, 0xde, 0x01, 0xd8, 0x8f,
+	0x16, 0x31, 0x4d, 0x54, 0xd2, 0xf7, 0xa0, 0x19, 0xac, 0x8c, 0xd2, 0x00, 0xb9, 0xc4, 0x66, 0xbd,
+	0xd5, 0xd0, 0x2f, 0xf3, 0xd3, 0xcb, 0x3b, 0x36, 0x6a, 0xfc, 0x5b, 0xf0, 0x74, 0x50, 0xeb, 0xcb,
+	0xf8, 0x3c, 0x52, 0xdd, 0x48, 0x27, 0x9c, 0x89, 0x46, 0x43, 0x49, 0x7e, 0xf4, 0x32, 0x4c, 0x0b,
+	0x8f, 0x4b, 0x0c, 0x25, 0x2f, 0x1f, 0xb3, 0x64, 0xca, 0x42, 0x41, 0xe7, 0x4c, 0x8f, 0x4e, 0x0e,
+	0xb1, 0x59, 0xaa, 0xbf, 0xb1, 0x40, 0x50, 0xd9, 0x57, 0xe4, 0xa2, 0xa1, 0xf0, 0x05, 0xa0, 0xf4,
+	0x76, 0xd6, 0xf9, 0x6f, 0x2a, 0xbd, 0x7b, 0xf9, 0xea, 0xd7, 0xec, 0x6f, 0xdb, 0x03, 0x42, 0xb6,
+	0xc9, 0xaa, 0x2a, 0xe2, 0x3f, 0x39, 0xe0, 0xa5, 0x01, 0xb3, 0x3a, 0xe2, 0xed, 0x78, 0xf7, 0xa0,
+	0x99, 0xa1, 0x9b, 0x5a, 0x6c, 0xb3, 0xd0, 0x57, 0xc5, 0x79, 0xb1, 0xd9, 0xef, 0xe4, 0x11, 0xb7,
+	0xae, 0x63, 0x41, 0xfe, 0x15, 0xd4, 0xf6, 0x55, 0x1c, 0xbd, 0x32, 0xe9, 0x3b, 0x50, 0x97, 0xdd,
+	0xd2, 0x5f, 0x9d, 0x99, 0x92, 0xb8, 0x07, 0xa0, 0xf5, 0x46, 0x52, 0xee, 0x15, 0xba, 0xb8, 0x95,
+	0x4a, 0xc8, 0x49, 0x14, 0x7f, 0x0e, 0x75, 0x43, 0xa1, 0x4f, 0xa0, 0xae, 0x43, 0x37, 0xcd, 0xbd,
+	0x96, 0xbe, 0xa8, 0x5e, 0x27, 0xe9, 0x22, 0xfe, 0x87, 0x03, 0x9b, 0x63, 0x7f, 0xe1, 0x07, 0x34,
+	0xf1, 0xc5, 0xed, 0xff, 0x69, 0x82, 0x97, 0x35, 0x49, 0x5c, 0xc9, 0xb1, 0x23, 0x0a, 0x66, 0x2a,
+	0xf3, 0x1c, 0x92, 0x31, 0x64, 0x9f, 0xbf, 0xa2, 0x7c, 0xb2, 0x12, 0xa8, 0xa9, 0xe2, 0x98, 0xe3,
+	0xe1, 0xc7, 0xb0, 0x65, 0xaa, 0x7b, 0x66, 0xcd, 0x6b, 0x86, 0x12, 0x0c, 0x2d, 0x9e, 0xca, 0xa4,
+	0xa0, 0x3b, 0x24, 0xc7, 0xc3, 0x7f, 0x74, 0xa0, 0x6d, 0xf6, 0xdb, 0x0f, 0x96, 0x5c, 0xb0, 0xc4,
+	0x76, 0x93, 0x93, 0x73, 0x93, 0x9e, 0xa5, 0xe2, 0x84, 0x71, 0x19, 0xfc, 0xc2, 0xbf, 0x49, 0x93,
+	0xb5, 0xc0, 0x95, 0xc8, 0x09, 0xb6, 0x88, 0x03, 0x2a, 0x98, 0x8a, 0x9e, 0x0d, 0xb2, 0xa2, 0xe5,
+	0xda, 0x34, 0xba, 0x62, 0xdc, 0x8f, 0x42, 0x85, 0x8d, 0x43, 0x56, 0x34, 0xfe, 0xb7, 0x03, 0x60,
+	0x59, 0xf5, 0xa6, 0x64, 0x79, 0x6d, 0x2c, 0xa1, 0x2f, 0xac, 0xc6, 0xa8, 0x43, 0xd7, 0x14, 0x8b,
+	0x35, 0xd8, 0xac, 0xf6, 0xb8, 0x07, 0x8d, 0xa9, 0x36, 0x5f, 0xe7, 0x71, 0xb3, 0x7f, 0x2f, 0xa7,
+	0x64, 0xb0, 0x21, 0x2b, 0x29, 0x74, 0x1f, 0xb6, 0xe8, 0x0d, 0x4b, 0xe8, 0x9c, 0x65, 0x1b, 0x1a,
+	0x8f, 0xae, 0x2f, 0xe4, 0xfd, 0x5e, 0x2b, 0xf8, 0x7d, 0x97, 0x40, 0xcb, 0x7e, 0x04, 0xa1, 0x3a,
+	0xb8, 0xfb, 0xe3, 0x27, 0x5e, 0x09, 0x35, 0xa0, 0xf2, 0xcd, 0xf8, 0x74, 0xe4, 0x39, 0x08, 0xa0,
+	0x36, 0x3a, 0x50, 0xdf, 0x65, 0xd4, 0x82, 0xc6, 0xe3, 0x01, 0x39, 0x3e, 0x38, 0x7d, 0x3a, 0xf2,
+	0x5c, 0x29, 0xf3, 0xec, 0x64, 0xfc, 0xcc, 0xab, 0xa0, 0x26, 0xd4, 0xcf, 0x06, 0xe4, 0x97, 0xe7,
+	0xc3, 0x89, 0x57, 0xdd, 0xdd, 0x83, 0xcd, 0xdc, 0x73, 0x09, 0x6d, 0x40, 0x75, 0x72, 0x3a, 0x19,
+	0x9c, 0x78, 0x25, 0xb9, 0xd9, 0xc9, 0x60, 0x32, 0x1c, 0x4f, 0x3c, 0x47, 0xaa, 0x9f, 0x0d, 0x07,
+	0xc7, 0x5e, 0x79, 0x77, 0x0c, 0x90, 0x8d, 0x45, 0x72, 0xb3, 0xf3, 0xd1, 0xf1, 0x48, 0x9e, 0x51,
+	0x42, 0x1e, 0xb4, 0x46, 0xc3, 0xa7, 0xcf, 0x0f, 0x07, 0x8f, 0x87, 0x4f, 0x87, 0xc3, 0x63, 0xcf,
+	0x41, 0x1f, 0xc0, 0xd6, 0xe9, 0xd3, 0xd1, 0x90, 0x8c, 0x1f, 0x1d, 0x9d, 0x3d, 0xdf, 0x7f, 0x34,
+	0x18, 0x1d, 0x0e, 0x0f, 0xbc, 0x32, 0xba, 0x03, 0xcd, 0x93, 0xa3, 0x27, 0xc3, 0xe7, 0x67, 0xa7,
+	0x47, 0xa3, 0xc9, 0xd8, 0x73, 0x77, 0xbf, 0x07, 0x4d, 0xab, 0x21, 0x49, 0xcb, 0xce, 0x46, 0x87,
+	0x5e, 0x49, 0x7e, 0x8c, 0x9f, 0x1c, 0x7a, 0x4e, 0xff, 0x5f, 0x55, 0x70, 0x1f, 0x9e, 0x9d, 0xa0,
+	0x9f, 0x03, 0x9a, 0x33, 0x31, 0x5a, 0x2e, 0x2e, 0x58, 0x72, 0x7a, 0x99, 0x4e, 0x39, 0xa6, 0x04,
+	0x16, 0x7f, 0x13, 0x74, 0xbd, 0x02, 0x9f, 0xe3, 0x12, 0x3a, 0x80, 0x0f, 0xe7, 0x4c, 0xd8, 0x8f,
+	0xf6, 0xa3, 0x50, 0xa7, 0x33, 0x32, 0xe2, 0x59, 0x72, 0x77, 0x3f, 0xd0, 0x9c, 0xe2, 0x2b, 0x5f,
+	0xee, 0x22, 0xef, 0x21, 0x1f, 0x42, 0x0f, 0xa3, 0x64, 0x15, 0x6e, 0x5b, 0xf9, 0xea, 0x47, 0xd8,
+	0x8b, 0xee, 0x77, 0x5e, 0xfb, 0x7c, 0xc3, 0x25, 0xf4, 0x0d, 0x6c, 0x67, 0xbb, 0xd8, 0xef, 0x68,
+	0x64, 0x0e, 0x2e, 0xbc, 0xad, 0xbb, 0xeb, 0x6c, 0xbd, 0xd3, 0x9e, 0x83, 0x7e, 0x0c, 0x9b, 0x73,
+	0x26, 0x4e, 0xb2, 0xc7, 0xc4, 0x66, 0x36, 0xe8, 0x49, 0xd5, 0xbb, 0x19, 0xb9, 0x9a, 0xf1, 0x95,
+	0xe2, 0xe7, 0xb0, 0xc1, 0xd3, 0xf1, 0x1b, 0x99, 0xc7, 0xb6, 0x3d, 0x8f, 0x77, 0x5b, 0xf6, 0x7c,
+	0xaa, 0x54, 0x3e, 0x85, 0xc6, 0x9c, 0x09, 0x3d, 0xd2, 0x98, 0x21, 0x26, 0x1d, 0xea, 0xba, 0x4d,
+	0x8b, 0xc6, 0x25, 0xf4, 0x19, 0xc0, 0x9c, 0x89, 0x74, 0x58, 0x30, 0x08, 0x67, 0xe3, 0x4d, 0x77,
+	0x33, 0xc7, 0xc1, 0x25, 0xf4, 0x33, 0x68, 0x4f, 0xf5, 0x58, 0xa0, 0xfd, 0xc0, 0x53, 0xa5, 0x6c,
+	0x58, 0xe8, 0x6e, 0xe7, 0x1d, 0x95, 0xf6, 0x18, 0x5c, 0x42, 0xf7, 0xa1, 0x35, 0x63, 0x97, 0x7e,
+	0xc8, 0x4c, 0x83, 0xc9, 0x95, 0xf9, 0x6e, 0x8e, 0xc2, 0x25, 0xb4, 0x07, 0xcd, 0xc0, 0xe7, 0x22,
+	0x6d, 0x12, 0x9e, 0xbd, 0xcc, 0xad, 0xdb, 0x19, 0x8e, 0xd2, 0x68, 0xcd, 0x58, 0xc0, 0x44, 0xba,
+	0x7f, 0x4e, 0x45, 0xb6, 0xa5, 0xb5, 0x33, 0xbe, 0x56, 0x7e, 0xb1, 0x92, 0xdc, 0x38, 0x22, 0xd7,
+	0x65, 0xba, 0x5e, 0x91, 0x89, 0x4b, 0x17, 0x35, 0xf5, 0x17, 0xec, 0x8b, 0xff, 0x0c, 0x00, 0x60,
+	0x70, 0x42, 0x92, 0x17, 0x13, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConn
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion4
+
+// FPLClient is the client API for FPL service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type FPLClient interface {
+	GetNumberOfPlayers(ctx context.Context, in *NumPlayerRequest, opts ...grpc.CallOption) (*NumPlayers, error)
+	GetParticipantsInLeague(ctx context.Context, in *LeagueCode, opts ...grpc.CallOption) (*NumParticipants, error)
+	GetDataForGameweek(ctx context.Context, in *GameweekReq, opts ...grpc.CallOption) (*PlayerOccuranceData, error)
+	GetDataForAllGameweeks(ctx context.Context, in *AllGameweeksReq, opts ...grpc.CallOption) (FPL_GetDataForAllGameweeksClient, error)
+	GetLivePoints(ctx context.Context, in *LiveReq, opts ...grpc.CallOption) (FPL_GetLivePointsClient, error)
+	Subscribe(ctx context.Context, in *SubscribeReq, opts ...grpc.CallOption) (FPL_SubscribeClient, error)
+	GetUsage(ctx context.Context, in *UsageReq, opts ...grpc.CallOption) (*Usage, error)
+	GetHeatmap(ctx context.Context, in *HeatmapReq, opts ...grpc.CallOption) (*Heatmap, error)
+	CompareLeagues(ctx context.Context, in *CompareReq, opts ...grpc.CallOption) (*LeagueComparison, error)
+	DefineCohort(ctx context.Context, in *Cohort, opts ...grpc.CallOption) (*Cohort, error)
+	ListCohorts(ctx context.Context, in *CohortsReq, opts ...grpc.CallOption) (*Cohorts, error)
+	DeleteCohort(ctx context.Context, in *CohortName, opts ...grpc.CallOption) (*Cohort, error)
+	GetSimilarity(ctx context.Context, in *SimilarityReq, opts ...grpc.CallOption) (*Similarity, error)
+}
+
+type fPLClient struct {
+	cc *grpc.ClientConn
+}
+
+func NewFPLClient(cc *grpc.ClientConn) FPLClient {
+	return &fPLClient{cc}
+}
+
+func (c *fPLClient) GetNumberOfPlayers(ctx context.Context, in *NumPlayerRequest, opts ...grpc.CallOption) (*NumPlayers, error) {
+	out := new(NumPlayers)
+	err := c.cc.Invoke(ctx, "/grpc.FPL/getNumberOfPlayers", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fPLClient) GetParticipantsInLeague(ctx context.Context, in *LeagueCode, opts ...grpc.CallOption) (*NumParticipants, error) {
+	out := new(NumParticipants)
+	err := c.cc.Invoke(ctx, "/grpc.FPL/getParticipantsInLeague", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fPLClient) GetDataForGameweek(ctx context.Context, in *GameweekReq, opts ...grpc.CallOption) (*PlayerOccuranceData, error) {
+	out := new(PlayerOccuranceData)
+	err := c.cc.Invoke(ctx, "/grpc.FPL/getDataForGameweek", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fPLClient) GetDataForAllGameweeks(ctx context.Context, in *AllGameweeksReq, opts ...grpc.CallOption) (FPL_GetDataForAllGameweeksClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FPL_serviceDesc.Streams[0], "/grpc.FPL/getDataForAllGameweeks", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fPLGetDataForAllGameweeksClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FPL_GetDataForAllGameweeksClient interface {
+	Recv() (*AllGameweekData, error)
+	grpc.ClientStream
+}
+
+type fPLGetDataForAllGameweeksClient struct {
+	grpc.ClientStream
+}
+
+func (x *fPLGetDataForAllGameweeksClient) Recv() (*AllGameweekData, error) {
+	m := new(AllGameweekData)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *fPLClient) GetLivePoints(ctx context.Context, in *LiveReq, opts ...grpc.CallOption) (FPL_GetLivePointsClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FPL_serviceDesc.Streams[1], "/grpc.FPL/getLivePoints", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fPLGetLivePointsClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FPL_GetLivePointsClient interface {
+	Recv() (*LiveStandings, error)
+	grpc.ClientStream
+}
+
+type fPLGetLivePointsClient struct {
+	grpc.ClientStream
+}
+
+func (x *fPLGetLivePointsClient) Recv() (*LiveStandings, error) {
+	m := new(LiveStandings)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *fPLClient) Subscribe(ctx context.Context, in *SubscribeReq, opts ...grpc.CallOption) (FPL_SubscribeClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_FPL_serviceDesc.Streams[2], "/grpc.FPL/subscribe", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &fPLSubscribeClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type FPL_SubscribeClient interface {
+	Recv() (*Update, error)
+	grpc.ClientStream
+}
+
+type fPLSubscribeClient struct {
+	grpc.ClientStream
+}
+
+func (x *fPLSubscribeClient) Recv() (*Update, error) {
+	m := new(Update)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *fPLClient) GetUsage(ctx context.Context, in *UsageReq, opts ...grpc.CallOption) (*Usage, error) {
+	out := new(Usage)
+	err := c.cc.Invoke(ctx, "/grpc.FPL/getUsage", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fPLClient) GetHeatmap(ctx context.Context, in *HeatmapReq, opts ...grpc.CallOption) (*Heatmap, error) {
+	out := new(Heatmap)
+	err := c.cc.Invoke(ctx, "/grpc.FPL/getHeatmap", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fPLClient) CompareLeagues(ctx context.Context, in *CompareReq, opts ...grpc.CallOption) (*LeagueComparison, error) {
+	out := new(LeagueComparison)
+	err := c.cc.Invoke(ctx, "/grpc.FPL/compareLeagues", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fPLClient) DefineCohort(ctx context.Context, in *Cohort, opts ...grpc.CallOption) (*Cohort, error) {
+	out := new(Cohort)
+	err := c.cc.Invoke(ctx, "/grpc.FPL/defineCohort", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fPLClient) ListCohorts(ctx context.Context, in *CohortsReq, opts ...grpc.CallOption) (*Cohorts, error) {
+	out := new(Cohorts)
+	err := c.cc.Invoke(ctx, "/grpc.FPL/listCohorts", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fPLClient) DeleteCohort(ctx context.Context, in *CohortName, opts ...grpc.CallOption) (*Cohort, error) {
+	out := new(Cohort)
+	err := c.cc.Invoke(ctx, "/grpc.FPL/deleteCohort", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *fPLClient) GetSimilarity(ctx context.Context, in *SimilarityReq, opts ...grpc.CallOption) (*Similarity, error) {
+	out := new(Similarity)
+	err := c.cc.Invoke(ctx, "/grpc.FPL/getSimilarity", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// FPLServer is the server API for FPL service.
+type FPLServer interface {
+	GetNumberOfPlayers(context.Context, *NumPlayerRequest) (*NumPlayers, error)
+	GetParticipantsInLeague(context.Context, *LeagueCode) (*NumParticipants, error)
+	GetDataForGameweek(context.Context, *GameweekReq) (*PlayerOccuranceData, error)
+	GetDataForAllGameweeks(*AllGameweeksReq, FPL_GetDataForAllGameweeksServer) error
+	GetLivePoints(*LiveReq, FPL_GetLivePointsServer) error
+	Subscribe(*SubscribeReq, FPL_SubscribeServer) error
+	GetUsage(context.Context, *UsageReq) (*Usage, error)
+	GetHeatmap(context.Context, *HeatmapReq) (*Heatmap, error)
+	CompareLeagues(context.Context, *CompareReq) (*LeagueComparison, error)
+	DefineCohort(context.Context, *Cohort) (*Cohort, error)
+	ListCohorts(context.Context, *CohortsReq) (*Cohorts, error)
+	DeleteCohort(context.Context, *CohortName) (*Cohort, error)
+	GetSimilarity(context.Context, *SimilarityReq) (*Similarity, error)
+}
+
+func RegisterFPLServer(s *grpc.Server, srv FPLServer) {
+	s.RegisterService(&_FPL_serviceDesc, srv)
+}
+
+func _FPL_GetNumberOfPlayers_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(NumPlayerRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
@@ -488,7 +2351,7 @@ func _FPL_GetDataForGameweek_Handler(srv interface{}, ctx context.Context, dec f
 }
 
 func _FPL_GetDataForAllGameweeks_Handler(srv interface{}, stream grpc.ServerStream) error {
-	m := new(LeagueCode)
+	m := new(AllGameweeksReq)
 	if err := stream.RecvMsg(m); err != nil {
 		return err
 	}
@@ -508,6 +2371,174 @@ func (x *fPLGetDataForAllGameweeksServer) Send(m *AllGameweekData) error {
 	return x.ServerStream.SendMsg(m)
 }
 
+func _FPL_GetLivePoints_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(LiveReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FPLServer).GetLivePoints(m, &fPLGetLivePointsServer{stream})
+}
+
+type FPL_GetLivePointsServer interface {
+	Send(*LiveStandings) error
+	grpc.ServerStream
+}
+
+type fPLGetLivePointsServer struct {
+	grpc.ServerStream
+}
+
+func (x *fPLGetLivePointsServer) Send(m *LiveStandings) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FPL_Subscribe_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeReq)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(FPLServer).Subscribe(m, &fPLSubscribeServer{stream})
+}
+
+type FPL_SubscribeServer interface {
+	Send(*Update) error
+	grpc.ServerStream
+}
+
+type fPLSubscribeServer struct {
+	grpc.ServerStream
+}
+
+func (x *fPLSubscribeServer) Send(m *Update) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _FPL_GetUsage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UsageReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FPLServer).GetUsage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.FPL/GetUsage",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FPLServer).GetUsage(ctx, req.(*UsageReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FPL_GetHeatmap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HeatmapReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FPLServer).GetHeatmap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.FPL/GetHeatmap",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FPLServer).GetHeatmap(ctx, req.(*HeatmapReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FPL_CompareLeagues_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FPLServer).CompareLeagues(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.FPL/CompareLeagues",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FPLServer).CompareLeagues(ctx, req.(*CompareReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FPL_DefineCohort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Cohort)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FPLServer).DefineCohort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.FPL/DefineCohort",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FPLServer).DefineCohort(ctx, req.(*Cohort))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FPL_ListCohorts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CohortsReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FPLServer).ListCohorts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.FPL/ListCohorts",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FPLServer).ListCohorts(ctx, req.(*CohortsReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FPL_DeleteCohort_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CohortName)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FPLServer).DeleteCohort(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.FPL/DeleteCohort",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FPLServer).DeleteCohort(ctx, req.(*CohortName))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _FPL_GetSimilarity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SimilarityReq)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(FPLServer).GetSimilarity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.FPL/GetSimilarity",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(FPLServer).GetSimilarity(ctx, req.(*SimilarityReq))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 var _FPL_serviceDesc = grpc.ServiceDesc{
 	ServiceName: "grpc.FPL",
 	HandlerType: (*FPLServer)(nil),
@@ -524,6 +2555,34 @@ var _FPL_serviceDesc = grpc.ServiceDesc{
 			MethodName: "getDataForGameweek",
 			Handler:    _FPL_GetDataForGameweek_Handler,
 		},
+		{
+			MethodName: "getUsage",
+			Handler:    _FPL_GetUsage_Handler,
+		},
+		{
+			MethodName: "getHeatmap",
+			Handler:    _FPL_GetHeatmap_Handler,
+		},
+		{
+			MethodName: "compareLeagues",
+			Handler:    _FPL_CompareLeagues_Handler,
+		},
+		{
+			MethodName: "defineCohort",
+			Handler:    _FPL_DefineCohort_Handler,
+		},
+		{
+			MethodName: "listCohorts",
+			Handler:    _FPL_ListCohorts_Handler,
+		},
+		{
+			MethodName: "deleteCohort",
+			Handler:    _FPL_DeleteCohort_Handler,
+		},
+		{
+			MethodName: "getSimilarity",
+			Handler:    _FPL_GetSimilarity_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -531,6 +2590,16 @@ var _FPL_serviceDesc = grpc.ServiceDesc{
 			Handler:       _FPL_GetDataForAllGameweeks_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "getLivePoints",
+			Handler:       _FPL_GetLivePoints_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "subscribe",
+			Handler:       _FPL_Subscribe_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "grpc/fpl.proto",
 }
@@ -35,8 +35,62 @@ func (m *MockFPLClient) EXPECT() *MockFPLClientMockRecorder {
 	return m.recorder
 }
 
+// CompareLeagues mocks base method
+func (m *MockFPLClient) CompareLeagues(arg0 context.Context, arg1 *grpc.CompareReq, arg2 ...grpc0.CallOption) (*grpc.LeagueComparison, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CompareLeagues", varargs...)
+	ret0, _ := ret[0].(*grpc.LeagueComparison)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareLeagues indicates an expected call of CompareLeagues
+func (mr *MockFPLClientMockRecorder) CompareLeagues(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareLeagues", reflect.TypeOf((*MockFPLClient)(nil).CompareLeagues), varargs...)
+}
+
+// DefineCohort mocks base method
+func (m *MockFPLClient) DefineCohort(arg0 context.Context, arg1 *grpc.Cohort, arg2 ...grpc0.CallOption) (*grpc.Cohort, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DefineCohort", varargs...)
+	ret0, _ := ret[0].(*grpc.Cohort)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DefineCohort indicates an expected call of DefineCohort
+func (mr *MockFPLClientMockRecorder) DefineCohort(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DefineCohort", reflect.TypeOf((*MockFPLClient)(nil).DefineCohort), varargs...)
+}
+
+// DeleteCohort mocks base method
+func (m *MockFPLClient) DeleteCohort(arg0 context.Context, arg1 *grpc.CohortName, arg2 ...grpc0.CallOption) (*grpc.Cohort, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "DeleteCohort", varargs...)
+	ret0, _ := ret[0].(*grpc.Cohort)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCohort indicates an expected call of DeleteCohort
+func (mr *MockFPLClientMockRecorder) DeleteCohort(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCohort", reflect.TypeOf((*MockFPLClient)(nil).DeleteCohort), varargs...)
+}
+
 // GetDataForAllGameweeks mocks base method
-func (m *MockFPLClient) GetDataForAllGameweeks(arg0 context.Context, arg1 *grpc.LeagueCode, arg2 ...grpc0.CallOption) (grpc.FPL_GetDataForAllGameweeksClient, error) {
+func (m *MockFPLClient) GetDataForAllGameweeks(arg0 context.Context, arg1 *grpc.AllGameweeksReq, arg2 ...grpc0.CallOption) (grpc.FPL_GetDataForAllGameweeksClient, error) {
 	varargs := []interface{}{arg0, arg1}
 	for _, a := range arg2 {
 		varargs = append(varargs, a)
@@ -71,6 +125,42 @@ func (mr *MockFPLClientMockRecorder) GetDataForGameweek(arg0, arg1 interface{},
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataForGameweek", reflect.TypeOf((*MockFPLClient)(nil).GetDataForGameweek), varargs...)
 }
 
+// GetHeatmap mocks base method
+func (m *MockFPLClient) GetHeatmap(arg0 context.Context, arg1 *grpc.HeatmapReq, arg2 ...grpc0.CallOption) (*grpc.Heatmap, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetHeatmap", varargs...)
+	ret0, _ := ret[0].(*grpc.Heatmap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeatmap indicates an expected call of GetHeatmap
+func (mr *MockFPLClientMockRecorder) GetHeatmap(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeatmap", reflect.TypeOf((*MockFPLClient)(nil).GetHeatmap), varargs...)
+}
+
+// GetLivePoints mocks base method
+func (m *MockFPLClient) GetLivePoints(arg0 context.Context, arg1 *grpc.LiveReq, arg2 ...grpc0.CallOption) (grpc.FPL_GetLivePointsClient, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetLivePoints", varargs...)
+	ret0, _ := ret[0].(grpc.FPL_GetLivePointsClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLivePoints indicates an expected call of GetLivePoints
+func (mr *MockFPLClientMockRecorder) GetLivePoints(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLivePoints", reflect.TypeOf((*MockFPLClient)(nil).GetLivePoints), varargs...)
+}
+
 // GetNumberOfPlayers mocks base method
 func (m *MockFPLClient) GetNumberOfPlayers(arg0 context.Context, arg1 *grpc.NumPlayerRequest, arg2 ...grpc0.CallOption) (*grpc.NumPlayers, error) {
 	varargs := []interface{}{arg0, arg1}
@@ -106,3 +196,75 @@ func (mr *MockFPLClientMockRecorder) GetParticipantsInLeague(arg0, arg1 interfac
 	varargs := append([]interface{}{arg0, arg1}, arg2...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParticipantsInLeague", reflect.TypeOf((*MockFPLClient)(nil).GetParticipantsInLeague), varargs...)
 }
+
+// GetSimilarity mocks base method
+func (m *MockFPLClient) GetSimilarity(arg0 context.Context, arg1 *grpc.SimilarityReq, arg2 ...grpc0.CallOption) (*grpc.Similarity, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetSimilarity", varargs...)
+	ret0, _ := ret[0].(*grpc.Similarity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSimilarity indicates an expected call of GetSimilarity
+func (mr *MockFPLClientMockRecorder) GetSimilarity(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSimilarity", reflect.TypeOf((*MockFPLClient)(nil).GetSimilarity), varargs...)
+}
+
+// GetUsage mocks base method
+func (m *MockFPLClient) GetUsage(arg0 context.Context, arg1 *grpc.UsageReq, arg2 ...grpc0.CallOption) (*grpc.Usage, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "GetUsage", varargs...)
+	ret0, _ := ret[0].(*grpc.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsage indicates an expected call of GetUsage
+func (mr *MockFPLClientMockRecorder) GetUsage(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockFPLClient)(nil).GetUsage), varargs...)
+}
+
+// ListCohorts mocks base method
+func (m *MockFPLClient) ListCohorts(arg0 context.Context, arg1 *grpc.CohortsReq, arg2 ...grpc0.CallOption) (*grpc.Cohorts, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "ListCohorts", varargs...)
+	ret0, _ := ret[0].(*grpc.Cohorts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCohorts indicates an expected call of ListCohorts
+func (mr *MockFPLClientMockRecorder) ListCohorts(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCohorts", reflect.TypeOf((*MockFPLClient)(nil).ListCohorts), varargs...)
+}
+
+// Subscribe mocks base method
+func (m *MockFPLClient) Subscribe(arg0 context.Context, arg1 *grpc.SubscribeReq, arg2 ...grpc0.CallOption) (grpc.FPL_SubscribeClient, error) {
+	varargs := []interface{}{arg0, arg1}
+	for _, a := range arg2 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Subscribe", varargs...)
+	ret0, _ := ret[0].(grpc.FPL_SubscribeClient)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Subscribe indicates an expected call of Subscribe
+func (mr *MockFPLClientMockRecorder) Subscribe(arg0, arg1 interface{}, arg2 ...interface{}) *gomock.Call {
+	varargs := append([]interface{}{arg0, arg1}, arg2...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockFPLClient)(nil).Subscribe), varargs...)
+}
@@ -5,9 +5,13 @@
 package mock_server
 
 import (
+	context0 "context"
 	grpc "github.com/go-fantasy/fpl/grpc"
+	server "github.com/go-fantasy/fpl/server"
 	gomock "github.com/golang/mock/gomock"
 	context "golang.org/x/net/context"
+	io "io"
+	net "net"
 	reflect "reflect"
 )
 
@@ -74,7 +78,7 @@ func (mr *MockFPLServerMockRecorder) GetDataForGameweek(arg0, arg1 interface{})
 }
 
 // GetDataForAllGameweeks mocks base method
-func (m *MockFPLServer) GetDataForAllGameweeks(arg0 *grpc.LeagueCode, arg1 grpc.FPL_GetDataForAllGameweeksServer) error {
+func (m *MockFPLServer) GetDataForAllGameweeks(arg0 *grpc.AllGameweeksReq, arg1 grpc.FPL_GetDataForAllGameweeksServer) error {
 	ret := m.ctrl.Call(m, "GetDataForAllGameweeks", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
@@ -85,16 +89,165 @@ func (mr *MockFPLServerMockRecorder) GetDataForAllGameweeks(arg0, arg1 interface
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetDataForAllGameweeks", reflect.TypeOf((*MockFPLServer)(nil).GetDataForAllGameweeks), arg0, arg1)
 }
 
+// GetLivePoints mocks base method
+func (m *MockFPLServer) GetLivePoints(arg0 *grpc.LiveReq, arg1 grpc.FPL_GetLivePointsServer) error {
+	ret := m.ctrl.Call(m, "GetLivePoints", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GetLivePoints indicates an expected call of GetLivePoints
+func (mr *MockFPLServerMockRecorder) GetLivePoints(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLivePoints", reflect.TypeOf((*MockFPLServer)(nil).GetLivePoints), arg0, arg1)
+}
+
+// Subscribe mocks base method
+func (m *MockFPLServer) Subscribe(arg0 *grpc.SubscribeReq, arg1 grpc.FPL_SubscribeServer) error {
+	ret := m.ctrl.Call(m, "Subscribe", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Subscribe indicates an expected call of Subscribe
+func (mr *MockFPLServerMockRecorder) Subscribe(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Subscribe", reflect.TypeOf((*MockFPLServer)(nil).Subscribe), arg0, arg1)
+}
+
+// GetUsage mocks base method
+func (m *MockFPLServer) GetUsage(arg0 context.Context, arg1 *grpc.UsageReq) (*grpc.Usage, error) {
+	ret := m.ctrl.Call(m, "GetUsage", arg0, arg1)
+	ret0, _ := ret[0].(*grpc.Usage)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetUsage indicates an expected call of GetUsage
+func (mr *MockFPLServerMockRecorder) GetUsage(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetUsage", reflect.TypeOf((*MockFPLServer)(nil).GetUsage), arg0, arg1)
+}
+
+// GetHeatmap mocks base method
+func (m *MockFPLServer) GetHeatmap(arg0 context.Context, arg1 *grpc.HeatmapReq) (*grpc.Heatmap, error) {
+	ret := m.ctrl.Call(m, "GetHeatmap", arg0, arg1)
+	ret0, _ := ret[0].(*grpc.Heatmap)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetHeatmap indicates an expected call of GetHeatmap
+func (mr *MockFPLServerMockRecorder) GetHeatmap(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetHeatmap", reflect.TypeOf((*MockFPLServer)(nil).GetHeatmap), arg0, arg1)
+}
+
+// CompareLeagues mocks base method
+func (m *MockFPLServer) CompareLeagues(arg0 context.Context, arg1 *grpc.CompareReq) (*grpc.LeagueComparison, error) {
+	ret := m.ctrl.Call(m, "CompareLeagues", arg0, arg1)
+	ret0, _ := ret[0].(*grpc.LeagueComparison)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CompareLeagues indicates an expected call of CompareLeagues
+func (mr *MockFPLServerMockRecorder) CompareLeagues(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CompareLeagues", reflect.TypeOf((*MockFPLServer)(nil).CompareLeagues), arg0, arg1)
+}
+
+// DefineCohort mocks base method
+func (m *MockFPLServer) DefineCohort(arg0 context.Context, arg1 *grpc.Cohort) (*grpc.Cohort, error) {
+	ret := m.ctrl.Call(m, "DefineCohort", arg0, arg1)
+	ret0, _ := ret[0].(*grpc.Cohort)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DefineCohort indicates an expected call of DefineCohort
+func (mr *MockFPLServerMockRecorder) DefineCohort(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DefineCohort", reflect.TypeOf((*MockFPLServer)(nil).DefineCohort), arg0, arg1)
+}
+
+// ListCohorts mocks base method
+func (m *MockFPLServer) ListCohorts(arg0 context.Context, arg1 *grpc.CohortsReq) (*grpc.Cohorts, error) {
+	ret := m.ctrl.Call(m, "ListCohorts", arg0, arg1)
+	ret0, _ := ret[0].(*grpc.Cohorts)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListCohorts indicates an expected call of ListCohorts
+func (mr *MockFPLServerMockRecorder) ListCohorts(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCohorts", reflect.TypeOf((*MockFPLServer)(nil).ListCohorts), arg0, arg1)
+}
+
+// DeleteCohort mocks base method
+func (m *MockFPLServer) DeleteCohort(arg0 context.Context, arg1 *grpc.CohortName) (*grpc.Cohort, error) {
+	ret := m.ctrl.Call(m, "DeleteCohort", arg0, arg1)
+	ret0, _ := ret[0].(*grpc.Cohort)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// DeleteCohort indicates an expected call of DeleteCohort
+func (mr *MockFPLServerMockRecorder) DeleteCohort(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteCohort", reflect.TypeOf((*MockFPLServer)(nil).DeleteCohort), arg0, arg1)
+}
+
+// GetSimilarity mocks base method
+func (m *MockFPLServer) GetSimilarity(arg0 context.Context, arg1 *grpc.SimilarityReq) (*grpc.Similarity, error) {
+	ret := m.ctrl.Call(m, "GetSimilarity", arg0, arg1)
+	ret0, _ := ret[0].(*grpc.Similarity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSimilarity indicates an expected call of GetSimilarity
+func (mr *MockFPLServerMockRecorder) GetSimilarity(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSimilarity", reflect.TypeOf((*MockFPLServer)(nil).GetSimilarity), arg0, arg1)
+}
+
 // Start mocks base method
-func (m *MockFPLServer) Start(arg0 string) error {
-	ret := m.ctrl.Call(m, "Start", arg0)
+func (m *MockFPLServer) Start(arg0 context0.Context, arg1 string) error {
+	ret := m.ctrl.Call(m, "Start", arg0, arg1)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
 // Start indicates an expected call of Start
-func (mr *MockFPLServerMockRecorder) Start(arg0 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockFPLServer)(nil).Start), arg0)
+func (mr *MockFPLServerMockRecorder) Start(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Start", reflect.TypeOf((*MockFPLServer)(nil).Start), arg0, arg1)
+}
+
+// Serve mocks base method
+func (m *MockFPLServer) Serve(arg0 context0.Context, arg1 net.Listener) error {
+	ret := m.ctrl.Call(m, "Serve", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Serve indicates an expected call of Serve
+func (mr *MockFPLServerMockRecorder) Serve(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Serve", reflect.TypeOf((*MockFPLServer)(nil).Serve), arg0, arg1)
+}
+
+// Stop mocks base method
+func (m *MockFPLServer) Stop() {
+	m.ctrl.Call(m, "Stop")
+}
+
+// Stop indicates an expected call of Stop
+func (mr *MockFPLServerMockRecorder) Stop() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Stop", reflect.TypeOf((*MockFPLServer)(nil).Stop))
+}
+
+// GracefulStop mocks base method
+func (m *MockFPLServer) GracefulStop(arg0 context0.Context) error {
+	ret := m.ctrl.Call(m, "GracefulStop", arg0)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// GracefulStop indicates an expected call of GracefulStop
+func (mr *MockFPLServerMockRecorder) GracefulStop(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GracefulStop", reflect.TypeOf((*MockFPLServer)(nil).GracefulStop), arg0)
 }
 
 // MockScraper is a mock of Scraper interface
@@ -121,55 +274,305 @@ func (m *MockScraper) EXPECT() *MockScraperMockRecorder {
 }
 
 // GetTeamInfoForParticipant mocks base method
-func (m *MockScraper) GetTeamInfoForParticipant(arg0 map[int64]string, arg1 int, arg2 *[]int64) (map[string]int, error) {
-	ret := m.ctrl.Call(m, "GetTeamInfoForParticipant", arg0, arg1, arg2)
+func (m *MockScraper) GetTeamInfoForParticipant(arg0 context0.Context, arg1 map[int64]string, arg2 int, arg3 *[]int64) (map[string]int, error) {
+	ret := m.ctrl.Call(m, "GetTeamInfoForParticipant", arg0, arg1, arg2, arg3)
 	ret0, _ := ret[0].(map[string]int)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetTeamInfoForParticipant indicates an expected call of GetTeamInfoForParticipant
-func (mr *MockScraperMockRecorder) GetTeamInfoForParticipant(arg0, arg1, arg2 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamInfoForParticipant", reflect.TypeOf((*MockScraper)(nil).GetTeamInfoForParticipant), arg0, arg1, arg2)
+func (mr *MockScraperMockRecorder) GetTeamInfoForParticipant(arg0, arg1, arg2, arg3 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetTeamInfoForParticipant", reflect.TypeOf((*MockScraper)(nil).GetTeamInfoForParticipant), arg0, arg1, arg2, arg3)
 }
 
 // GetPlayerMapping mocks base method
-func (m *MockScraper) GetPlayerMapping() (map[int64]string, error) {
-	ret := m.ctrl.Call(m, "GetPlayerMapping")
+func (m *MockScraper) GetPlayerMapping(arg0 context0.Context) (map[int64]string, error) {
+	ret := m.ctrl.Call(m, "GetPlayerMapping", arg0)
 	ret0, _ := ret[0].(map[int64]string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetPlayerMapping indicates an expected call of GetPlayerMapping
-func (mr *MockScraperMockRecorder) GetPlayerMapping() *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlayerMapping", reflect.TypeOf((*MockScraper)(nil).GetPlayerMapping))
+func (mr *MockScraperMockRecorder) GetPlayerMapping(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPlayerMapping", reflect.TypeOf((*MockScraper)(nil).GetPlayerMapping), arg0)
+}
+
+// GetEvents mocks base method
+func (m *MockScraper) GetEvents(arg0 context0.Context) ([]server.Event, error) {
+	ret := m.ctrl.Call(m, "GetEvents", arg0)
+	ret0, _ := ret[0].([]server.Event)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetEvents indicates an expected call of GetEvents
+func (mr *MockScraperMockRecorder) GetEvents(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetEvents", reflect.TypeOf((*MockScraper)(nil).GetEvents), arg0)
+}
+
+// GetFixtures mocks base method
+func (m *MockScraper) GetFixtures(arg0 context0.Context, arg1 int) ([]server.Fixture, error) {
+	ret := m.ctrl.Call(m, "GetFixtures", arg0, arg1)
+	ret0, _ := ret[0].([]server.Fixture)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFixtures indicates an expected call of GetFixtures
+func (mr *MockScraperMockRecorder) GetFixtures(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFixtures", reflect.TypeOf((*MockScraper)(nil).GetFixtures), arg0, arg1)
 }
 
 // GetParticipantsInLeague mocks base method
-func (m *MockScraper) GetParticipantsInLeague(arg0 int) (*[]int64, error) {
-	ret := m.ctrl.Call(m, "GetParticipantsInLeague", arg0)
+func (m *MockScraper) GetParticipantsInLeague(arg0 context0.Context, arg1 int) (*[]int64, error) {
+	ret := m.ctrl.Call(m, "GetParticipantsInLeague", arg0, arg1)
 	ret0, _ := ret[0].(*[]int64)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetParticipantsInLeague indicates an expected call of GetParticipantsInLeague
-func (mr *MockScraperMockRecorder) GetParticipantsInLeague(arg0 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParticipantsInLeague", reflect.TypeOf((*MockScraper)(nil).GetParticipantsInLeague), arg0)
+func (mr *MockScraperMockRecorder) GetParticipantsInLeague(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetParticipantsInLeague", reflect.TypeOf((*MockScraper)(nil).GetParticipantsInLeague), arg0, arg1)
 }
 
-// WriteToFile mocks base method
-func (m *MockScraper) WriteToFile(arg0 map[int]map[string]int, arg1 int) (string, error) {
-	ret := m.ctrl.Call(m, "WriteToFile", arg0, arg1)
-	ret0, _ := ret[0].(string)
+// GetPicksForParticipants mocks base method
+func (m *MockScraper) GetPicksForParticipants(arg0 context0.Context, arg1 int, arg2 *[]int64) (map[int64]*server.ParticipantTeamInfo, error) {
+	ret := m.ctrl.Call(m, "GetPicksForParticipants", arg0, arg1, arg2)
+	ret0, _ := ret[0].(map[int64]*server.ParticipantTeamInfo)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
-// WriteToFile indicates an expected call of WriteToFile
-func (mr *MockScraperMockRecorder) WriteToFile(arg0, arg1 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "WriteToFile", reflect.TypeOf((*MockScraper)(nil).WriteToFile), arg0, arg1)
+// GetPicksForParticipants indicates an expected call of GetPicksForParticipants
+func (mr *MockScraperMockRecorder) GetPicksForParticipants(arg0, arg1, arg2 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPicksForParticipants", reflect.TypeOf((*MockScraper)(nil).GetPicksForParticipants), arg0, arg1, arg2)
+}
+
+// GetLivePoints mocks base method
+func (m *MockScraper) GetLivePoints(arg0 context0.Context, arg1 int) (map[int64]int, error) {
+	ret := m.ctrl.Call(m, "GetLivePoints", arg0, arg1)
+	ret0, _ := ret[0].(map[int64]int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetLivePoints indicates an expected call of GetLivePoints
+func (mr *MockScraperMockRecorder) GetLivePoints(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetLivePoints", reflect.TypeOf((*MockScraper)(nil).GetLivePoints), arg0, arg1)
+}
+
+// MockStore is a mock of Store interface
+type MockStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockStoreMockRecorder
+}
+
+// MockStoreMockRecorder is the mock recorder for MockStore
+type MockStoreMockRecorder struct {
+	mock *MockStore
+}
+
+// NewMockStore creates a new mock instance
+func NewMockStore(ctrl *gomock.Controller) *MockStore {
+	mock := &MockStore{ctrl: ctrl}
+	mock.recorder = &MockStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockStore) EXPECT() *MockStoreMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method
+func (m *MockStore) Get(arg0 int, arg1 int) (*server.LeagueData, bool) {
+	ret := m.ctrl.Call(m, "Get", arg0, arg1)
+	ret0, _ := ret[0].(*server.LeagueData)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockStoreMockRecorder) Get(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockStore)(nil).Get), arg0, arg1)
+}
+
+// Set mocks base method
+func (m *MockStore) Set(arg0 *server.LeagueData) {
+	m.ctrl.Call(m, "Set", arg0)
+}
+
+// Set indicates an expected call of Set
+func (mr *MockStoreMockRecorder) Set(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockStore)(nil).Set), arg0)
+}
+
+// MockCohortStore is a mock of CohortStore interface
+type MockCohortStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockCohortStoreMockRecorder
+}
+
+// MockCohortStoreMockRecorder is the mock recorder for MockCohortStore
+type MockCohortStoreMockRecorder struct {
+	mock *MockCohortStore
+}
+
+// NewMockCohortStore creates a new mock instance
+func NewMockCohortStore(ctrl *gomock.Controller) *MockCohortStore {
+	mock := &MockCohortStore{ctrl: ctrl}
+	mock.recorder = &MockCohortStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockCohortStore) EXPECT() *MockCohortStoreMockRecorder {
+	return m.recorder
+}
+
+// Get mocks base method
+func (m *MockCohortStore) Get(arg0 string) (server.Cohort, bool) {
+	ret := m.ctrl.Call(m, "Get", arg0)
+	ret0, _ := ret[0].(server.Cohort)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get
+func (mr *MockCohortStoreMockRecorder) Get(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockCohortStore)(nil).Get), arg0)
+}
+
+// Set mocks base method
+func (m *MockCohortStore) Set(arg0 server.Cohort) {
+	m.ctrl.Call(m, "Set", arg0)
+}
+
+// Set indicates an expected call of Set
+func (mr *MockCohortStoreMockRecorder) Set(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockCohortStore)(nil).Set), arg0)
+}
+
+// Delete mocks base method
+func (m *MockCohortStore) Delete(arg0 string) bool {
+	ret := m.ctrl.Call(m, "Delete", arg0)
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete
+func (mr *MockCohortStoreMockRecorder) Delete(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockCohortStore)(nil).Delete), arg0)
+}
+
+// List mocks base method
+func (m *MockCohortStore) List() []server.Cohort {
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]server.Cohort)
+	return ret0
+}
+
+// List indicates an expected call of List
+func (mr *MockCohortStoreMockRecorder) List() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockCohortStore)(nil).List))
+}
+
+// MockKeyStore is a mock of KeyStore interface
+type MockKeyStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockKeyStoreMockRecorder
+}
+
+// MockKeyStoreMockRecorder is the mock recorder for MockKeyStore
+type MockKeyStoreMockRecorder struct {
+	mock *MockKeyStore
+}
+
+// NewMockKeyStore creates a new mock instance
+func NewMockKeyStore(ctrl *gomock.Controller) *MockKeyStore {
+	mock := &MockKeyStore{ctrl: ctrl}
+	mock.recorder = &MockKeyStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockKeyStore) EXPECT() *MockKeyStoreMockRecorder {
+	return m.recorder
+}
+
+// Lookup mocks base method
+func (m *MockKeyStore) Lookup(arg0 string) (*server.APIKey, bool) {
+	ret := m.ctrl.Call(m, "Lookup", arg0)
+	ret0, _ := ret[0].(*server.APIKey)
+	ret1, _ := ret[1].(bool)
+	return ret0, ret1
+}
+
+// Lookup indicates an expected call of Lookup
+func (mr *MockKeyStoreMockRecorder) Lookup(arg0 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Lookup", reflect.TypeOf((*MockKeyStore)(nil).Lookup), arg0)
+}
+
+// List mocks base method
+func (m *MockKeyStore) List() []server.APIKey {
+	ret := m.ctrl.Call(m, "List")
+	ret0, _ := ret[0].([]server.APIKey)
+	return ret0
+}
+
+// List indicates an expected call of List
+func (mr *MockKeyStoreMockRecorder) List() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "List", reflect.TypeOf((*MockKeyStore)(nil).List))
+}
+
+// MockExporter is a mock of Exporter interface
+type MockExporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockExporterMockRecorder
+}
+
+// MockExporterMockRecorder is the mock recorder for MockExporter
+type MockExporterMockRecorder struct {
+	mock *MockExporter
+}
+
+// NewMockExporter creates a new mock instance
+func NewMockExporter(ctrl *gomock.Controller) *MockExporter {
+	mock := &MockExporter{ctrl: ctrl}
+	mock.recorder = &MockExporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockExporter) EXPECT() *MockExporterMockRecorder {
+	return m.recorder
+}
+
+// Export mocks base method
+func (m *MockExporter) Export(arg0 io.Writer, arg1 *server.OwnershipTable) error {
+	ret := m.ctrl.Call(m, "Export", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Export indicates an expected call of Export
+func (mr *MockExporterMockRecorder) Export(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Export", reflect.TypeOf((*MockExporter)(nil).Export), arg0, arg1)
+}
+
+// ContentType mocks base method
+func (m *MockExporter) ContentType() string {
+	ret := m.ctrl.Call(m, "ContentType")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// ContentType indicates an expected call of ContentType
+func (mr *MockExporterMockRecorder) ContentType() *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ContentType", reflect.TypeOf((*MockExporter)(nil).ContentType))
 }
 
 // MockClient is a mock of Client interface
@@ -196,14 +599,14 @@ func (m *MockClient) EXPECT() *MockClientMockRecorder {
 }
 
 // MakeRequest mocks base method
-func (m *MockClient) MakeRequest(arg0 string) ([]byte, error) {
-	ret := m.ctrl.Call(m, "MakeRequest", arg0)
+func (m *MockClient) MakeRequest(arg0 context0.Context, arg1 string) ([]byte, error) {
+	ret := m.ctrl.Call(m, "MakeRequest", arg0, arg1)
 	ret0, _ := ret[0].([]byte)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // MakeRequest indicates an expected call of MakeRequest
-func (mr *MockClientMockRecorder) MakeRequest(arg0 interface{}) *gomock.Call {
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeRequest", reflect.TypeOf((*MockClient)(nil).MakeRequest), arg0)
+func (mr *MockClientMockRecorder) MakeRequest(arg0, arg1 interface{}) *gomock.Call {
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MakeRequest", reflect.TypeOf((*MockClient)(nil).MakeRequest), arg0, arg1)
 }
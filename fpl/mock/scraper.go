@@ -0,0 +1,43 @@
+package mock_server
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-fantasy/fpl/server"
+	"github.com/golang/mock/gomock"
+)
+
+//NewLeagueScraper returns a scraper for the tests of the packages serving the FPL server. League 313 has
+//participants 1 to 3 and league 314 participants 2 and 3, both of them with data for gameweeks 1 and 2, gameweek 2
+//being the current one
+func NewLeagueScraper(mockCtrl *gomock.Controller) *MockScraper {
+	testObj := NewMockScraper(mockCtrl)
+	testObj.EXPECT().GetPlayerMapping(gomock.Any()).Return(map[int64]string{267: "Messi", 247: "Ronaldo", 301: "Salah"}, nil).AnyTimes()
+	testObj.EXPECT().GetParticipantsInLeague(gomock.Any(), 313).Return(&[]int64{1, 2, 3}, nil).AnyTimes()
+	testObj.EXPECT().GetTeamInfoForParticipant(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, playerMap map[int64]string, gameweek int, participants *[]int64) (map[string]int, error) {
+			switch gameweek {
+			case 1:
+				return map[string]int{"Messi": 3, "Ronaldo": 1}, nil
+			case 2:
+				return map[string]int{"Messi": 1, "Ronaldo": 2, "Salah": 2}, nil
+			}
+			return nil, errors.New("gameweek hasn't been played yet")
+		}).AnyTimes()
+	testObj.EXPECT().GetParticipantsInLeague(gomock.Any(), 314).Return(&[]int64{2, 3}, nil).AnyTimes()
+	testObj.EXPECT().GetPicksForParticipants(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, gameweek int, participants *[]int64) (map[int64]*server.ParticipantTeamInfo, error) {
+			if gameweek != 1 {
+				return nil, errors.New("gameweek hasn't been played yet")
+			}
+			messi, ronaldo := server.TeamPlayers{Element: 267}, server.TeamPlayers{Element: 247}
+			return map[int64]*server.ParticipantTeamInfo{
+				1: {TeamPlayers: []server.TeamPlayers{messi, ronaldo}},
+				2: {TeamPlayers: []server.TeamPlayers{messi}},
+				3: {TeamPlayers: []server.TeamPlayers{messi}},
+			}, nil
+		}).AnyTimes()
+	testObj.EXPECT().GetEvents(gomock.Any()).Return([]server.Event{{ID: 1, Finished: true}, {ID: 2, IsCurrent: true}, {ID: 3}}, nil).AnyTimes()
+	return testObj
+}
@@ -0,0 +1,49 @@
+package sdk
+
+import (
+	"context"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+)
+
+//Cohort is a named group of FPL entries the server can look at instead of a league, see WithCohort
+type Cohort struct {
+	Name    string  `json:"name"`
+	Entries []int64 `json:"entries"`
+}
+
+//DefineCohort adds a cohort to the server, or replaces the cohort with the same name, returning it as the server
+//keeps it. Servers using API keys need an admin key
+func (c *Client) DefineCohort(ctx context.Context, name string, entries ...int64) (Cohort, error) {
+	cohort, err := c.fpl.DefineCohort(ctx, &grpc_fpl.Cohort{Name: name, Entries: entries})
+	if err != nil {
+		return Cohort{}, fromStatus(err)
+	}
+	return fromCohort(cohort), nil
+}
+
+//Cohorts returns every cohort of the server, sorted by name
+func (c *Client) Cohorts(ctx context.Context) ([]Cohort, error) {
+	resp, err := c.fpl.ListCohorts(ctx, &grpc_fpl.CohortsReq{})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	cohorts := make([]Cohort, 0, len(resp.Cohorts))
+	for _, cohort := range resp.Cohorts {
+		cohorts = append(cohorts, fromCohort(cohort))
+	}
+	return cohorts, nil
+}
+
+//DeleteCohort removes a cohort from the server, returning it. Servers using API keys need an admin key
+func (c *Client) DeleteCohort(ctx context.Context, name string) (Cohort, error) {
+	cohort, err := c.fpl.DeleteCohort(ctx, &grpc_fpl.CohortName{Name: name})
+	if err != nil {
+		return Cohort{}, fromStatus(err)
+	}
+	return fromCohort(cohort), nil
+}
+
+func fromCohort(cohort *grpc_fpl.Cohort) Cohort {
+	return Cohort{Name: cohort.Name, Entries: cohort.Entries}
+}
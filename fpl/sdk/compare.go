@@ -0,0 +1,76 @@
+package sdk
+
+import (
+	"context"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+)
+
+//LeagueComparison is the ownership of players in several leagues side by side
+type LeagueComparison struct {
+	LeagueCodes []int `json:"leagueCodes"`
+	//SampleSizes are how many participants of each league were sampled, in the order of LeagueCodes
+	SampleSizes []int                `json:"sampleSizes"`
+	Gameweeks   []GameweekComparison `json:"gameweeks"`
+}
+
+//GameweekComparison is the ownership of players in the leagues compared in a gameweek, the players owned the most
+//over every league first
+type GameweekComparison struct {
+	Gameweek int                `json:"gameweek"`
+	Players  []PlayerComparison `json:"players"`
+}
+
+//PlayerComparison is the ownership of a player in every league compared, in the order of the leagues. Percentages
+//are the share of the sample of each league owning the player, and Deltas the difference to the first league in
+//percentage points
+type PlayerComparison struct {
+	Player      string    `json:"player"`
+	Owners      []int     `json:"owners"`
+	Percentages []float64 `json:"percentages"`
+	Deltas      []float64 `json:"deltas"`
+}
+
+//CompareLeagues returns the ownership of players in leagues side by side in every gameweek, or in the gameweeks
+//selected with WithGameweeks, WithGameweekRange or WithLastGameweeks
+func (c *Client) CompareLeagues(ctx context.Context, leagueCodes []int, opts ...RequestOption) (*LeagueComparison, error) {
+	o := newRequestOptions(opts)
+	req := &grpc_fpl.CompareReq{SampleSize: o.sampleSize, Gameweeks: o.gameweeks}
+	for _, leagueCode := range leagueCodes {
+		req.LeagueCodes = append(req.LeagueCodes, int64(leagueCode))
+	}
+	resp, err := c.fpl.CompareLeagues(ctx, req)
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+
+	comparison := &LeagueComparison{
+		LeagueCodes: toInts(resp.LeagueCodes),
+		SampleSizes: toInts(resp.SampleSizes),
+		Gameweeks:   make([]GameweekComparison, 0, len(resp.Gameweeks)),
+	}
+	for _, gameweek := range resp.Gameweeks {
+		gameweekComparison := GameweekComparison{
+			Gameweek: int(gameweek.Gameweek),
+			Players:  make([]PlayerComparison, 0, len(gameweek.Players)),
+		}
+		for _, player := range gameweek.Players {
+			gameweekComparison.Players = append(gameweekComparison.Players, PlayerComparison{
+				Player:      player.Player,
+				Owners:      toInts(player.Owners),
+				Percentages: player.Percentages,
+				Deltas:      player.Deltas,
+			})
+		}
+		comparison.Gameweeks = append(comparison.Gameweeks, gameweekComparison)
+	}
+	return comparison, nil
+}
+
+func toInts(values []int64) []int {
+	ints := make([]int, len(values))
+	for i, value := range values {
+		ints[i] = int(value)
+	}
+	return ints
+}
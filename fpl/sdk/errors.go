@@ -0,0 +1,61 @@
+package sdk
+
+import (
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//Error is returned when the server fails a call. Compare it to the Err values with errors.Is to find out why
+type Error struct {
+	Code    codes.Code
+	Message string
+	cause   error
+}
+
+//The Err values match every Error of their Code
+var (
+	ErrInvalidArgument    = &Error{Code: codes.InvalidArgument}
+	ErrNotFound           = &Error{Code: codes.NotFound}
+	ErrFailedPrecondition = &Error{Code: codes.FailedPrecondition}
+	ErrOutOfRange         = &Error{Code: codes.OutOfRange}
+	ErrUnauthenticated    = &Error{Code: codes.Unauthenticated}
+	ErrPermissionDenied   = &Error{Code: codes.PermissionDenied}
+	ErrResourceExhausted  = &Error{Code: codes.ResourceExhausted}
+	ErrUnavailable        = &Error{Code: codes.Unavailable}
+	ErrDeadlineExceeded   = &Error{Code: codes.DeadlineExceeded}
+	ErrCanceled           = &Error{Code: codes.Canceled}
+	ErrInternal           = &Error{Code: codes.Internal}
+	ErrDataLoss           = &Error{Code: codes.DataLoss}
+)
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("fpl: %v", e.Code)
+	}
+	return fmt.Sprintf("fpl: %v: %v", e.Code, e.Message)
+}
+
+//Is reports whether target is the Err value of the code of e
+func (e *Error) Is(target error) bool {
+	t, ok := target.(*Error)
+	return ok && t.Code == e.Code && t.Message == ""
+}
+
+//Unwrap returns the gRPC error the server failed the call with
+func (e *Error) Unwrap() error {
+	return e.cause
+}
+
+//fromStatus turns errors of gRPC calls into an Error. Other errors are returned as they are
+func fromStatus(err error) error {
+	if err == nil {
+		return nil
+	}
+	s, ok := status.FromError(err)
+	if !ok {
+		return err
+	}
+	return &Error{Code: s.Code(), Message: s.Message(), cause: err}
+}
@@ -0,0 +1,131 @@
+package sdk
+
+import (
+	"errors"
+	"io"
+	"time"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+)
+
+//Done is returned by the iterators once the server has ended the stream
+var Done = errors.New("no more items in iterator")
+
+//Manager is a participant of a league in the live standings
+type Manager struct {
+	Entry        int64  `json:"entry"`
+	LivePoints   int    `json:"livePoints"`
+	TotalPoints  int    `json:"totalPoints"`
+	LiveRank     int    `json:"liveRank"`
+	PreviousRank int    `json:"previousRank"`
+	Captain      string `json:"captain"`
+}
+
+//Standings are the live standings of the sampled participants of a league, best first
+type Standings struct {
+	Gameweek  int       `json:"gameweek"`
+	Managers  []Manager `json:"managers"`
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+//UpdateType is what changed in a league
+type UpdateType = grpc_fpl.UpdateType
+
+//Update is something that changed in a league
+type Update struct {
+	Cursor         int64          `json:"cursor"`
+	Type           UpdateType     `json:"type"`
+	LeagueCode     int            `json:"leagueCode"`
+	Gameweek       int            `json:"gameweek"`
+	Owners         map[string]int `json:"owners"`
+	ChangedPlayers []string       `json:"changedPlayers"`
+	Managers       []Manager      `json:"managers"`
+	UpdatedAt      time.Time      `json:"updatedAt"`
+}
+
+//StandingsIterator returns the standings sent by the server, see Client.LivePoints
+type StandingsIterator struct {
+	stream grpc_fpl.FPL_GetLivePointsClient
+	cancel func()
+}
+
+//Next waits for the next standings. It returns Done when the server ended the stream
+func (it *StandingsIterator) Next() (*Standings, error) {
+	liveStandings, err := it.stream.Recv()
+	if err == io.EOF {
+		return nil, Done
+	}
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return &Standings{
+		Gameweek:  int(liveStandings.Gameweek),
+		Managers:  managers(liveStandings.Managers),
+		UpdatedAt: time.Unix(liveStandings.UpdatedAt, 0),
+	}, nil
+}
+
+//Close stops following the standings
+func (it *StandingsIterator) Close() {
+	it.cancel()
+}
+
+//UpdateIterator returns the updates sent by the server, see Client.Subscribe
+type UpdateIterator struct {
+	stream grpc_fpl.FPL_SubscribeClient
+	cancel func()
+	cursor int64
+}
+
+//Next waits for the next update. It returns Done when the server ended the stream, which can be resumed
+//by subscribing again from Cursor
+func (it *UpdateIterator) Next() (*Update, error) {
+	update, err := it.stream.Recv()
+	if err == io.EOF {
+		return nil, Done
+	}
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	it.cursor = update.Cursor
+
+	owners := make(map[string]int, len(update.PlayerOccurance))
+	for player, count := range update.PlayerOccurance {
+		owners[player] = int(count)
+	}
+	return &Update{
+		Cursor:         update.Cursor,
+		Type:           update.Type,
+		LeagueCode:     int(update.LeagueCode),
+		Gameweek:       int(update.Gameweek),
+		Owners:         owners,
+		ChangedPlayers: update.ChangedPlayers,
+		Managers:       managers(update.Managers),
+		UpdatedAt:      time.Unix(update.UpdatedAt, 0),
+	}, nil
+}
+
+//Cursor is the cursor of the last update returned, to subscribe again without missing any
+func (it *UpdateIterator) Cursor() int64 {
+	return it.cursor
+}
+
+//Close stops following the updates
+func (it *UpdateIterator) Close() {
+	it.cancel()
+}
+
+func managers(liveManagers []*grpc_fpl.LiveManager) []Manager {
+	result := make([]Manager, 0, len(liveManagers))
+	for _, manager := range liveManagers {
+		result = append(result, Manager{
+			Entry:        manager.Entry,
+			LivePoints:   int(manager.LivePoints),
+			TotalPoints:  int(manager.TotalPoints),
+			LiveRank:     int(manager.LiveRank),
+			PreviousRank: int(manager.PreviousRank),
+			Captain:      manager.Captain,
+		})
+	}
+	return result
+}
@@ -0,0 +1,132 @@
+package sdk
+
+import (
+	"bufio"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+//summaryColumns are the columns of the CSV after the gameweeks, which can be worked out from Owners
+var summaryColumns = map[string]bool{"Total": true, "Average": true, "Peak gameweek": true}
+
+//OwnershipMatrix is how many of the sampled participants of a league owned every player in every gameweek
+type OwnershipMatrix struct {
+	LeagueCode int `json:"leagueCode"`
+	//Cohort is the cohort the matrix is of, empty for leagues
+	Cohort string `json:"cohort,omitempty"`
+	//SampleSize is how many participants were sampled, 0 when the server didn't say
+	SampleSize int `json:"sampleSize,omitempty"`
+	//FetchedAt is when the server scraped the league, zero when it didn't say
+	FetchedAt time.Time `json:"fetchedAt"`
+	//Gameweeks are the gameweeks of the matrix, in order
+	Gameweeks []int `json:"gameweeks"`
+	//Players are the players owned in any gameweek, in the order the server sorted them, the most owned in total
+	//first unless WithSort asked for another order
+	Players []string `json:"players"`
+	//Owners has the owners of a player in every gameweek, in the order of Gameweeks
+	Owners map[string][]int `json:"owners"`
+}
+
+//Count returns how many participants owned player in gameweek
+func (m *OwnershipMatrix) Count(player string, gameweek int) int {
+	for i, g := range m.Gameweeks {
+		if g == gameweek {
+			return m.Owners[player][i]
+		}
+	}
+	return 0
+}
+
+//ParseOwnershipMatrix reads an ownership matrix from the CSV sent by the server: # comment lines with the league,
+//sample size and fetch time, then a Player column, a column per gameweek and summary columns
+func ParseOwnershipMatrix(r io.Reader) (*OwnershipMatrix, error) {
+	matrix := &OwnershipMatrix{Owners: make(map[string][]int)}
+	buffered := bufio.NewReader(r)
+	if err := parseMetadata(buffered, matrix); err != nil {
+		return nil, err
+	}
+
+	reader := csv.NewReader(buffered)
+	header, err := reader.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("ownership CSV is empty")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(header) == 0 || header[0] != "Player" {
+		return nil, fmt.Errorf("ownership CSV should start with a Player column, not %q", header)
+	}
+
+	for _, column := range header[1:] {
+		if summaryColumns[column] {
+			continue
+		}
+		gameweek, err := strconv.Atoi(strings.TrimPrefix(column, "Gameweek "))
+		if err != nil || !strings.HasPrefix(column, "Gameweek ") {
+			return nil, fmt.Errorf("unexpected column %q in ownership CSV", column)
+		}
+		matrix.Gameweeks = append(matrix.Gameweeks, gameweek)
+	}
+
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		owners := make([]int, len(matrix.Gameweeks))
+		for i := range owners {
+			owners[i], err = strconv.Atoi(record[i+1])
+			if err != nil {
+				return nil, fmt.Errorf("invalid number of owners %q for %v in ownership CSV", record[i+1], record[0])
+			}
+		}
+		matrix.Players = append(matrix.Players, record[0])
+		matrix.Owners[record[0]] = owners
+	}
+	return matrix, nil
+}
+
+//parseMetadata reads the # comment lines at the start of the CSV into matrix, ignoring the ones it doesn't know
+func parseMetadata(r *bufio.Reader, matrix *OwnershipMatrix) error {
+	for {
+		next, err := r.Peek(1)
+		if err == io.EOF || (err == nil && next[0] != '#') {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		line, err := r.ReadString('\n')
+		if err != nil && err != io.EOF {
+			return err
+		}
+		parts := strings.SplitN(strings.TrimSpace(strings.TrimPrefix(line, "#")), ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		value := strings.TrimSpace(parts[1])
+		switch parts[0] {
+		case "League":
+			matrix.LeagueCode, err = strconv.Atoi(value)
+		case "Cohort":
+			matrix.Cohort = value
+		case "Sample size":
+			matrix.SampleSize, err = strconv.Atoi(value)
+		case "Fetched at":
+			matrix.FetchedAt, err = time.Parse(time.RFC3339, value)
+		default:
+			err = nil
+		}
+		if err != nil {
+			return fmt.Errorf("invalid %v %q in ownership CSV", strings.ToLower(parts[0]), value)
+		}
+	}
+}
@@ -0,0 +1,334 @@
+//Package sdk is a Go client for the FPL server, returning Go structs rather than the messages and streams of the
+//generated gRPC client
+package sdk
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"hash"
+	"io"
+	"sort"
+
+	"github.com/go-fantasy/fpl/client"
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//Client calls the FPL server. It is safe for concurrent use
+type Client struct {
+	fpl     grpc_fpl.FPLClient
+	cleanup func()
+}
+
+//RequestOption changes what a call asks the server for
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	sampleSize int64
+	sort       grpc_fpl.OwnershipSort
+	gameweeks  *grpc_fpl.GameweekSelection
+	cohort     string
+	threshold  *float64
+}
+
+//WithSampleSize looks at the teams of the top n participants of the league rather than the server default
+func WithSampleSize(n int) RequestOption {
+	return func(o *requestOptions) {
+		o.sampleSize = int64(n)
+	}
+}
+
+//WithSort orders the players of exports by their total, latest or peak ownership, total by default
+func WithSort(sort grpc_fpl.OwnershipSort) RequestOption {
+	return func(o *requestOptions) {
+		o.sort = sort
+	}
+}
+
+//WithGameweeks exports only gameweeks rather than every gameweek, so that the server only scrapes them
+func WithGameweeks(gameweeks ...int) RequestOption {
+	return func(o *requestOptions) {
+		selection := &grpc_fpl.GameweekSelection{}
+		for _, gameweek := range gameweeks {
+			selection.Gameweeks = append(selection.Gameweeks, int64(gameweek))
+		}
+		o.gameweeks = selection
+	}
+}
+
+//WithGameweekRange exports only the gameweeks from from to to, either end being open when it is 0
+func WithGameweekRange(from, to int) RequestOption {
+	return func(o *requestOptions) {
+		o.gameweeks = &grpc_fpl.GameweekSelection{From: int64(from), To: int64(to)}
+	}
+}
+
+//WithLastGameweeks exports only the last n gameweeks, up to the current one
+func WithLastGameweeks(n int) RequestOption {
+	return func(o *requestOptions) {
+		o.gameweeks = &grpc_fpl.GameweekSelection{Last: int64(n)}
+	}
+}
+
+//WithCohort looks at every entry of a cohort defined on the server instead of a league, whose code should then be 0.
+//It applies to the ownership, export, heatmap, live point and similarity calls
+func WithCohort(name string) RequestOption {
+	return func(o *requestOptions) {
+		o.cohort = name
+	}
+}
+
+//WithThreshold groups managers whose average similarity is at least threshold into clusters rather than the server
+//default, 0 putting them all in the same cluster. It applies to similarity calls
+func WithThreshold(threshold float64) RequestOption {
+	return func(o *requestOptions) {
+		o.threshold = &threshold
+	}
+}
+
+func newRequestOptions(opts []RequestOption) requestOptions {
+	var o requestOptions
+	for _, opt := range opts {
+		opt(&o)
+	}
+	return o
+}
+
+//New connects to the server, configured by opts as client.New is
+func New(opts ...client.Option) (*Client, error) {
+	fpl, cleanup, err := client.New(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{fpl: fpl, cleanup: cleanup}, nil
+}
+
+//NewFromFPLClient wraps a generated client, for connections made some other way
+func NewFromFPLClient(fpl grpc_fpl.FPLClient) *Client {
+	return &Client{fpl: fpl}
+}
+
+//Close closes the connection made by New
+func (c *Client) Close() {
+	if c.cleanup != nil {
+		c.cleanup()
+	}
+}
+
+//NumberOfPlayers returns how many players there are in FPL
+func (c *Client) NumberOfPlayers(ctx context.Context) (int, error) {
+	numPlayers, err := c.fpl.GetNumberOfPlayers(ctx, &grpc_fpl.NumPlayerRequest{})
+	if err != nil {
+		return 0, fromStatus(err)
+	}
+	return int(numPlayers.NumPlayers), nil
+}
+
+//LeagueSize returns how many participants there are in a league
+func (c *Client) LeagueSize(ctx context.Context, leagueCode int) (int, error) {
+	numParticipants, err := c.fpl.GetParticipantsInLeague(ctx, &grpc_fpl.LeagueCode{LeagueCode: int64(leagueCode)})
+	if err != nil {
+		return 0, fromStatus(err)
+	}
+	return int(numParticipants.NumParticipants), nil
+}
+
+//PlayerOwnership is how many of the sampled participants of a league own a player
+type PlayerOwnership struct {
+	Player string `json:"player"`
+	Owners int    `json:"owners"`
+}
+
+//Gameweek returns the players owned in a league in a gameweek, the most owned first
+func (c *Client) Gameweek(ctx context.Context, leagueCode, gameweek int, opts ...RequestOption) ([]PlayerOwnership, error) {
+	o := newRequestOptions(opts)
+	data, err := c.fpl.GetDataForGameweek(ctx, &grpc_fpl.GameweekReq{
+		LeagueCode: int64(leagueCode),
+		Gameweek:   int64(gameweek),
+		SampleSize: o.sampleSize,
+		Cohort:     o.cohort,
+	})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+
+	ownership := make([]PlayerOwnership, 0, len(data.PlayerOccurance))
+	for player, owners := range data.PlayerOccurance {
+		ownership = append(ownership, PlayerOwnership{Player: player, Owners: int(owners)})
+	}
+	sort.Slice(ownership, func(i, j int) bool {
+		if ownership[i].Owners != ownership[j].Owners {
+			return ownership[i].Owners > ownership[j].Owners
+		}
+		return ownership[i].Player < ownership[j].Player
+	})
+	return ownership, nil
+}
+
+//DownloadCSV writes the ownership of every player in every gameweek of a league to w as CSV, as the server
+//sends it. It returns the number of bytes written
+func (c *Client) DownloadCSV(ctx context.Context, leagueCode int, w io.Writer, opts ...RequestOption) (int64, error) {
+	return c.Export(ctx, leagueCode, grpc_fpl.ExportFormat_CSV, w, opts...)
+}
+
+//maxExportResumes is how many times Export resumes an export after its stream broke
+const maxExportResumes = 3
+
+//Export writes the ownership of every player in every gameweek of a league to w in format, as the server sends
+//it. It returns the number of bytes written. An export whose stream breaks with the server unavailable is resumed
+//where it stopped, and what was written is checked against the digest the server ends with, failing with
+//ErrDataLoss when they don't match
+func (c *Client) Export(ctx context.Context, leagueCode int, format grpc_fpl.ExportFormat, w io.Writer, opts ...RequestOption) (int64, error) {
+	o := newRequestOptions(opts)
+	req := &grpc_fpl.AllGameweeksReq{
+		LeagueCode: int64(leagueCode),
+		SampleSize: o.sampleSize,
+		Format:     format,
+		Sort:       o.sort,
+		Gameweeks:  o.gameweeks,
+		Cohort:     o.cohort,
+	}
+	download := &exportDownload{w: w, digest: sha256.New()}
+	for resumes := 0; ; resumes++ {
+		err := download.receive(ctx, c.fpl, req)
+		if err == nil {
+			return download.written, nil
+		}
+		if download.token == "" || resumes == maxExportResumes || status.Code(err) != codes.Unavailable || ctx.Err() != nil {
+			return download.written, fromStatus(err)
+		}
+		req.Offset, req.ResumeToken = download.written, download.token
+	}
+}
+
+//exportDownload is an export being written to w as the server sends it
+type exportDownload struct {
+	w       io.Writer
+	digest  hash.Hash
+	written int64
+	token   string
+}
+
+//receive writes the data of the stream of req to w, until the server sends the digest of the export
+func (d *exportDownload) receive(ctx context.Context, fpl grpc_fpl.FPLClient, req *grpc_fpl.AllGameweeksReq) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	stream, err := fpl.GetDataForAllGameweeks(ctx, req)
+	if err != nil {
+		return err
+	}
+	for {
+		chunk, err := stream.Recv()
+		if err == io.EOF {
+			//Servers without resumable exports end the stream without a digest
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if chunk.ResumeToken != "" && chunk.Offset != d.written {
+			return &Error{Code: codes.DataLoss, Message: fmt.Sprintf("export chunk at offset %v, expected %v", chunk.Offset, d.written)}
+		}
+		d.token = chunk.ResumeToken
+		n, err := d.w.Write(chunk.Data)
+		d.digest.Write(chunk.Data[:n])
+		d.written += int64(n)
+		if err != nil {
+			return err
+		}
+		if chunk.Digest != "" {
+			if digest := hex.EncodeToString(d.digest.Sum(nil)); digest != chunk.Digest {
+				return &Error{Code: codes.DataLoss, Message: fmt.Sprintf("export digest %v doesn't match %v sent by the server", digest, chunk.Digest)}
+			}
+			return nil
+		}
+	}
+}
+
+//Heatmap returns an image of the ownership of players over every gameweek of a league, with the top players
+//owned the most in total, or every player when top is 0
+func (c *Client) Heatmap(ctx context.Context, leagueCode int, format grpc_fpl.ImageFormat, top int, opts ...RequestOption) ([]byte, error) {
+	o := newRequestOptions(opts)
+	heatmap, err := c.fpl.GetHeatmap(ctx, &grpc_fpl.HeatmapReq{
+		LeagueCode: int64(leagueCode),
+		SampleSize: o.sampleSize,
+		Format:     format,
+		Top:        int64(top),
+		Cohort:     o.cohort,
+	})
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+	return heatmap.Image, nil
+}
+
+//OwnershipMatrix returns the ownership of every player in every gameweek of a league, or in the gameweeks selected
+//with WithGameweeks, WithGameweekRange or WithLastGameweeks
+func (c *Client) OwnershipMatrix(ctx context.Context, leagueCode int, opts ...RequestOption) (*OwnershipMatrix, error) {
+	pr, pw := io.Pipe()
+	go func() {
+		_, err := c.DownloadCSV(ctx, leagueCode, pw, opts...)
+		pw.CloseWithError(err)
+	}()
+	matrix, err := ParseOwnershipMatrix(pr)
+	//Unblock the download when parsing stopped early
+	pr.CloseWithError(io.ErrClosedPipe)
+	if err != nil {
+		return nil, err
+	}
+	matrix.LeagueCode = leagueCode
+	return matrix, nil
+}
+
+//Players returns every player owned in a league in the latest gameweek, the most owned first
+func (c *Client) Players(ctx context.Context, leagueCode int, opts ...RequestOption) ([]string, error) {
+	matrix, err := c.OwnershipMatrix(ctx, leagueCode, append([]RequestOption{WithSort(grpc_fpl.OwnershipSort_LATEST)}, opts...)...)
+	if err != nil {
+		return nil, err
+	}
+	latest := len(matrix.Gameweeks) - 1
+	var players []string
+	for _, player := range matrix.Players {
+		if latest >= 0 && matrix.Owners[player][latest] > 0 {
+			players = append(players, player)
+		}
+	}
+	return players, nil
+}
+
+//LivePoints follows the live points of the sampled participants of a league during a gameweek. The iterator
+//returns new standings every time the server sends them, until ctx is done or it is closed
+func (c *Client) LivePoints(ctx context.Context, leagueCode, gameweek int, opts ...RequestOption) (*StandingsIterator, error) {
+	o := newRequestOptions(opts)
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := c.fpl.GetLivePoints(ctx, &grpc_fpl.LiveReq{
+		LeagueCode: int64(leagueCode),
+		Gameweek:   int64(gameweek),
+		SampleSize: o.sampleSize,
+		Cohort:     o.cohort,
+	})
+	if err != nil {
+		cancel()
+		return nil, fromStatus(err)
+	}
+	return &StandingsIterator{stream: stream, cancel: cancel}, nil
+}
+
+//Subscribe follows the updates of a league, only about players when some are given. The iterator returns every
+//update after cursor, 0 starting with the latest ones, until ctx is done or it is closed
+func (c *Client) Subscribe(ctx context.Context, leagueCode int, cursor int64, players ...string) (*UpdateIterator, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	stream, err := c.fpl.Subscribe(ctx, &grpc_fpl.SubscribeReq{
+		LeagueCode: int64(leagueCode),
+		Players:    players,
+		Cursor:     cursor,
+	})
+	if err != nil {
+		cancel()
+		return nil, fromStatus(err)
+	}
+	return &UpdateIterator{stream: stream, cancel: cancel, cursor: cursor}, nil
+}
@@ -0,0 +1,313 @@
+package sdk_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net"
+	"strings"
+	"testing"
+	"time"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/go-fantasy/fpl/mock"
+	"github.com/go-fantasy/fpl/mock-client"
+	"github.com/go-fantasy/fpl/sdk"
+	"github.com/go-fantasy/fpl/server"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+//newClient serves a server scraping through testObj in memory, and returns an SDK client connected to it
+func newClient(t *testing.T, testObj server.Scraper) (*sdk.Client, func()) {
+	fpl, cleanup := newFPLClient(t, testObj)
+	return sdk.NewFromFPLClient(fpl), cleanup
+}
+
+//newFPLClient serves a server scraping through testObj in memory, and returns a gRPC client connected to it
+func newFPLClient(t *testing.T, testObj server.Scraper) (grpc_fpl.FPLClient, func()) {
+	cohorts, err := server.NewCohortStore()
+	require.Nil(t, err)
+	lis := bufconn.Listen(1024 * 1024)
+	ctx, cancel := context.WithCancel(context.Background())
+	myFPLServer := &server.MyFPLServer{Scraper: testObj, Store: server.NewMemoryStore(), Cohorts: cohorts}
+	go myFPLServer.Serve(ctx, lis)
+
+	conn, err := grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithDialer(func(string, time.Duration) (net.Conn, error) {
+		return lis.Dial()
+	}))
+	require.Nil(t, err)
+	return grpc_fpl.NewFPLClient(conn), func() {
+		conn.Close()
+		cancel()
+	}
+}
+
+func TestClient(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	client, cleanup := newClient(t, mock_server.NewLeagueScraper(mockCtrl))
+	defer cleanup()
+	ctx := context.Background()
+
+	numPlayers, err := client.NumberOfPlayers(ctx)
+	assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, 3, numPlayers)
+
+	leagueSize, err := client.LeagueSize(ctx, 313)
+	assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, 3, leagueSize)
+
+	ownership, err := client.Gameweek(ctx, 313, 2)
+	assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, []sdk.PlayerOwnership{{"Ronaldo", 2}, {"Salah", 2}, {"Messi", 1}}, ownership)
+
+	matrix, err := client.OwnershipMatrix(ctx, 313)
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, 313, matrix.LeagueCode)
+	assert.Equal(t, []int{1, 2}, matrix.Gameweeks)
+	assert.Equal(t, []string{"Messi", "Ronaldo", "Salah"}, matrix.Players)
+	assert.Equal(t, 3, matrix.Count("Messi", 1))
+	assert.Equal(t, 0, matrix.Count("Salah", 1))
+	assert.Equal(t, 0, matrix.Count("Messi", 3))
+
+	latest, err := client.OwnershipMatrix(ctx, 313, sdk.WithSort(grpc_fpl.OwnershipSort_LATEST))
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, []string{"Ronaldo", "Salah", "Messi"}, latest.Players)
+
+	selected, err := client.OwnershipMatrix(ctx, 313, sdk.WithGameweeks(1))
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, []int{1}, selected.Gameweeks)
+	assert.Equal(t, []string{"Messi", "Ronaldo"}, selected.Players)
+	selected, err = client.OwnershipMatrix(ctx, 313, sdk.WithGameweekRange(2, 0))
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, []int{2}, selected.Gameweeks)
+
+	comparison, err := client.CompareLeagues(ctx, []int{313, 314}, sdk.WithGameweeks(1))
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, &sdk.LeagueComparison{
+		LeagueCodes: []int{313, 314},
+		SampleSizes: []int{3, 2},
+		Gameweeks: []sdk.GameweekComparison{{Gameweek: 1, Players: []sdk.PlayerComparison{
+			{Player: "Messi", Owners: []int{3, 2}, Percentages: []float64{100, 100}, Deltas: []float64{0, 0}},
+			{Player: "Ronaldo", Owners: []int{1, 0}, Percentages: []float64{33.33, 0}, Deltas: []float64{0, -33.33}},
+		}}},
+	}, comparison)
+
+	similarity, err := client.Similarity(ctx, 313, 1, sdk.WithThreshold(0.8))
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, []int64{1, 2, 3}, similarity.Entries)
+	assert.Equal(t, [][]float64{{1, 0.5, 0.5}, {0.5, 1, 1}, {0.5, 1, 1}}, similarity.Matrix)
+	assert.Equal(t, []sdk.ManagerCluster{
+		{Entries: []int64{2, 3}, Representative: 2, Template: []string{"Messi"}, Cohesion: 1},
+		{Entries: []int64{1}, Representative: 1, Template: []string{"Messi", "Ronaldo"}, Cohesion: 1},
+	}, similarity.Clusters)
+
+	cohort, err := client.DefineCohort(ctx, "creators", 3, 1)
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, sdk.Cohort{Name: "creators", Entries: []int64{1, 3}}, cohort)
+	cohorts, err := client.Cohorts(ctx)
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, []sdk.Cohort{cohort}, cohorts)
+	cohortMatrix, err := client.OwnershipMatrix(ctx, 0, sdk.WithCohort("creators"), sdk.WithGameweeks(2))
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, "creators", cohortMatrix.Cohort)
+	assert.Equal(t, 2, cohortMatrix.SampleSize)
+	assert.Equal(t, []string{"Ronaldo", "Salah", "Messi"}, cohortMatrix.Players)
+	_, err = client.DeleteCohort(ctx, "creators")
+	assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+	_, err = client.Gameweek(ctx, 0, 1, sdk.WithCohort("creators"))
+	assert.True(t, errors.Is(err, sdk.ErrNotFound), "Deleted cohorts shouldn't be found, got %v", err)
+
+	players, err := client.Players(ctx, 313)
+	assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, latest.Players, players)
+
+	var csv bytes.Buffer
+	written, err := client.DownloadCSV(ctx, 313, &csv)
+	assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, int64(csv.Len()), written)
+	assert.True(t, strings.HasPrefix(csv.String(), "# League: 313\n"), "Unexpected CSV %v", csv.String())
+}
+
+func TestErrors(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	client, cleanup := newClient(t, mock_server.NewLeagueScraper(mockCtrl))
+	defer cleanup()
+
+	standings, err := client.LivePoints(context.Background(), 313, 50)
+	require.Nil(t, err, "Streams fail when they are read")
+	defer standings.Close()
+	_, err = standings.Next()
+	assert.True(t, errors.Is(err, sdk.ErrInvalidArgument), "Error %v should be an invalid argument", err)
+	assert.False(t, errors.Is(err, sdk.ErrNotFound))
+
+	var sdkErr *sdk.Error
+	if assert.True(t, errors.As(err, &sdkErr)) {
+		assert.Equal(t, codes.InvalidArgument, sdkErr.Code)
+		assert.Equal(t, codes.InvalidArgument, status.Code(errors.Unwrap(err)), "The gRPC error should be wrapped")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = client.NumberOfPlayers(ctx)
+	assert.True(t, errors.Is(err, sdk.ErrCanceled), "Error %v should be a cancellation", err)
+}
+
+//flakyExports breaks the streams of the first exports after half of their first chunk, as a dropped connection
+//would, or corrupts that chunk
+type flakyExports struct {
+	grpc_fpl.FPLClient
+	breaks   int
+	corrupt  bool
+	requests []grpc_fpl.AllGameweeksReq
+}
+
+func (c *flakyExports) GetDataForAllGameweeks(ctx context.Context, in *grpc_fpl.AllGameweeksReq, opts ...grpc.CallOption) (grpc_fpl.FPL_GetDataForAllGameweeksClient, error) {
+	c.requests = append(c.requests, *in)
+	stream, err := c.FPLClient.GetDataForAllGameweeks(ctx, in, opts...)
+	if err != nil || (c.breaks == 0 && !c.corrupt) {
+		return stream, err
+	}
+	c.breaks--
+	return &flakyStream{FPL_GetDataForAllGameweeksClient: stream, corrupt: c.corrupt}, nil
+}
+
+type flakyStream struct {
+	grpc_fpl.FPL_GetDataForAllGameweeksClient
+	corrupt  bool
+	received int
+}
+
+func (s *flakyStream) Recv() (*grpc_fpl.AllGameweekData, error) {
+	s.received++
+	if s.received > 1 && !s.corrupt {
+		return nil, status.Error(codes.Unavailable, "connection dropped")
+	}
+	chunk, err := s.FPL_GetDataForAllGameweeksClient.Recv()
+	if err != nil || s.received > 1 {
+		return chunk, err
+	}
+	if s.corrupt {
+		chunk.Data = bytes.ToUpper(chunk.Data)
+	} else {
+		chunk.Data = chunk.Data[:len(chunk.Data)/2]
+	}
+	return chunk, nil
+}
+
+func TestExportResume(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	fpl, cleanup := newFPLClient(t, mock_server.NewLeagueScraper(mockCtrl))
+	defer cleanup()
+	ctx := context.Background()
+
+	var expected bytes.Buffer
+	_, err := sdk.NewFromFPLClient(fpl).DownloadCSV(ctx, 313, &expected)
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+
+	flaky := &flakyExports{FPLClient: fpl, breaks: 2}
+	var csv bytes.Buffer
+	written, err := sdk.NewFromFPLClient(flaky).DownloadCSV(ctx, 313, &csv)
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, expected.String(), csv.String())
+	assert.Equal(t, int64(csv.Len()), written)
+	if assert.Equal(t, 3, len(flaky.requests), "The export should be resumed twice") {
+		assert.Equal(t, int64(0), flaky.requests[0].Offset)
+		assert.Equal(t, int64(expected.Len()/2), flaky.requests[1].Offset)
+		assert.NotEmpty(t, flaky.requests[1].ResumeToken)
+		assert.Equal(t, int64(expected.Len()/2+(expected.Len()-expected.Len()/2)/2), flaky.requests[2].Offset)
+	}
+
+	_, err = sdk.NewFromFPLClient(&flakyExports{FPLClient: fpl, breaks: 10}).DownloadCSV(ctx, 313, ioutil.Discard)
+	assert.True(t, errors.Is(err, sdk.ErrUnavailable), "Error %v should be returned once resuming gives up", err)
+
+	_, err = sdk.NewFromFPLClient(&flakyExports{FPLClient: fpl, corrupt: true}).DownloadCSV(ctx, 313, ioutil.Discard)
+	assert.True(t, errors.Is(err, sdk.ErrDataLoss), "Error %v should be a digest mismatch", err)
+}
+
+//updateStream sends updates, then ends
+type updateStream struct {
+	grpc.ClientStream
+	updates []*grpc_fpl.Update
+}
+
+func (u *updateStream) Recv() (*grpc_fpl.Update, error) {
+	if len(u.updates) == 0 {
+		return nil, io.EOF
+	}
+	update := u.updates[0]
+	u.updates = u.updates[1:]
+	return update, nil
+}
+
+func TestSubscribe(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testObj := mock_grpc.NewMockFPLClient(mockCtrl)
+	testObj.EXPECT().Subscribe(gomock.Any(), &grpc_fpl.SubscribeReq{LeagueCode: 313, Cursor: 4, Players: []string{"Messi"}}).
+		Return(&updateStream{updates: []*grpc_fpl.Update{
+			{Cursor: 5, Type: grpc_fpl.UpdateType_OWNERSHIP_CHANGED, LeagueCode: 313, Gameweek: 2, PlayerOccurance: map[string]int32{"Messi": 1}, ChangedPlayers: []string{"Messi"}, UpdatedAt: 100},
+			{Cursor: 6, Type: grpc_fpl.UpdateType_LIVE_POINTS, LeagueCode: 313, Gameweek: 2, Managers: []*grpc_fpl.LiveManager{{Entry: 1, LivePoints: 50, LiveRank: 1, Captain: "Messi"}}},
+		}}, nil)
+
+	updates, err := sdk.NewFromFPLClient(testObj).Subscribe(context.Background(), 313, 4, "Messi")
+	require.Nil(t, err)
+	defer updates.Close()
+
+	update, err := updates.Next()
+	require.Nil(t, err)
+	assert.Equal(t, &sdk.Update{
+		Cursor:         5,
+		Type:           grpc_fpl.UpdateType_OWNERSHIP_CHANGED,
+		LeagueCode:     313,
+		Gameweek:       2,
+		Owners:         map[string]int{"Messi": 1},
+		ChangedPlayers: []string{"Messi"},
+		Managers:       []sdk.Manager{},
+		UpdatedAt:      time.Unix(100, 0),
+	}, update)
+
+	update, err = updates.Next()
+	require.Nil(t, err)
+	assert.Equal(t, []sdk.Manager{{Entry: 1, LivePoints: 50, LiveRank: 1, Captain: "Messi"}}, update.Managers)
+	assert.Equal(t, int64(6), updates.Cursor())
+
+	_, err = updates.Next()
+	assert.Equal(t, sdk.Done, err)
+}
+
+func TestParseOwnershipMatrix(t *testing.T) {
+	matrix, err := sdk.ParseOwnershipMatrix(strings.NewReader("Player,Gameweek 1,Gameweek 2\nMessi,2,1\nSalah,0,3\n"))
+	require.Nil(t, err)
+	assert.Equal(t, []string{"Messi", "Salah"}, matrix.Players, "Players should stay in the order of the server")
+	assert.Equal(t, map[string][]int{"Messi": {2, 1}, "Salah": {0, 3}}, matrix.Owners)
+
+	matrix, err = sdk.ParseOwnershipMatrix(strings.NewReader("# League: 313\n# Sample size: 10\n# Fetched at: 2019-10-05T12:00:00Z\n" +
+		"Player,Gameweek 1,Gameweek 2,Total,Average,Peak gameweek\nMessi,2,1,3,1.50,1\nRooney,1,0,1,0.50,1\n"))
+	require.Nil(t, err)
+	assert.Equal(t, 313, matrix.LeagueCode)
+	assert.Equal(t, 10, matrix.SampleSize)
+	assert.Equal(t, time.Date(2019, 10, 5, 12, 0, 0, 0, time.UTC), matrix.FetchedAt)
+	assert.Equal(t, []int{1, 2}, matrix.Gameweeks)
+	assert.Equal(t, []string{"Messi", "Rooney"}, matrix.Players)
+	assert.Equal(t, map[string][]int{"Messi": {2, 1}, "Rooney": {1, 0}}, matrix.Owners)
+
+	_, err = sdk.ParseOwnershipMatrix(strings.NewReader(""))
+	assert.NotNil(t, err)
+	_, err = sdk.ParseOwnershipMatrix(strings.NewReader("Name,Gameweek 1\n"))
+	assert.NotNil(t, err)
+	_, err = sdk.ParseOwnershipMatrix(strings.NewReader("Player,Gameweek 1\nMessi,many\n"))
+	assert.NotNil(t, err)
+	_, err = sdk.ParseOwnershipMatrix(strings.NewReader("# Sample size: ten\nPlayer,Gameweek 1\n"))
+	assert.NotNil(t, err)
+}
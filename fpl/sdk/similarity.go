@@ -0,0 +1,71 @@
+package sdk
+
+import (
+	"context"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+)
+
+//Similarity is how alike the squads of the sampled managers are in a gameweek
+type Similarity struct {
+	Gameweek int `json:"gameweek"`
+	//Entries are the managers compared, in order
+	Entries []int64 `json:"entries"`
+	//Matrix has the similarity of every pair of managers, between 0 and 1, in the order of Entries
+	Matrix            [][]float64 `json:"matrix"`
+	AverageSimilarity float64     `json:"averageSimilarity"`
+	//Threshold is the average similarity the managers of a cluster have at least
+	Threshold float64          `json:"threshold"`
+	Clusters  []ManagerCluster `json:"clusters"`
+}
+
+//ManagerCluster is a group of managers with similar squads, with the players picked by at least half of them and
+//the manager whose squad is the closest to the others
+type ManagerCluster struct {
+	Entries        []int64  `json:"entries"`
+	Representative int64    `json:"representative"`
+	Template       []string `json:"template"`
+	//Cohesion is the average similarity between the managers of the cluster
+	Cohesion float64 `json:"cohesion"`
+}
+
+//Similarity compares the squads of the sampled managers of a league in a gameweek, grouping managers whose average
+//similarity is at least the threshold of WithThreshold, or the server default, into clusters
+func (c *Client) Similarity(ctx context.Context, leagueCode, gameweek int, opts ...RequestOption) (*Similarity, error) {
+	o := newRequestOptions(opts)
+	req := &grpc_fpl.SimilarityReq{
+		LeagueCode: int64(leagueCode),
+		Gameweek:   int64(gameweek),
+		SampleSize: o.sampleSize,
+		Cohort:     o.cohort,
+	}
+	if o.threshold != nil {
+		req.Threshold = *o.threshold
+		req.HasThreshold = true
+	}
+	resp, err := c.fpl.GetSimilarity(ctx, req)
+	if err != nil {
+		return nil, fromStatus(err)
+	}
+
+	similarity := &Similarity{
+		Gameweek:          int(resp.Gameweek),
+		Entries:           resp.Entries,
+		Matrix:            make([][]float64, 0, len(resp.Managers)),
+		AverageSimilarity: resp.AverageSimilarity,
+		Threshold:         resp.Threshold,
+		Clusters:          make([]ManagerCluster, 0, len(resp.Clusters)),
+	}
+	for _, manager := range resp.Managers {
+		similarity.Matrix = append(similarity.Matrix, manager.Similarities)
+	}
+	for _, cluster := range resp.Clusters {
+		similarity.Clusters = append(similarity.Clusters, ManagerCluster{
+			Entries:        cluster.Entries,
+			Representative: cluster.Representative,
+			Template:       cluster.Template,
+			Cohesion:       cluster.Cohesion,
+		})
+	}
+	return similarity, nil
+}
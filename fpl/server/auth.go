@@ -0,0 +1,193 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"golang.org/x/time/rate"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	//APIKeyHeader is the gRPC metadata key of the API key
+	APIKeyHeader = "x-api-key"
+	//DefaultQuotaPeriod is the period API key quotas are counted over when QuotaPeriod isn't set
+	DefaultQuotaPeriod = 24 * time.Hour
+
+	fplMethodPrefix    = "/" + FPLServiceName + "/"
+	getUsageMethod     = fplMethodPrefix + "GetUsage"
+	defineCohortMethod = fplMethodPrefix + "DefineCohort"
+	deleteCohortMethod = fplMethodPrefix + "DeleteCohort"
+)
+
+//usageTracker counts the calls of every API key, and enforces their quotas and rate limits
+type usageTracker struct {
+	mu     sync.Mutex
+	period time.Duration
+	keys   map[string]*keyUsage
+}
+
+type keyUsage struct {
+	limiter     *rate.Limiter
+	requests    int64
+	rejected    int64
+	periodStart time.Time
+	periodCalls int64
+	lastUsed    time.Time
+}
+
+func newUsageTracker(period time.Duration) *usageTracker {
+	if period <= 0 {
+		period = DefaultQuotaPeriod
+	}
+	return &usageTracker{
+		period: period,
+		keys:   make(map[string]*keyUsage),
+	}
+}
+
+//usageTracker returns the tracker of API key usage, creating it on first use
+func (s *MyFPLServer) usageTracker() *usageTracker {
+	s.usageOnce.Do(func() {
+		s.usage = newUsageTracker(s.QuotaPeriod)
+	})
+	return s.usage
+}
+
+//usageOf returns the usage of key, starting a new quota period when the current one is over. mu must be held
+func (u *usageTracker) usageOf(key *APIKey, now time.Time) *keyUsage {
+	usage, ok := u.keys[key.Name]
+	if !ok {
+		limit, burst := rate.Inf, key.Burst
+		if key.RateLimit > 0 {
+			limit = rate.Limit(key.RateLimit)
+			if burst == 0 {
+				burst = 1
+			}
+		}
+		usage = &keyUsage{
+			limiter:     rate.NewLimiter(limit, burst),
+			periodStart: now,
+		}
+		u.keys[key.Name] = usage
+	}
+	if !now.Before(usage.periodStart.Add(u.period)) {
+		usage.periodStart = now
+		usage.periodCalls = 0
+	}
+	return usage
+}
+
+//allow records a call made with key, returning a ResourceExhausted error when its quota or rate limit is exceeded
+func (u *usageTracker) allow(key *APIKey, now time.Time) error {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	usage := u.usageOf(key, now)
+	usage.lastUsed = now
+	if key.Quota > 0 && usage.periodCalls >= key.Quota {
+		usage.rejected++
+		return status.Errorf(codes.ResourceExhausted, "quota of %v calls of API key %v is used up until %v", key.Quota, key.Name, usage.periodStart.Add(u.period).Format(time.RFC3339))
+	}
+	if !usage.limiter.AllowN(now, 1) {
+		usage.rejected++
+		return status.Errorf(codes.ResourceExhausted, "rate limit of %v calls per second of API key %v is exceeded", key.RateLimit, key.Name)
+	}
+	usage.requests++
+	usage.periodCalls++
+	return nil
+}
+
+//report returns the usage of key
+func (u *usageTracker) report(key APIKey, now time.Time) *grpc_fpl.KeyUsage {
+	u.mu.Lock()
+	defer u.mu.Unlock()
+
+	keyUsage := &grpc_fpl.KeyUsage{
+		Name:  key.Name,
+		Admin: key.Admin,
+		Quota: key.Quota,
+	}
+	usage, ok := u.keys[key.Name]
+	if !ok {
+		keyUsage.Remaining = key.Quota
+		return keyUsage
+	}
+	usage = u.usageOf(&key, now)
+	keyUsage.Requests = usage.requests
+	keyUsage.Rejected = usage.rejected
+	keyUsage.LastUsedAt = usage.lastUsed.Unix()
+	if key.Quota > 0 {
+		keyUsage.Remaining = key.Quota - usage.periodCalls
+		keyUsage.QuotaResetsAt = usage.periodStart.Add(u.period).Unix()
+	}
+	return keyUsage
+}
+
+//authenticate checks the API key of a call to the FPL service, when the server has a KeyStore. Other services,
+//like health checks and reflection, don't need a key
+func (s *MyFPLServer) authenticate(ctx context.Context, method string) (context.Context, error) {
+	if s.KeyStore == nil || !strings.HasPrefix(method, fplMethodPrefix) {
+		return ctx, nil
+	}
+
+	var value string
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(APIKeyHeader)) > 0 {
+		value = md.Get(APIKeyHeader)[0]
+	}
+	if value == "" {
+		return ctx, status.Errorf(codes.Unauthenticated, "an API key is required in the %v metadata", APIKeyHeader)
+	}
+	key, ok := s.KeyStore.Lookup(value)
+	if !ok {
+		return ctx, status.Errorf(codes.Unauthenticated, "invalid API key")
+	}
+
+	ctx = withLogger(ctx, s.logger(ctx).WithField("api_key", key.Name))
+	if method == getUsageMethod && !key.Admin {
+		return ctx, status.Errorf(codes.PermissionDenied, "API key %v can't see the usage of other keys", key.Name)
+	}
+	if (method == defineCohortMethod || method == deleteCohortMethod) && !key.Admin {
+		return ctx, status.Errorf(codes.PermissionDenied, "API key %v can't change cohorts", key.Name)
+	}
+	if err := s.usageTracker().allow(key, time.Now()); err != nil {
+		return ctx, err
+	}
+	return ctx, nil
+}
+
+//unaryAuthInterceptor and streamAuthInterceptor reject calls without a valid API key, or over its quota
+func (s *MyFPLServer) unaryAuthInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, err := s.authenticate(ctx, info.FullMethod)
+	if err != nil {
+		return nil, err
+	}
+	return handler(ctx, req)
+}
+
+func (s *MyFPLServer) streamAuthInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, err := s.authenticate(stream.Context(), info.FullMethod)
+	if err != nil {
+		return err
+	}
+	return handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+}
+
+//GetUsage returns the calls made with every API key. Only admin keys can call it
+func (s *MyFPLServer) GetUsage(cxt context.Context, req *grpc_fpl.UsageReq) (*grpc_fpl.Usage, error) {
+	if s.KeyStore == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "the server doesn't use API keys")
+	}
+	now := time.Now()
+	usage := &grpc_fpl.Usage{}
+	for _, key := range s.KeyStore.List() {
+		usage.Keys = append(usage.Keys, s.usageTracker().report(key, now))
+	}
+	return usage, nil
+}
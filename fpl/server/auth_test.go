@@ -0,0 +1,157 @@
+package server_test
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/go-fantasy/fpl/mock"
+	"github.com/go-fantasy/fpl/server"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func withAPIKey(key string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), server.APIKeyHeader, key)
+}
+
+func TestAPIKeys(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testObj := mock_server.NewMockScraper(mockCtrl)
+	testObj.EXPECT().GetPlayerMapping(gomock.Any()).Return(map[int64]string{267: "Messi"}, nil).AnyTimes()
+	testObj.EXPECT().GetEvents(gomock.Any()).Return([]server.Event{}, nil).AnyTimes()
+
+	keyStore, err := server.NewKeyStore(
+		server.APIKey{Name: "admin", Key: "admin-key", Admin: true},
+		server.APIKey{Name: "quota", Key: "quota-key", Quota: 2},
+		server.APIKey{Name: "limited", Key: "limited-key", RateLimit: 0.001, Burst: 1},
+		server.APIKey{Name: "unused", Key: "unused-key", Quota: 10},
+	)
+	require.Nil(t, err)
+
+	myFPLServer := &server.MyFPLServer{Scraper: testObj, KeyStore: keyStore, HealthCheck: true}
+	lis := bufconn.Listen(1024 * 1024)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go myFPLServer.Serve(ctx, lis)
+
+	conn, err := dialBufconn(lis)
+	require.Nil(t, err)
+	defer conn.Close()
+	grpcClient := grpc_fpl.NewFPLClient(conn)
+
+	callWith := func(callCtx context.Context) codes.Code {
+		_, err := grpcClient.GetNumberOfPlayers(callCtx, &grpc_fpl.NumPlayerRequest{})
+		return status.Code(err)
+	}
+
+	assert.Equal(t, codes.Unauthenticated, callWith(context.Background()), "Calls without a key should be rejected")
+	assert.Equal(t, codes.Unauthenticated, callWith(withAPIKey("wrong-key")), "Calls with an unknown key should be rejected")
+
+	assert.Equal(t, codes.OK, callWith(withAPIKey("quota-key")))
+	assert.Equal(t, codes.OK, callWith(withAPIKey("quota-key")))
+	assert.Equal(t, codes.ResourceExhausted, callWith(withAPIKey("quota-key")), "Calls over the quota should be rejected")
+
+	assert.Equal(t, codes.OK, callWith(withAPIKey("limited-key")))
+	assert.Equal(t, codes.ResourceExhausted, callWith(withAPIKey("limited-key")), "Calls over the rate limit should be rejected")
+
+	for i := 0; i < 5; i++ {
+		assert.Equal(t, codes.OK, callWith(withAPIKey("admin-key")), "Keys without limits shouldn't be limited")
+	}
+
+	_, err = healthpb.NewHealthClient(conn).Check(context.Background(), &healthpb.HealthCheckRequest{})
+	assert.Nil(t, err, "Health checks shouldn't need a key")
+
+	_, err = grpcClient.GetUsage(withAPIKey("quota-key"), &grpc_fpl.UsageReq{})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "Only admin keys should see the usage")
+
+	usage, err := grpcClient.GetUsage(withAPIKey("admin-key"), &grpc_fpl.UsageReq{})
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	require.Len(t, usage.Keys, 4)
+
+	byName := make(map[string]*grpc_fpl.KeyUsage)
+	for _, keyUsage := range usage.Keys {
+		byName[keyUsage.Name] = keyUsage
+	}
+	assert.Equal(t, int64(6), byName["admin"].Requests, "GetUsage should count as a call")
+	assert.True(t, byName["admin"].Admin)
+	assert.Equal(t, int64(2), byName["quota"].Requests)
+	assert.Equal(t, int64(1), byName["quota"].Rejected, "Calls over the quota should be counted")
+	assert.Equal(t, int64(0), byName["quota"].Remaining)
+	assert.InDelta(t, time.Now().Add(server.DefaultQuotaPeriod).Unix(), byName["quota"].QuotaResetsAt, 60)
+	assert.Equal(t, int64(1), byName["limited"].Requests)
+	assert.Equal(t, int64(1), byName["limited"].Rejected)
+	assert.Equal(t, int64(0), byName["unused"].Requests)
+	assert.Equal(t, int64(10), byName["unused"].Remaining)
+}
+
+func TestQuotaPeriod(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testObj := mock_server.NewMockScraper(mockCtrl)
+	testObj.EXPECT().GetPlayerMapping(gomock.Any()).Return(map[int64]string{}, nil).AnyTimes()
+
+	keyStore, err := server.NewKeyStore(server.APIKey{Name: "quota", Key: "quota-key", Quota: 1})
+	require.Nil(t, err)
+	myFPLServer := &server.MyFPLServer{Scraper: testObj, KeyStore: keyStore, QuotaPeriod: time.Millisecond * 100}
+	lis := bufconn.Listen(1024 * 1024)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go myFPLServer.Serve(ctx, lis)
+
+	conn, err := dialBufconn(lis)
+	require.Nil(t, err)
+	defer conn.Close()
+	grpcClient := grpc_fpl.NewFPLClient(conn)
+
+	_, err = grpcClient.GetNumberOfPlayers(withAPIKey("quota-key"), &grpc_fpl.NumPlayerRequest{})
+	assert.Nil(t, err)
+	_, err = grpcClient.GetNumberOfPlayers(withAPIKey("quota-key"), &grpc_fpl.NumPlayerRequest{})
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+
+	time.Sleep(time.Millisecond * 150)
+	_, err = grpcClient.GetNumberOfPlayers(withAPIKey("quota-key"), &grpc_fpl.NumPlayerRequest{})
+	assert.Nil(t, err, "The quota should be reset after QuotaPeriod")
+}
+
+func TestLoadKeyStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fpl-keys")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "keys.json")
+	err = ioutil.WriteFile(file, []byte(`[
+		{"name": "dashboard", "key": "dashboard-key", "quota": 1000, "rateLimit": 1.5, "burst": 5},
+		{"name": "ops", "key": "ops-key", "admin": true}
+	]`), 0600)
+	require.Nil(t, err)
+
+	keyStore, err := server.LoadKeyStore(file)
+	require.Nil(t, err)
+	key, ok := keyStore.Lookup("dashboard-key")
+	assert.True(t, ok)
+	assert.Equal(t, server.APIKey{Name: "dashboard", Key: "dashboard-key", Quota: 1000, RateLimit: 1.5, Burst: 5}, *key)
+	_, ok = keyStore.Lookup("dashboard")
+	assert.False(t, ok, "Keys should be looked up by their value, not their name")
+	assert.Equal(t, []string{"dashboard", "ops"}, []string{keyStore.List()[0].Name, keyStore.List()[1].Name})
+
+	_, err = server.NewKeyStore(server.APIKey{Name: "a", Key: "key"}, server.APIKey{Name: "a", Key: "other-key"})
+	assert.NotNil(t, err, "Names should be unique")
+	_, err = server.NewKeyStore(server.APIKey{Name: "a", Key: "key"}, server.APIKey{Name: "b", Key: "key"})
+	assert.NotNil(t, err, "Keys should be unique")
+	_, err = server.NewKeyStore(server.APIKey{Name: "a"})
+	assert.NotNil(t, err, "Keys need a value")
+	_, err = server.LoadKeyStore(filepath.Join(dir, "missing.json"))
+	assert.NotNil(t, err)
+}
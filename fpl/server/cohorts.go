@@ -0,0 +1,264 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"time"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//maxCohortEntries is how many entries a cohort can have, as every one of them is scraped in every gameweek
+const maxCohortEntries = 1000
+
+//NewCohortStore creates a cohort store holding cohorts. Every cohort needs a unique name and some entries
+func NewCohortStore(cohorts ...Cohort) (*MemoryCohortStore, error) {
+	store := &MemoryCohortStore{
+		cohorts: make(map[string]Cohort, len(cohorts)),
+	}
+	for _, cohort := range cohorts {
+		cohort, err := validCohort(cohort)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := store.cohorts[cohort.Name]; ok {
+			return nil, errors.Errorf("cohort %v is defined more than once", cohort.Name)
+		}
+		store.cohorts[cohort.Name] = cohort
+	}
+	return store, nil
+}
+
+//LoadCohortStore creates a cohort store from a JSON file holding an array of cohorts, like
+//[{"name": "creators", "entries": [1234, 5678]}]
+func LoadCohortStore(file string) (*MemoryCohortStore, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Errorf("error reading cohorts from %v : %v", file, err)
+	}
+	var cohorts []Cohort
+	if err := json.Unmarshal(content, &cohorts); err != nil {
+		return nil, errors.Errorf("error parsing cohorts from %v : %v", file, err)
+	}
+	return NewCohortStore(cohorts...)
+}
+
+//validCohort checks that a cohort has a name and between 1 and maxCohortEntries valid entries, returning it with
+//its entries in order and without duplicates
+func validCohort(cohort Cohort) (Cohort, error) {
+	if cohort.Name == "" {
+		return cohort, errors.Errorf("cohorts need a name")
+	}
+	seen := make(map[int64]bool, len(cohort.Entries))
+	entries := make([]int64, 0, len(cohort.Entries))
+	for _, entry := range cohort.Entries {
+		if entry <= 0 {
+			return cohort, errors.Errorf("cohort %v has an invalid entry %v", cohort.Name, entry)
+		}
+		if !seen[entry] {
+			seen[entry] = true
+			entries = append(entries, entry)
+		}
+	}
+	if len(entries) == 0 || len(entries) > maxCohortEntries {
+		return cohort, errors.Errorf("cohort %v should have between 1 and %v entries, not %v", cohort.Name, maxCohortEntries, len(entries))
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i] < entries[j]
+	})
+	return Cohort{Name: cohort.Name, Entries: entries}, nil
+}
+
+//Get returns the cohort with the given name
+func (m *MemoryCohortStore) Get(name string) (Cohort, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	cohort, ok := m.cohorts[name]
+	return cohort, ok
+}
+
+//Set adds a cohort, or replaces the cohort with the same name
+func (m *MemoryCohortStore) Set(cohort Cohort) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cohorts[cohort.Name] = cohort
+}
+
+//Delete removes the cohort with the given name, returning whether there was one
+func (m *MemoryCohortStore) Delete(name string) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	_, ok := m.cohorts[name]
+	delete(m.cohorts, name)
+	return ok
+}
+
+//List returns every cohort, sorted by name
+func (m *MemoryCohortStore) List() []Cohort {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	cohorts := make([]Cohort, 0, len(m.cohorts))
+	for _, cohort := range m.cohorts {
+		cohorts = append(cohorts, cohort)
+	}
+	sort.Slice(cohorts, func(i, j int) bool {
+		return cohorts[i].Name < cohorts[j].Name
+	})
+	return cohorts
+}
+
+//DefineCohort is the gRPC method adding a cohort, or replacing the cohort with the same name. When the server uses
+//API keys, only admin keys can call it
+func (s *MyFPLServer) DefineCohort(ctx context.Context, req *grpc_fpl.Cohort) (*grpc_fpl.Cohort, error) {
+	if s.Cohorts == nil {
+		return nil, status.Errorf(codes.FailedPrecondition, "the server doesn't keep cohorts")
+	}
+	cohort, err := validCohort(Cohort{Name: req.Name, Entries: req.Entries})
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	s.Cohorts.Set(cohort)
+	s.logger(ctx).WithField("cohort", cohort.Name).WithField("entries", len(cohort.Entries)).Info("defined cohort")
+	return toCohortResult(cohort), nil
+}
+
+//ListCohorts is the gRPC method returning every cohort, sorted by name
+func (s *MyFPLServer) ListCohorts(ctx context.Context, req *grpc_fpl.CohortsReq) (*grpc_fpl.Cohorts, error) {
+	cohorts := &grpc_fpl.Cohorts{}
+	if s.Cohorts == nil {
+		return cohorts, nil
+	}
+	for _, cohort := range s.Cohorts.List() {
+		cohorts.Cohorts = append(cohorts.Cohorts, toCohortResult(cohort))
+	}
+	return cohorts, nil
+}
+
+//DeleteCohort is the gRPC method removing a cohort, returning it. When the server uses API keys, only admin keys
+//can call it
+func (s *MyFPLServer) DeleteCohort(ctx context.Context, req *grpc_fpl.CohortName) (*grpc_fpl.Cohort, error) {
+	cohort, err := s.cohort(req.Name)
+	if err != nil {
+		return nil, err
+	}
+	if !s.Cohorts.Delete(cohort.Name) {
+		return nil, status.Errorf(codes.NotFound, "cohort %v isn't defined", req.Name)
+	}
+	s.logger(ctx).WithField("cohort", cohort.Name).Info("deleted cohort")
+	return toCohortResult(cohort), nil
+}
+
+func toCohortResult(cohort Cohort) *grpc_fpl.Cohort {
+	return &grpc_fpl.Cohort{Name: cohort.Name, Entries: cohort.Entries}
+}
+
+//cohort returns the cohort with the given name, failing with NotFound when there is none
+func (s *MyFPLServer) cohort(name string) (Cohort, error) {
+	if s.Cohorts != nil {
+		if cohort, ok := s.Cohorts.Get(name); ok {
+			return cohort, nil
+		}
+	}
+	return Cohort{}, status.Errorf(codes.NotFound, "cohort %v isn't defined", name)
+}
+
+//checkLeagueOrCohort fails requests asking for both a league and a cohort
+func checkLeagueOrCohort(leagueCode int64, cohort string) error {
+	if leagueCode != 0 && cohort != "" {
+		return status.Errorf(codes.InvalidArgument, "league %v and cohort %v can't be looked at together", leagueCode, cohort)
+	}
+	return nil
+}
+
+//groupData returns the data of some gameweeks of a cohort when one is given, or of the league otherwise, every
+//gameweek when gameweeks is nil
+func (s *MyFPLServer) groupData(ctx context.Context, leagueCode int, cohort string, sampleSize int, gameweeks []int) (*LeagueData, error) {
+	if cohort != "" {
+		return s.cohortData(ctx, cohort, gameweeks)
+	}
+	return s.gameweeksData(ctx, leagueCode, sampleSize, gameweeks)
+}
+
+//cohortData returns the data of every entry of a cohort in gameweeks, or in every gameweek when gameweeks is nil.
+//Cohorts aren't kept in the store, so they are scraped on every call
+func (s *MyFPLServer) cohortData(ctx context.Context, name string, gameweeks []int) (*LeagueData, error) {
+	cohort, err := s.cohort(name)
+	if err != nil {
+		return nil, err
+	}
+	if gameweeks == nil {
+		gameweeks = gameweekRange(1, GameweekMax)
+	}
+
+	//Identical requests running at the same time share a single scrape
+	key := fmt.Sprintf("cohort:%v:%v:%v", cohort.Name, cohort.Entries, gameweeks)
+	result, err, _ := s.scrapes.Do(key, func() (interface{}, error) {
+		return s.scrapeCohort(s.sharedContext(ctx), cohort, gameweeks)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*LeagueData), nil
+}
+
+//scrapeCohort fetches the player occurances of every entry of a cohort in gameweeks
+func (s *MyFPLServer) scrapeCohort(ctx context.Context, cohort Cohort, gameweeks []int) (leagueData *LeagueData, err error) {
+	ctx, span := startSpan(ctx, "scrapeCohort",
+		attribute.String("cohort", cohort.Name),
+		attribute.Int("entries", len(cohort.Entries)),
+		attribute.Int("gameweeks", len(gameweeks)),
+	)
+	defer func() { endSpan(span, err) }()
+
+	playerMap, err := s.Scraper.GetPlayerMapping(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error while getting player mapping : %v", err)
+	}
+	playerOccurances := s.scrapeEntries(ctx, playerMap, cohort.Entries, gameweeks)
+
+	s.logger(ctx).WithField("gameweeks", len(playerOccurances)).Info("scraped cohort")
+	return &LeagueData{
+		Cohort:           cohort.Name,
+		SampleSize:       len(cohort.Entries),
+		PlayerMap:        playerMap,
+		Participants:     cohort.Entries,
+		PlayerOccurances: playerOccurances,
+		FetchedAt:        time.Now(),
+	}, nil
+}
+
+//groupPicks fetches the picks of every entry of a cohort for a gameweek when one is given, or of the top
+//participants of the league otherwise, along with the names of the players
+func (s *MyFPLServer) groupPicks(ctx context.Context, leagueCode int, cohort string, gameweek, sampleSize int) (map[int64]string, map[int64]*ParticipantTeamInfo, error) {
+	if cohort == "" {
+		return s.leaguePicks(ctx, leagueCode, gameweek, sampleSize)
+	}
+	found, err := s.cohort(cohort)
+	if err != nil {
+		return nil, nil, err
+	}
+	playerMap, err := s.Scraper.GetPlayerMapping(ctx)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "error while getting player mapping : %v", err)
+	}
+	picks, err := s.Scraper.GetPicksForParticipants(ctx, gameweek, &found.Entries)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "error while fetching picks for gameweek %v : %v", gameweek, err)
+	}
+	return playerMap, picks, nil
+}
+
+//name describes what the data is of, a league or a cohort
+func (d *LeagueData) name() string {
+	if d.Cohort != "" {
+		return "cohort " + d.Cohort
+	}
+	return fmt.Sprintf("league %v", d.LeagueCode)
+}
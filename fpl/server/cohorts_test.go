@@ -0,0 +1,177 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/go-fantasy/fpl/mock"
+	"github.com/go-fantasy/fpl/server"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestCohorts(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testObj := mock_server.NewMockScraper(mockCtrl)
+
+	cohorts, err := server.NewCohortStore()
+	require.Nil(t, err)
+	myFPLServer := &server.MyFPLServer{Scraper: testObj, Cohorts: cohorts}
+	ctx := context.Background()
+
+	cohort, err := myFPLServer.DefineCohort(ctx, &grpc_fpl.Cohort{Name: "creators", Entries: []int64{3, 1, 3}})
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, []int64{1, 3}, cohort.Entries, "Entries should be sorted without duplicates")
+	for _, invalid := range []*grpc_fpl.Cohort{{Entries: []int64{1}}, {Name: "empty"}, {Name: "negative", Entries: []int64{-1}}} {
+		_, err := myFPLServer.DefineCohort(ctx, invalid)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "Cohort %v should be invalid", invalid)
+	}
+	listed, err := myFPLServer.ListCohorts(ctx, &grpc_fpl.CohortsReq{})
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	require.Len(t, listed.Cohorts, 1)
+	assert.Equal(t, "creators", listed.Cohorts[0].Name)
+
+	//Every entry of the cohort is scraped, whatever the sample size
+	testObj.EXPECT().GetPlayerMapping(gomock.Any()).Return(map[int64]string{267: "Messi", 301: "Salah"}, nil).AnyTimes()
+	testObj.EXPECT().GetTeamInfoForParticipant(gomock.Any(), gomock.Any(), gomock.Any(), &[]int64{1, 3}).
+		DoAndReturn(func(ctx context.Context, playerMap map[int64]string, gameweek int, participants *[]int64) (map[string]int, error) {
+			if gameweek != 1 {
+				return nil, errors.New("gameweek hasn't been played yet")
+			}
+			return map[string]int{"Messi": 2, "Salah": 1}, nil
+		}).AnyTimes()
+
+	data, err := myFPLServer.GetDataForGameweek(ctx, &grpc_fpl.GameweekReq{Cohort: "creators", Gameweek: 1, SampleSize: 1})
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, map[string]int32{"Messi": 2, "Salah": 1}, data.PlayerOccurance)
+
+	stream := &exportStream{}
+	err = myFPLServer.GetDataForAllGameweeks(&grpc_fpl.AllGameweeksReq{
+		Cohort:    "creators",
+		Gameweeks: &grpc_fpl.GameweekSelection{To: 2},
+	}, stream)
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	lines := strings.Split(stream.data.String(), "\n")
+	assert.Equal(t, []string{"# Cohort: creators", "# Sample size: 2"}, lines[:2])
+	assert.Equal(t, []string{"Player,Gameweek 1,Total,Average,Peak gameweek", "Messi,2,2,2.00,1", "Salah,1,1,1.00,1"}, lines[3:6])
+
+	_, err = myFPLServer.GetHeatmap(ctx, &grpc_fpl.HeatmapReq{Cohort: "creators", Format: grpc_fpl.ImageFormat_SVG})
+	assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+
+	_, err = myFPLServer.GetDataForGameweek(ctx, &grpc_fpl.GameweekReq{LeagueCode: 313, Cohort: "creators", Gameweek: 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "A league and a cohort can't be looked at together")
+	_, err = myFPLServer.GetDataForGameweek(ctx, &grpc_fpl.GameweekReq{Cohort: "unknown", Gameweek: 1})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+
+	deleted, err := myFPLServer.DeleteCohort(ctx, &grpc_fpl.CohortName{Name: "creators"})
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, "creators", deleted.Name)
+	_, err = myFPLServer.DeleteCohort(ctx, &grpc_fpl.CohortName{Name: "creators"})
+	assert.Equal(t, codes.NotFound, status.Code(err))
+}
+
+func TestCohortsNeedAdminKey(t *testing.T) {
+	keyStore, err := server.NewKeyStore(
+		server.APIKey{Name: "admin", Key: "admin-key", Admin: true},
+		server.APIKey{Name: "reader", Key: "reader-key"},
+	)
+	require.Nil(t, err)
+	cohorts, err := server.NewCohortStore()
+	require.Nil(t, err)
+	myFPLServer := &server.MyFPLServer{KeyStore: keyStore, Cohorts: cohorts}
+	lis := bufconn.Listen(1024 * 1024)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	go myFPLServer.Serve(ctx, lis)
+
+	conn, err := dialBufconn(lis)
+	require.Nil(t, err)
+	defer conn.Close()
+	grpcClient := grpc_fpl.NewFPLClient(conn)
+
+	cohort := &grpc_fpl.Cohort{Name: "creators", Entries: []int64{1}}
+	_, err = grpcClient.DefineCohort(withAPIKey("reader-key"), cohort)
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "Only admin keys should define cohorts")
+	_, err = grpcClient.DefineCohort(withAPIKey("admin-key"), cohort)
+	assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+	listed, err := grpcClient.ListCohorts(withAPIKey("reader-key"), &grpc_fpl.CohortsReq{})
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Len(t, listed.Cohorts, 1, "Any key should list cohorts")
+	_, err = grpcClient.DeleteCohort(withAPIKey("reader-key"), &grpc_fpl.CohortName{Name: "creators"})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err), "Only admin keys should delete cohorts")
+}
+
+func TestLoadCohortStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fpl-cohorts")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	file := filepath.Join(dir, "cohorts.json")
+	err = ioutil.WriteFile(file, []byte(`[
+		{"name": "creators", "entries": [5678, 1234]},
+		{"name": "friends", "entries": [42]}
+	]`), 0600)
+	require.Nil(t, err)
+
+	cohorts, err := server.LoadCohortStore(file)
+	require.Nil(t, err)
+	cohort, ok := cohorts.Get("creators")
+	assert.True(t, ok)
+	assert.Equal(t, server.Cohort{Name: "creators", Entries: []int64{1234, 5678}}, cohort)
+	assert.Len(t, cohorts.List(), 2)
+
+	_, err = server.NewCohortStore(server.Cohort{Name: "a", Entries: []int64{1}}, server.Cohort{Name: "a", Entries: []int64{2}})
+	assert.NotNil(t, err, "Names should be unique")
+	_, err = server.LoadCohortStore(filepath.Join(dir, "missing.json"))
+	assert.NotNil(t, err)
+}
+
+func TestCohortExportResume(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testObj := mock_server.NewMockScraper(mockCtrl)
+
+	//The cohort is scraped once, resuming sends the rest of the same data
+	testObj.EXPECT().GetPlayerMapping(gomock.Any()).Return(map[int64]string{267: "Messi"}, nil).Times(1)
+	testObj.EXPECT().GetTeamInfoForParticipant(gomock.Any(), gomock.Any(), gomock.Any(), &[]int64{1, 2}).
+		Return(map[string]int{"Messi": 2}, nil).Times(3)
+
+	cohorts, err := server.NewCohortStore(server.Cohort{Name: "c", Entries: []int64{1, 2}})
+	require.Nil(t, err)
+	myFPLServer := &server.MyFPLServer{Scraper: testObj, Cohorts: cohorts}
+	req := &grpc_fpl.AllGameweeksReq{Cohort: "c", Gameweeks: &grpc_fpl.GameweekSelection{To: 3}}
+	full := &exportStream{}
+	err = myFPLServer.GetDataForAllGameweeks(req, full)
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+
+	resumed := &exportStream{}
+	err = myFPLServer.GetDataForAllGameweeks(&grpc_fpl.AllGameweeksReq{
+		Cohort:      "c",
+		Gameweeks:   req.Gameweeks,
+		Offset:      1,
+		ResumeToken: full.messages[0].ResumeToken,
+	}, resumed)
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, full.data.String()[1:], resumed.data.String())
+
+	//The token doesn't resume an export of the same cohort in another format
+	err = myFPLServer.GetDataForAllGameweeks(&grpc_fpl.AllGameweeksReq{
+		Cohort:      "c",
+		Gameweeks:   req.Gameweeks,
+		Format:      grpc_fpl.ExportFormat_JSON,
+		Offset:      1,
+		ResumeToken: full.messages[0].ResumeToken,
+	}, &exportStream{})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "Error %v should be a failed precondition", err)
+}
@@ -0,0 +1,273 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+	"time"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//maxComparedLeagues is how many leagues can be compared in a single call
+const maxComparedLeagues = 10
+
+//CompareLeagues is the gRPC method comparing the ownership of players among the top participants of several leagues,
+//side by side in every gameweek with the difference of each league to the first one
+func (s *MyFPLServer) CompareLeagues(ctx context.Context, req *grpc_fpl.CompareReq) (*grpc_fpl.LeagueComparison, error) {
+	sampleSize := sampleSizeOrDefault(int(req.SampleSize))
+	ctx = withLogger(ctx, s.logger(ctx).WithFields(logrus.Fields{
+		"leagues":     req.LeagueCodes,
+		"sample_size": sampleSize,
+	}))
+	if len(req.LeagueCodes) < 2 || len(req.LeagueCodes) > maxComparedLeagues {
+		return nil, status.Errorf(codes.InvalidArgument, "between 2 and %v leagues should be compared, not %v", maxComparedLeagues, len(req.LeagueCodes))
+	}
+	leagueCodes := make([]int, len(req.LeagueCodes))
+	seen := make(map[int64]bool)
+	for i, leagueCode := range req.LeagueCodes {
+		if leagueCode <= 0 {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid league %v", leagueCode)
+		}
+		if seen[leagueCode] {
+			return nil, status.Errorf(codes.InvalidArgument, "league %v is compared more than once", leagueCode)
+		}
+		seen[leagueCode] = true
+		leagueCodes[i] = int(leagueCode)
+	}
+	gameweeks, err := s.selectedGameweeks(ctx, req.Gameweeks)
+	if err != nil {
+		return nil, err
+	}
+
+	leagues, err := s.comparedLeagueData(ctx, leagueCodes, sampleSize, gameweeks)
+	if err != nil {
+		return nil, err
+	}
+	return compareLeagues(leagues), nil
+}
+
+//comparedLeagueData returns the data of every league compared, in the order asked for. Leagues fresh in the store are
+//served from it and the others are scraped together, so that the player mapping is fetched once and the picks of a
+//participant in several of the leagues are fetched once per gameweek
+func (s *MyFPLServer) comparedLeagueData(ctx context.Context, leagueCodes []int, sampleSize int, gameweeks []int) ([]*LeagueData, error) {
+	leagues := make([]*LeagueData, len(leagueCodes))
+	var missing []int
+	for i, leagueCode := range leagueCodes {
+		if leagueData, ok := s.cachedLeagueData(ctx, leagueCode, sampleSize); ok {
+			leagues[i] = leagueData.only(gameweeks)
+		} else {
+			missing = append(missing, leagueCode)
+		}
+	}
+	if len(missing) == 0 {
+		return leagues, nil
+	}
+
+	//Identical comparisons running at the same time share a single scrape
+	key := fmt.Sprintf("compare:%v:%v:%v", missing, gameweeks, sampleSize)
+	result, err, _ := s.scrapes.Do(key, func() (interface{}, error) {
+		scraped, err := s.scrapeLeagues(s.sharedContext(ctx), missing, sampleSize, gameweeks)
+		if err != nil {
+			return nil, err
+		}
+		//Only scrapes of every gameweek are stored, as the store keeps the data of every gameweek
+		if s.Store != nil && gameweeks == nil {
+			for _, leagueData := range scraped {
+				s.Store.Set(leagueData)
+			}
+		}
+		return scraped, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	scraped := result.(map[int]*LeagueData)
+	for i, leagueCode := range leagueCodes {
+		if leagues[i] == nil {
+			leagues[i] = scraped[leagueCode]
+		}
+	}
+	return leagues, nil
+}
+
+//scrapeLeagues fetches the player occurances of the top participants of several leagues in gameweeks, or in every
+//gameweek when gameweeks is nil, with a go-routine per gameweek fetching the picks of every participant once
+func (s *MyFPLServer) scrapeLeagues(ctx context.Context, leagueCodes []int, sampleSize int, gameweeks []int) (leagues map[int]*LeagueData, err error) {
+	if gameweeks == nil {
+		gameweeks = gameweekRange(1, GameweekMax)
+	}
+	ctx, span := startSpan(ctx, "scrapeLeagues",
+		attribute.Int("leagues", len(leagueCodes)),
+		attribute.Int("sample_size", sampleSize),
+		attribute.Int("gameweeks", len(gameweeks)),
+	)
+	defer func() { endSpan(span, err) }()
+
+	playerMap, err := s.Scraper.GetPlayerMapping(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error while getting player mapping : %v", err)
+	}
+
+	leagueParticipants := make(map[int][]int64, len(leagueCodes))
+	var participants []int64
+	seen := make(map[int64]bool)
+	for _, leagueCode := range leagueCodes {
+		allParticipants, err := s.Scraper.GetParticipantsInLeague(ctx, leagueCode)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "error in GetParticipantsInLeague for league %v : %v", leagueCode, err)
+		}
+		leagueParticipants[leagueCode] = topParticipants(allParticipants, sampleSize)
+		for _, participant := range leagueParticipants[leagueCode] {
+			if !seen[participant] {
+				seen[participant] = true
+				participants = append(participants, participant)
+			}
+		}
+	}
+	s.logger(ctx).WithField("participants", len(participants)).Debug("fetching picks of the participants of every league")
+
+	var (
+		wg               sync.WaitGroup
+		mutex            sync.Mutex
+		playerOccurances = make(map[int]map[int]map[string]int, len(leagueCodes))
+	)
+	for _, leagueCode := range leagueCodes {
+		playerOccurances[leagueCode] = make(map[int]map[string]int)
+	}
+	for _, gameweek := range gameweeks {
+		wg.Add(1)
+		go func(gameweek int) {
+			defer wg.Done()
+
+			ctx := withLogger(ctx, s.logger(ctx).WithField("gameweek", gameweek))
+			ctx, span := startSpan(ctx, "scrapeGameweek", attribute.Int("gameweek", gameweek))
+
+			//Gameweeks that haven't been played yet have no picks, so errors are skipped
+			start := time.Now()
+			picks, err := s.Scraper.GetPicksForParticipants(ctx, gameweek, &participants)
+			endSpan(span, err)
+			if err != nil {
+				s.logger(ctx).WithError(err).Debug("skipping gameweek")
+				return
+			}
+			s.Metrics.observeScrape(gameweek, start)
+
+			mutex.Lock()
+			defer mutex.Unlock()
+			for _, leagueCode := range leagueCodes {
+				if playerOccuranceForGameweek := countPicks(playerMap, picks, leagueParticipants[leagueCode]); len(playerOccuranceForGameweek) > 0 {
+					playerOccurances[leagueCode][gameweek] = playerOccuranceForGameweek
+				}
+			}
+		}(gameweek)
+	}
+	wg.Wait()
+
+	fetchedAt := time.Now()
+	leagues = make(map[int]*LeagueData, len(leagueCodes))
+	for _, leagueCode := range leagueCodes {
+		leagues[leagueCode] = &LeagueData{
+			LeagueCode:       leagueCode,
+			SampleSize:       sampleSize,
+			PlayerMap:        playerMap,
+			Participants:     leagueParticipants[leagueCode],
+			PlayerOccurances: playerOccurances[leagueCode],
+			FetchedAt:        fetchedAt,
+		}
+	}
+	s.logger(ctx).Info("scraped leagues")
+	return leagues, nil
+}
+
+//countPicks counts how many of participants picked each player
+func countPicks(playerMap map[int64]string, picks map[int64]*ParticipantTeamInfo, participants []int64) map[string]int {
+	playerOccuranceForGameweek := make(map[string]int)
+	for _, participant := range participants {
+		teamInfo, ok := picks[participant]
+		if !ok {
+			continue
+		}
+		for _, player := range teamInfo.TeamPlayers {
+			playerOccuranceForGameweek[playerMap[player.Element]]++
+		}
+	}
+	return playerOccuranceForGameweek
+}
+
+//compareLeagues lays the ownership of players in leagues side by side for every gameweek played in any of them. The
+//players owned in any of the leagues come first by the most owners over every league, and deltas are the difference
+//in percentage points between the share of each league owning a player and the share of the first league
+func compareLeagues(leagues []*LeagueData) *grpc_fpl.LeagueComparison {
+	comparison := &grpc_fpl.LeagueComparison{}
+	sampled := make([]int, len(leagues))
+	gameweekSet := make(map[int]bool)
+	for i, leagueData := range leagues {
+		sampled[i] = len(leagueData.Participants)
+		if sampled[i] == 0 {
+			sampled[i] = leagueData.SampleSize
+		}
+		comparison.LeagueCodes = append(comparison.LeagueCodes, int64(leagueData.LeagueCode))
+		comparison.SampleSizes = append(comparison.SampleSizes, int64(sampled[i]))
+		for gameweek := range leagueData.PlayerOccurances {
+			gameweekSet[gameweek] = true
+		}
+	}
+	gameweeks := make([]int, 0, len(gameweekSet))
+	for gameweek := range gameweekSet {
+		gameweeks = append(gameweeks, gameweek)
+	}
+	sort.Ints(gameweeks)
+
+	for _, gameweek := range gameweeks {
+		totals := make(map[string]int)
+		for _, leagueData := range leagues {
+			for player, owners := range leagueData.PlayerOccurances[gameweek] {
+				if owners > 0 {
+					totals[player] += owners
+				}
+			}
+		}
+
+		gameweekComparison := &grpc_fpl.GameweekComparison{Gameweek: int64(gameweek)}
+		for player := range totals {
+			playerComparison := &grpc_fpl.PlayerComparison{Player: player}
+			for i, leagueData := range leagues {
+				owners := leagueData.PlayerOccurances[gameweek][player]
+				playerComparison.Owners = append(playerComparison.Owners, int64(owners))
+				playerComparison.Percentages = append(playerComparison.Percentages, percentage(owners, sampled[i]))
+			}
+			for _, percent := range playerComparison.Percentages {
+				playerComparison.Deltas = append(playerComparison.Deltas, roundPercentage(percent-playerComparison.Percentages[0]))
+			}
+			gameweekComparison.Players = append(gameweekComparison.Players, playerComparison)
+		}
+		sort.Slice(gameweekComparison.Players, func(i, j int) bool {
+			first, second := gameweekComparison.Players[i].Player, gameweekComparison.Players[j].Player
+			if totals[first] != totals[second] {
+				return totals[first] > totals[second]
+			}
+			return first < second
+		})
+		comparison.Gameweeks = append(comparison.Gameweeks, gameweekComparison)
+	}
+	return comparison
+}
+
+//percentage is the share of sampled participants owning a player, rounded to 2 decimals
+func percentage(owners, sampled int) float64 {
+	if sampled == 0 {
+		return 0
+	}
+	return roundPercentage(float64(owners) * 100 / float64(sampled))
+}
+
+func roundPercentage(percent float64) float64 {
+	return math.Round(percent*100) / 100
+}
@@ -0,0 +1,87 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/go-fantasy/fpl/mock"
+	"github.com/go-fantasy/fpl/server"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//picksOf returns the picks of a participant owning players
+func picksOf(players ...int64) *server.ParticipantTeamInfo {
+	teamInfo := &server.ParticipantTeamInfo{}
+	for _, player := range players {
+		teamInfo.TeamPlayers = append(teamInfo.TeamPlayers, server.TeamPlayers{Element: player})
+	}
+	return teamInfo
+}
+
+func TestCompareLeagues(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testObj := mock_server.NewMockScraper(mockCtrl)
+
+	//Participant 2 is in both scraped leagues, so its picks should only be fetched once
+	testObj.EXPECT().GetPlayerMapping(gomock.Any()).Return(map[int64]string{267: "Messi", 301: "Salah"}, nil).Times(1)
+	testObj.EXPECT().GetParticipantsInLeague(gomock.Any(), 100).Return(&[]int64{1, 2}, nil).Times(1)
+	testObj.EXPECT().GetParticipantsInLeague(gomock.Any(), 200).Return(&[]int64{2, 3, 4, 5}, nil).Times(1)
+	testObj.EXPECT().GetPicksForParticipants(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, gameweek int, participants *[]int64) (map[int64]*server.ParticipantTeamInfo, error) {
+			assert.ElementsMatch(t, []int64{1, 2, 3, 4, 5}, *participants)
+			if gameweek != 1 {
+				return nil, errors.New("gameweek hasn't been played yet")
+			}
+			return map[int64]*server.ParticipantTeamInfo{
+				1: picksOf(267, 301),
+				2: picksOf(301),
+				3: picksOf(267),
+				4: picksOf(267),
+				5: picksOf(301),
+			}, nil
+		}).Times(server.GameweekMax)
+
+	store := server.NewMemoryStore()
+	store.Set(&server.LeagueData{
+		LeagueCode:       313,
+		SampleSize:       10,
+		Participants:     []int64{6, 7, 8, 9, 10, 11, 12, 13, 14, 15},
+		PlayerOccurances: map[int]map[string]int{1: {"Salah": 9, "Kane": 1}},
+		FetchedAt:        time.Now(),
+	})
+	myFPLServer := &server.MyFPLServer{Scraper: testObj, Store: store, WatchedLeagues: []int{313}}
+
+	comparison, err := myFPLServer.CompareLeagues(context.Background(), &grpc_fpl.CompareReq{LeagueCodes: []int64{313, 100, 200}})
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, []int64{313, 100, 200}, comparison.LeagueCodes)
+	assert.Equal(t, []int64{10, 2, 4}, comparison.SampleSizes)
+	require.Len(t, comparison.Gameweeks, 1)
+	assert.Equal(t, int64(1), comparison.Gameweeks[0].Gameweek)
+	assert.Equal(t, []*grpc_fpl.PlayerComparison{
+		{Player: "Salah", Owners: []int64{9, 2, 2}, Percentages: []float64{90, 100, 50}, Deltas: []float64{0, 10, -40}},
+		{Player: "Messi", Owners: []int64{0, 1, 2}, Percentages: []float64{0, 50, 50}, Deltas: []float64{0, 50, 50}},
+		{Player: "Kane", Owners: []int64{1, 0, 0}, Percentages: []float64{10, 0, 0}, Deltas: []float64{0, -10, -10}},
+	}, comparison.Gameweeks[0].Players)
+
+	_, ok := store.Get(200, 10)
+	assert.True(t, ok, "Leagues scraped in every gameweek should be stored")
+	myFPLServer.CacheTTL = time.Hour
+	_, err = myFPLServer.CompareLeagues(context.Background(), &grpc_fpl.CompareReq{LeagueCodes: []int64{200, 100}})
+	assert.Nil(t, err, "Stored leagues shouldn't be scraped again")
+}
+
+func TestCompareLeaguesInvalid(t *testing.T) {
+	myFPLServer := &server.MyFPLServer{}
+	for _, leagueCodes := range [][]int64{nil, {313}, {313, 313}, {313, -1}, {1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11}} {
+		_, err := myFPLServer.CompareLeagues(context.Background(), &grpc_fpl.CompareReq{LeagueCodes: leagueCodes})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "Leagues %v should be invalid", leagueCodes)
+	}
+}
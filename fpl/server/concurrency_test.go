@@ -0,0 +1,171 @@
+package server_test
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net"
+	"os"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/go-fantasy/fpl/mock"
+	"github.com/go-fantasy/fpl/server"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/suite"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const (
+	concurrentLeagues    = 4
+	concurrentIterations = 5
+)
+
+//ConcurrencyTest calls every RPC for several leagues at the same time through an in-process gRPC connection,
+//and checks that no response contains data of another league. Run it with -race
+type ConcurrencyTest struct {
+	suite.Suite
+	lis        *bufconn.Listener
+	grpcServer *grpc.Server
+	conn       *grpc.ClientConn
+	client     grpc_fpl.FPLClient
+}
+
+func TestConcurrency(t *testing.T) {
+	suite.Run(t, new(ConcurrencyTest))
+}
+
+//dialBufconn connects a client to a server listening in memory
+func dialBufconn(lis *bufconn.Listener) (*grpc.ClientConn, error) {
+	return grpc.Dial("bufnet", grpc.WithInsecure(), grpc.WithDialer(func(string, time.Duration) (net.Conn, error) {
+		return lis.Dial()
+	}))
+}
+
+//leaguePlayer is the only player owned in a league, so that responses mixing leagues are easy to spot
+func leaguePlayer(leagueCode int) string {
+	return fmt.Sprintf("Player of league %v", leagueCode)
+}
+
+func (suite *ConcurrencyTest) SetupSuite() {
+	mockCtrl := gomock.NewController(suite.T())
+	testObj := mock_server.NewMockScraper(mockCtrl)
+
+	testObj.EXPECT().GetPlayerMapping(gomock.Any()).Return(map[int64]string{267: "Messi", 247: "Ronaldo"}, nil).AnyTimes()
+	//League n has participants n*10+1 to n*10+n, so every league has a different size
+	testObj.EXPECT().GetParticipantsInLeague(gomock.Any(), gomock.Any()).DoAndReturn(func(ctx context.Context, leagueCode int) (*[]int64, error) {
+		var participants []int64
+		for i := 1; i <= leagueCode; i++ {
+			participants = append(participants, int64(leagueCode*10+i))
+		}
+		return &participants, nil
+	}).AnyTimes()
+	testObj.EXPECT().GetTeamInfoForParticipant(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, playerMap map[int64]string, gameweek int, participants *[]int64) (map[string]int, error) {
+			if gameweek > 3 {
+				return nil, fmt.Errorf("gameweek %v hasn't been played yet", gameweek)
+			}
+			leagueCode := int((*participants)[0] / 10)
+			time.Sleep(time.Millisecond)
+			return map[string]int{leaguePlayer(leagueCode): len(*participants)}, nil
+		}).AnyTimes()
+
+	myFPLServer := &server.MyFPLServer{
+		Scraper: testObj,
+		Store:   server.NewMemoryStore(),
+	}
+
+	suite.lis = bufconn.Listen(1024 * 1024)
+	suite.grpcServer = grpc.NewServer()
+	grpc_fpl.RegisterFPLServer(suite.grpcServer, myFPLServer)
+	go suite.grpcServer.Serve(suite.lis)
+
+	conn, err := dialBufconn(suite.lis)
+	suite.Require().Nil(err)
+	suite.conn = conn
+	suite.client = grpc_fpl.NewFPLClient(conn)
+}
+
+func (suite *ConcurrencyTest) TearDownSuite() {
+	suite.conn.Close()
+	suite.grpcServer.Stop()
+}
+
+func (suite *ConcurrencyTest) TestAllRPCsConcurrently() {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*30)
+	defer cancel()
+
+	var wg sync.WaitGroup
+	run := func(rpc func(leagueCode int)) {
+		for leagueCode := 1; leagueCode <= concurrentLeagues; leagueCode++ {
+			wg.Add(1)
+			go func(leagueCode int) {
+				defer wg.Done()
+				for i := 0; i < concurrentIterations; i++ {
+					rpc(leagueCode)
+				}
+			}(leagueCode)
+		}
+	}
+
+	run(func(int) {
+		numPlayers, err := suite.client.GetNumberOfPlayers(ctx, &grpc_fpl.NumPlayerRequest{})
+		if suite.Nil(err) {
+			suite.Equal(int64(2), numPlayers.NumPlayers)
+		}
+	})
+
+	run(func(leagueCode int) {
+		numParticipants, err := suite.client.GetParticipantsInLeague(ctx, &grpc_fpl.LeagueCode{LeagueCode: int64(leagueCode)})
+		if suite.Nil(err) {
+			suite.Equal(int64(leagueCode), numParticipants.NumParticipants)
+		}
+	})
+
+	run(func(leagueCode int) {
+		for gameweek := 1; gameweek <= 3; gameweek++ {
+			playerOccurance, err := suite.client.GetDataForGameweek(ctx, &grpc_fpl.GameweekReq{LeagueCode: int64(leagueCode), Gameweek: int64(gameweek)})
+			if suite.Nil(err) {
+				suite.Equal(map[string]int32{leaguePlayer(leagueCode): int32(leagueCode)}, playerOccurance.PlayerOccurance)
+			}
+		}
+	})
+
+	run(func(leagueCode int) {
+		stream, err := suite.client.GetDataForAllGameweeks(ctx, &grpc_fpl.AllGameweeksReq{LeagueCode: int64(leagueCode)})
+		if !suite.Nil(err) {
+			return
+		}
+		var csv []byte
+		for {
+			data, err := stream.Recv()
+			if err == io.EOF {
+				break
+			}
+			if !suite.Nil(err) {
+				return
+			}
+			csv = append(csv, data.Data...)
+		}
+
+		lines := strings.Split(strings.TrimSpace(string(csv)), "\n")
+		if suite.Equal(5, len(lines), "Unexpected CSV %v", string(csv)) {
+			suite.Equal(fmt.Sprintf("# League: %v", leagueCode), lines[0])
+			suite.Equal([]string{
+				"Player,Gameweek 1,Gameweek 2,Gameweek 3,Total,Average,Peak gameweek",
+				fmt.Sprintf("%v,%v,%v,%v,%v,%v.00,1", leaguePlayer(leagueCode), leagueCode, leagueCode, leagueCode, 3*leagueCode, leagueCode),
+			}, lines[3:])
+		}
+	})
+
+	wg.Wait()
+
+	for leagueCode := 1; leagueCode <= concurrentLeagues; leagueCode++ {
+		_, err := os.Stat(fmt.Sprintf("temp-%v-%v.csv", time.Now().Format("2006-01-02"), leagueCode))
+		suite.True(os.IsNotExist(err), "no temp file should be written for league %v", leagueCode)
+	}
+}
@@ -0,0 +1,344 @@
+package server
+
+import (
+	"context"
+	"embed"
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+//dashboardAssets are the static files of the dashboard, bundled into the binary
+//
+//go:embed dashboard
+var dashboardAssets embed.FS
+
+const (
+	//dashboardMethod is the method dashboard calls are authenticated and logged as
+	dashboardMethod = fplMethodPrefix + "Dashboard"
+	//defaultTemplateSize is the number of players in a template team, a full squad
+	defaultTemplateSize = 15
+)
+
+//dashboardHandler serves a JSON API or an image for the dashboard, an error with a gRPC status setting the
+//HTTP status of the response
+type dashboardHandler func(http.ResponseWriter, *http.Request) error
+
+//DashboardHandler serves the web dashboard at / and the API it calls at /api/
+func (s *MyFPLServer) DashboardHandler() http.Handler {
+	assets, err := fs.Sub(dashboardAssets, "dashboard")
+	if err != nil {
+		panic(err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/", http.FileServer(http.FS(assets)))
+	mux.Handle("/api/ownership", s.dashboardAPI(s.serveOwnership))
+	mux.Handle("/api/heatmap.svg", s.dashboardAPI(s.serveHeatmap))
+	mux.Handle("/api/template", s.dashboardAPI(s.serveTemplate))
+	mux.Handle("/api/captains", s.dashboardAPI(s.serveCaptains))
+	mux.Handle("/api/standings", s.dashboardAPI(s.serveStandings))
+	mux.Handle("/api/player", s.dashboardAPI(s.servePlayer))
+	return mux
+}
+
+//dashboardAPI authenticates calls with the API key in the x-api-key header, like the gRPC calls, and logs them.
+//Keys aren't taken from the URL, which ends up in browser history, proxy logs and Referer headers
+func (s *MyFPLServer) dashboardAPI(handler dashboardHandler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := time.Now()
+		ctx := withLogger(r.Context(), s.logger(r.Context()).WithField("path", r.URL.Path))
+		key := r.Header.Get(APIKeyHeader)
+		ctx, err := s.authenticate(metadata.NewIncomingContext(ctx, metadata.Pairs(APIKeyHeader, key)), dashboardMethod)
+		if err == nil {
+			err = handler(w, r.WithContext(ctx))
+		}
+
+		log := s.logger(ctx).WithField("duration", time.Since(start).String())
+		if err != nil {
+			log.WithError(err).Warn("dashboard call failed")
+			http.Error(w, status.Convert(err).Message(), httpStatus(status.Code(err)))
+			return
+		}
+		log.Debug("dashboard call")
+	})
+}
+
+//httpStatus is the HTTP status of the gRPC code of an error
+func httpStatus(code codes.Code) int {
+	switch code {
+	case codes.InvalidArgument, codes.OutOfRange:
+		return http.StatusBadRequest
+	case codes.Unauthenticated:
+		return http.StatusUnauthorized
+	case codes.PermissionDenied:
+		return http.StatusForbidden
+	case codes.NotFound:
+		return http.StatusNotFound
+	case codes.ResourceExhausted:
+		return http.StatusTooManyRequests
+	case codes.Unavailable:
+		return http.StatusServiceUnavailable
+	case codes.Canceled, codes.DeadlineExceeded:
+		return http.StatusGatewayTimeout
+	}
+	return http.StatusInternalServerError
+}
+
+//intParam reads an integer query parameter, def when it is missing
+func intParam(r *http.Request, name string, def int) (int, error) {
+	value := r.URL.Query().Get(name)
+	if value == "" {
+		return def, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "%v should be a positive number, not %q", name, value)
+	}
+	return n, nil
+}
+
+//dashboardLeagueData returns the data of the league and sample size, or of the cohort, asked for, like
+//GetDataForAllGameweeks
+func (s *MyFPLServer) dashboardLeagueData(r *http.Request) (*LeagueData, error) {
+	leagueCode, cohort, err := groupParams(r)
+	if err != nil {
+		return nil, err
+	}
+	sampleSize, err := intParam(r, "sample", 0)
+	if err != nil {
+		return nil, err
+	}
+	ctx := withLogger(r.Context(), s.logger(r.Context()).WithFields(logrus.Fields{"league": leagueCode, "cohort": cohort}))
+	return s.groupData(ctx, leagueCode, cohort, sampleSizeOrDefault(sampleSize), nil)
+}
+
+//groupParams reads the league or the cohort the dashboard looks at, one of them being required
+func groupParams(r *http.Request) (int, string, error) {
+	leagueCode, err := intParam(r, "league", 0)
+	if err != nil {
+		return 0, "", err
+	}
+	cohort := r.URL.Query().Get("cohort")
+	if leagueCode == 0 && cohort == "" {
+		return 0, "", status.Errorf(codes.InvalidArgument, "a league or a cohort is required")
+	}
+	return leagueCode, cohort, checkLeagueOrCohort(int64(leagueCode), cohort)
+}
+
+//dashboardGameweek reads the gameweek parameter, the latest gameweek of leagueData when it is missing
+func dashboardGameweek(r *http.Request, leagueData *LeagueData) (int, error) {
+	latest := 0
+	if leagueData != nil {
+		for gameweek := range leagueData.PlayerOccurances {
+			if gameweek > latest {
+				latest = gameweek
+			}
+		}
+	}
+	gameweek, err := intParam(r, "gameweek", latest)
+	if err != nil {
+		return 0, err
+	}
+	if gameweek < 1 || gameweek > GameweekMax {
+		return 0, status.Errorf(codes.InvalidArgument, "gameweek %v is not between 1 and %v", gameweek, GameweekMax)
+	}
+	return gameweek, nil
+}
+
+func writeJSON(w http.ResponseWriter, value interface{}) error {
+	w.Header().Set("Content-Type", "application/json")
+	return json.NewEncoder(w).Encode(value)
+}
+
+//playerCount is how many managers own or captain a player
+type playerCount struct {
+	Player string `json:"player"`
+	Count  int    `json:"count"`
+}
+
+//mostCounted returns the top players with the highest counts, every player when top is 0
+func mostCounted(counts map[string]int, top int) []playerCount {
+	players := make([]playerCount, 0, len(counts))
+	for player, count := range counts {
+		if count > 0 {
+			players = append(players, playerCount{Player: player, Count: count})
+		}
+	}
+	sort.Slice(players, func(i, j int) bool {
+		if players[i].Count != players[j].Count {
+			return players[i].Count > players[j].Count
+		}
+		return players[i].Player < players[j].Player
+	})
+	if top > 0 && len(players) > top {
+		players = players[:top]
+	}
+	return players
+}
+
+//serveOwnership serves the ownership of every player in every gameweek, as the JSON export
+func (s *MyFPLServer) serveOwnership(w http.ResponseWriter, r *http.Request) error {
+	leagueData, err := s.dashboardLeagueData(r)
+	if err != nil {
+		return err
+	}
+	w.Header().Set("Content-Type", JSONExporter{}.ContentType())
+	return JSONExporter{}.Export(w, leagueTable(leagueData))
+}
+
+//serveHeatmap serves the heatmap of the league as an SVG
+func (s *MyFPLServer) serveHeatmap(w http.ResponseWriter, r *http.Request) error {
+	top, err := intParam(r, "top", 0)
+	if err != nil {
+		return err
+	}
+	leagueData, err := s.dashboardLeagueData(r)
+	if err != nil {
+		return err
+	}
+	exporter := HeatmapExporter{Format: grpc_fpl.ImageFormat_SVG, Top: top}
+	w.Header().Set("Content-Type", exporter.ContentType())
+	return exporter.Export(w, leagueTable(leagueData))
+}
+
+//serveTemplate serves the template team of a gameweek, its most owned players
+func (s *MyFPLServer) serveTemplate(w http.ResponseWriter, r *http.Request) error {
+	size, err := intParam(r, "top", defaultTemplateSize)
+	if err != nil {
+		return err
+	}
+	leagueData, err := s.dashboardLeagueData(r)
+	if err != nil {
+		return err
+	}
+	gameweek, err := dashboardGameweek(r, leagueData)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, map[string]interface{}{
+		"gameweek": gameweek,
+		"players":  mostCounted(leagueData.PlayerOccurances[gameweek], size),
+	})
+}
+
+//serveCaptains serves how many of the sampled managers captained each player in a gameweek
+func (s *MyFPLServer) serveCaptains(w http.ResponseWriter, r *http.Request) error {
+	leagueCode, cohort, sampleSize, gameweek, err := picksParams(r)
+	if err != nil {
+		return err
+	}
+	playerMap, picks, err := s.groupPicks(r.Context(), leagueCode, cohort, gameweek, sampleSize)
+	if err != nil {
+		return err
+	}
+	return writeJSON(w, map[string]interface{}{
+		"gameweek": gameweek,
+		"captains": mostCounted(captaincy(playerMap, picks), 0),
+	})
+}
+
+//standing is a manager in the live standings of the dashboard
+type standing struct {
+	Entry        int64  `json:"entry"`
+	LivePoints   int64  `json:"livePoints"`
+	TotalPoints  int64  `json:"totalPoints"`
+	LiveRank     int64  `json:"liveRank"`
+	PreviousRank int64  `json:"previousRank"`
+	Captain      string `json:"captain"`
+}
+
+//serveStandings serves the live standings of the sampled managers in a gameweek, as GetLivePoints sends them
+func (s *MyFPLServer) serveStandings(w http.ResponseWriter, r *http.Request) error {
+	leagueCode, cohort, sampleSize, gameweek, err := picksParams(r)
+	if err != nil {
+		return err
+	}
+	playerMap, picks, err := s.groupPicks(r.Context(), leagueCode, cohort, gameweek, sampleSize)
+	if err != nil {
+		return err
+	}
+	livePoints, err := s.Scraper.GetLivePoints(r.Context(), gameweek)
+	if err != nil {
+		return status.Errorf(codes.Internal, "error while fetching live points for gameweek %v : %v", gameweek, err)
+	}
+
+	standings := []standing{}
+	for _, manager := range calculateLiveStandings(playerMap, picks, livePoints) {
+		standings = append(standings, standing{
+			Entry:        manager.Entry,
+			LivePoints:   manager.LivePoints,
+			TotalPoints:  manager.TotalPoints,
+			LiveRank:     manager.LiveRank,
+			PreviousRank: manager.PreviousRank,
+			Captain:      manager.Captain,
+		})
+	}
+	return writeJSON(w, map[string]interface{}{
+		"gameweek": gameweek,
+		"managers": standings,
+	})
+}
+
+//picksParams reads the league or cohort, sample size and gameweek of the calls needing the picks of managers
+func picksParams(r *http.Request) (leagueCode int, cohort string, sampleSize, gameweek int, err error) {
+	if leagueCode, cohort, err = groupParams(r); err != nil {
+		return
+	}
+	if sampleSize, err = intParam(r, "sample", 0); err != nil {
+		return
+	}
+	gameweek, err = dashboardGameweek(r, nil)
+	return
+}
+
+//servePlayer serves the ownership of a player in every gameweek
+func (s *MyFPLServer) servePlayer(w http.ResponseWriter, r *http.Request) error {
+	player := r.URL.Query().Get("player")
+	if player == "" {
+		return status.Errorf(codes.InvalidArgument, "a player is required")
+	}
+	leagueData, err := s.dashboardLeagueData(r)
+	if err != nil {
+		return err
+	}
+	table := leagueTable(leagueData)
+	for _, row := range table.Rows {
+		if row.Player == player {
+			return writeJSON(w, map[string]interface{}{
+				"player":    player,
+				"gameweeks": table.Gameweeks,
+				"owners":    row.Owners,
+			})
+		}
+	}
+	return status.Errorf(codes.NotFound, "%v isn't owned in %v", player, leagueData.name())
+}
+
+//startDashboardServer serves the dashboard on DashboardPort in the background
+func (s *MyFPLServer) startDashboardServer() (*http.Server, error) {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%v", s.DashboardPort))
+	if err != nil {
+		return nil, errors.Errorf("error while starting dashboard server : %v", err)
+	}
+	dashboardServer := &http.Server{Handler: s.DashboardHandler()}
+	go func() {
+		log := s.logger(context.Background()).WithField("address", lis.Addr().String())
+		log.Info("serving dashboard")
+		if err := dashboardServer.Serve(lis); err != nil && err != http.ErrServerClosed {
+			log.WithError(err).Error("error while serving dashboard")
+		}
+	}()
+	return dashboardServer, nil
+}
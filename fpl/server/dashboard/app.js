@@ -0,0 +1,152 @@
+"use strict";
+
+const form = document.getElementById("query");
+const statusLine = document.getElementById("status");
+const gameweekSelect = document.getElementById("gameweek");
+const playerSelect = document.getElementById("player");
+let query = {};
+let ownership = null;
+let heatmapURL = null;
+
+//request calls the dashboard API with the league or cohort and the sample size of the form, sending the API key in
+//a header so that it never ends up in a URL
+async function request(path, params) {
+  const search = new URLSearchParams(params);
+  for (const name of ["league", "cohort", "sample"]) {
+    if (query[name]) {
+      search.set(name, query[name]);
+    }
+  }
+  const headers = query.key ? { "x-api-key": query.key } : {};
+  const response = await fetch(`api/${path}?${search}`, { headers });
+  if (!response.ok) {
+    throw new Error(`${response.status} ${(await response.text()).trim()}`);
+  }
+  return response;
+}
+
+async function api(path, params) {
+  return (await request(path, params)).json();
+}
+
+function setStatus(message) {
+  statusLine.textContent = message;
+}
+
+function fillRows(table, rows) {
+  const body = table.querySelector("tbody");
+  body.replaceChildren(...rows.map((cells) => {
+    const row = document.createElement("tr");
+    for (const cell of cells) {
+      const td = document.createElement("td");
+      td.textContent = cell;
+      row.appendChild(td);
+    }
+    return row;
+  }));
+}
+
+function fillOptions(select, values, selected) {
+  select.replaceChildren(...values.map((value) => new Option(value, value, false, value === selected)));
+}
+
+async function loadLeague() {
+  setStatus("Loading the league, scraping every gameweek can take a while…");
+  ownership = await api("ownership");
+  document.querySelector("main").hidden = false;
+
+  //The heatmap is fetched like the rest of the API, and shown through a blob URL
+  const heatmap = await (await request("heatmap.svg", { top: 30 })).blob();
+  if (heatmapURL) {
+    URL.revokeObjectURL(heatmapURL);
+  }
+  heatmapURL = URL.createObjectURL(heatmap);
+  document.getElementById("heatmap").src = heatmapURL;
+
+  const latest = ownership.gameweeks[ownership.gameweeks.length - 1];
+  fillOptions(gameweekSelect, ownership.gameweeks, latest);
+  fillOptions(playerSelect, ownership.players.map((player) => player.player).sort());
+  drawTimeline();
+  const group = ownership.cohort ? `Cohort ${ownership.cohort}` : `League ${ownership.leagueCode}`;
+  setStatus(`${group}, ${ownership.players.length} players owned`);
+  if (latest) {
+    await loadGameweek(latest);
+  }
+}
+
+async function loadGameweek(gameweek) {
+  const template = document.getElementById("template");
+  const results = await Promise.allSettled([
+    api("template", { gameweek }),
+    api("captains", { gameweek }),
+    api("standings", { gameweek }),
+  ]);
+
+  if (results[0].status === "fulfilled") {
+    template.replaceChildren(...results[0].value.players.map((player) => {
+      const item = document.createElement("li");
+      item.textContent = `${player.player} (${player.count})`;
+      return item;
+    }));
+  }
+  if (results[1].status === "fulfilled") {
+    fillRows(document.getElementById("captains"), results[1].value.captains.map((c) => [c.player, c.count]));
+  }
+  if (results[2].status === "fulfilled") {
+    fillRows(document.getElementById("standings"), results[2].value.managers.map((m) =>
+      [m.liveRank, m.entry, m.livePoints, m.totalPoints, m.captain]));
+  }
+  const failed = results.filter((result) => result.status === "rejected");
+  if (failed.length > 0) {
+    setStatus(`Gameweek ${gameweek}: ${failed.map((result) => result.reason.message).join(", ")}`);
+  }
+}
+
+//drawTimeline draws the ownership of the selected player over every gameweek, out of the sample size
+function drawTimeline() {
+  const svg = document.getElementById("timeline");
+  const player = ownership.players.find((p) => p.player === playerSelect.value);
+  if (!player) {
+    svg.replaceChildren();
+    return;
+  }
+  const width = 600;
+  const height = 160;
+  const margin = 20;
+  const max = Math.max(ownership.sampleSize || 0, ...player.owners, 1);
+  const x = (i) => margin + (i * (width - 2 * margin)) / Math.max(ownership.gameweeks.length - 1, 1);
+  const y = (owners) => height - margin - (owners * (height - 2 * margin)) / max;
+
+  const ns = "http://www.w3.org/2000/svg";
+  const element = (name, attributes, text) => {
+    const e = document.createElementNS(ns, name);
+    for (const [key, value] of Object.entries(attributes)) {
+      e.setAttribute(key, value);
+    }
+    if (text !== undefined) {
+      e.textContent = text;
+    }
+    return e;
+  };
+  svg.setAttribute("viewBox", `0 0 ${width} ${height}`);
+  svg.replaceChildren(
+    element("polyline", { points: player.owners.map((owners, i) => `${x(i)},${y(owners)}`).join(" ") }),
+    ...ownership.gameweeks.map((gameweek, i) => element("text", { x: x(i), y: height - 4, "text-anchor": "middle" }, gameweek)),
+    ...player.owners.map((owners, i) => element("text", { x: x(i), y: y(owners) - 6, "text-anchor": "middle" }, owners)),
+  );
+}
+
+form.addEventListener("submit", (event) => {
+  event.preventDefault();
+  const data = new FormData(form);
+  query = { league: data.get("league"), cohort: data.get("cohort").trim(), sample: data.get("sample"), key: data.get("key") };
+  if (!query.league === !query.cohort) {
+    setStatus("Pick either a league or a cohort");
+    return;
+  }
+  loadLeague().catch((err) => setStatus(err.message));
+});
+gameweekSelect.addEventListener("change", () => {
+  loadGameweek(gameweekSelect.value).catch((err) => setStatus(err.message));
+});
+playerSelect.addEventListener("change", drawTimeline);
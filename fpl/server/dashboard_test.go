@@ -0,0 +1,139 @@
+package server_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/go-fantasy/fpl/mock"
+	"github.com/go-fantasy/fpl/server"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//get calls the dashboard with key when it isn't empty, returning the status and body of the response
+func get(t *testing.T, dashboard *httptest.Server, path, key string) (int, string) {
+	req, err := http.NewRequest(http.MethodGet, dashboard.URL+path, nil)
+	require.Nil(t, err)
+	if key != "" {
+		req.Header.Set(server.APIKeyHeader, key)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	require.Nil(t, err)
+	defer resp.Body.Close()
+	body, err := ioutil.ReadAll(resp.Body)
+	require.Nil(t, err)
+	return resp.StatusCode, string(body)
+}
+
+func newDashboard(t *testing.T, testObj server.Scraper, keyStore server.KeyStore) *httptest.Server {
+	store := server.NewMemoryStore()
+	store.Set(&server.LeagueData{
+		LeagueCode:       313,
+		SampleSize:       10,
+		PlayerOccurances: map[int]map[string]int{1: {"Salah": 10, "Messi": 2}, 2: {"Salah": 9, "Messi": 3, "Kane": 3}},
+		FetchedAt:        time.Now(),
+	})
+	myFPLServer := &server.MyFPLServer{
+		Scraper:        testObj,
+		Store:          store,
+		WatchedLeagues: []int{313},
+		KeyStore:       keyStore,
+	}
+	return httptest.NewServer(myFPLServer.DashboardHandler())
+}
+
+func TestDashboard(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testObj := mock_server.NewMockScraper(mockCtrl)
+	dashboard := newDashboard(t, testObj, nil)
+	defer dashboard.Close()
+
+	code, body := get(t, dashboard, "/", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Contains(t, body, "<title>Go-fantasy</title>")
+	code, _ = get(t, dashboard, "/app.js", "")
+	assert.Equal(t, http.StatusOK, code)
+
+	code, body = get(t, dashboard, "/api/ownership?league=313", "")
+	assert.Equal(t, http.StatusOK, code, body)
+	assert.Contains(t, body, `"gameweeks":[1,2]`)
+
+	code, body = get(t, dashboard, "/api/heatmap.svg?league=313&top=1", "")
+	assert.Equal(t, http.StatusOK, code, body)
+	assert.Contains(t, body, "Salah")
+	assert.NotContains(t, body, "Messi")
+
+	code, body = get(t, dashboard, "/api/template?league=313&top=2", "")
+	assert.Equal(t, http.StatusOK, code, body)
+	assert.JSONEq(t, `{"gameweek": 2, "players": [{"player": "Salah", "count": 9}, {"player": "Kane", "count": 3}]}`, body)
+
+	code, body = get(t, dashboard, "/api/player?league=313&player=Messi", "")
+	assert.Equal(t, http.StatusOK, code, body)
+	assert.JSONEq(t, `{"player": "Messi", "gameweeks": [1, 2], "owners": [2, 3]}`, body)
+	code, _ = get(t, dashboard, "/api/player?league=313&player=Ronaldo", "")
+	assert.Equal(t, http.StatusNotFound, code)
+
+	testObj.EXPECT().GetPlayerMapping(gomock.Any()).Return(map[int64]string{267: "Messi", 247: "Salah"}, nil).Times(2)
+	testObj.EXPECT().GetParticipantsInLeague(gomock.Any(), 313).Return(&[]int64{1, 2}, nil).Times(2)
+	testObj.EXPECT().GetPicksForParticipants(gomock.Any(), 2, gomock.Any()).Return(map[int64]*server.ParticipantTeamInfo{
+		1: {
+			EntryHistory: server.EntryHistory{Points: 10, TotalPoints: 110},
+			TeamPlayers:  []server.TeamPlayers{{Element: 267, Multiplier: 2, IsCaptain: true}, {Element: 247, Multiplier: 1}},
+		},
+		2: {
+			EntryHistory: server.EntryHistory{Points: 20, TotalPoints: 105},
+			TeamPlayers:  []server.TeamPlayers{{Element: 247, Multiplier: 2, IsCaptain: true}},
+		},
+	}, nil).Times(2)
+	testObj.EXPECT().GetLivePoints(gomock.Any(), 2).Return(map[int64]int{267: 2, 247: 6}, nil).Times(1)
+
+	code, body = get(t, dashboard, "/api/captains?league=313&gameweek=2", "")
+	assert.Equal(t, http.StatusOK, code, body)
+	assert.JSONEq(t, `{"gameweek": 2, "captains": [{"player": "Messi", "count": 1}, {"player": "Salah", "count": 1}]}`, body)
+
+	code, body = get(t, dashboard, "/api/standings?league=313&gameweek=2", "")
+	assert.Equal(t, http.StatusOK, code, body)
+	var standings struct {
+		Managers []struct {
+			Entry      int64 `json:"entry"`
+			LivePoints int64 `json:"livePoints"`
+			LiveRank   int64 `json:"liveRank"`
+		} `json:"managers"`
+	}
+	require.Nil(t, json.Unmarshal([]byte(body), &standings))
+	require.Equal(t, 2, len(standings.Managers))
+	assert.Equal(t, int64(1), standings.Managers[0].Entry, "Manager 1 should stay ahead with 110 points")
+	assert.Equal(t, int64(10), standings.Managers[0].LivePoints)
+	assert.Equal(t, int64(12), standings.Managers[1].LivePoints)
+
+	code, _ = get(t, dashboard, "/api/template", "")
+	assert.Equal(t, http.StatusBadRequest, code, "A league is required")
+	code, _ = get(t, dashboard, "/api/captains?league=313&gameweek=40", "")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestDashboardAPIKeys(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	keyStore, err := server.NewKeyStore(server.APIKey{Name: "dashboard", Key: "dashboard-key"})
+	require.Nil(t, err)
+	dashboard := newDashboard(t, mock_server.NewMockScraper(mockCtrl), keyStore)
+	defer dashboard.Close()
+
+	code, _ := get(t, dashboard, "/", "")
+	assert.Equal(t, http.StatusOK, code, "The page itself doesn't need a key")
+	code, _ = get(t, dashboard, "/api/ownership?league=313", "")
+	assert.Equal(t, http.StatusUnauthorized, code)
+	code, _ = get(t, dashboard, "/api/ownership?league=313", "dashboard-key")
+	assert.Equal(t, http.StatusOK, code)
+	code, _ = get(t, dashboard, "/api/heatmap.svg?league=313", "dashboard-key")
+	assert.Equal(t, http.StatusOK, code)
+	code, _ = get(t, dashboard, "/api/heatmap.svg?league=313&key=dashboard-key", "")
+	assert.Equal(t, http.StatusUnauthorized, code, "Keys in the URL shouldn't be accepted")
+}
@@ -0,0 +1,553 @@
+package server
+
+import (
+	"archive/zip"
+	"bufio"
+	"crypto/sha256"
+	"encoding/csv"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/pkg/errors"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	//exportChunkSize is the most data sent in a single message of GetDataForAllGameweeks
+	exportChunkSize = 32 * 1024
+	//resumableExportTTL is how long exports of data that isn't kept in the store can be resumed
+	resumableExportTTL = time.Hour
+	//maxResumableExports is how many exports of data that isn't kept in the store can be resumed at once
+	maxResumableExports = 100
+)
+
+//DefaultExporters are the exporters of every format GetDataForAllGameweeks can send
+var DefaultExporters = map[grpc_fpl.ExportFormat]Exporter{
+	grpc_fpl.ExportFormat_CSV:      CSVExporter{},
+	grpc_fpl.ExportFormat_JSON:     JSONExporter{},
+	grpc_fpl.ExportFormat_NDJSON:   NDJSONExporter{},
+	grpc_fpl.ExportFormat_MARKDOWN: MarkdownExporter{},
+	grpc_fpl.ExportFormat_XLSX:     XLSXExporter{},
+	grpc_fpl.ExportFormat_PARQUET:  ParquetExporter{},
+}
+
+//exporter returns the exporter of format, from Exporters first
+func (s *MyFPLServer) exporter(format grpc_fpl.ExportFormat) (Exporter, bool) {
+	if exporter, ok := s.Exporters[format]; ok {
+		return exporter, true
+	}
+	exporter, ok := DefaultExporters[format]
+	return exporter, ok
+}
+
+//newOwnershipTable has a row for every player owned in any of the gameweeks of playerOccurances, the most owned
+//in total first
+func newOwnershipTable(leagueCode int, playerOccurances map[int]map[string]int) *OwnershipTable {
+	table := &OwnershipTable{LeagueCode: leagueCode}
+	players := make(map[string]bool)
+	for gameweekNum, playerOccurance := range playerOccurances {
+		table.Gameweeks = append(table.Gameweeks, gameweekNum)
+		for player, occurance := range playerOccurance {
+			if occurance > 0 {
+				players[player] = true
+			}
+		}
+	}
+	sort.Ints(table.Gameweeks)
+	for player := range players {
+		row := OwnershipRow{Player: player}
+		for _, gameweekNum := range table.Gameweeks {
+			row.Owners = append(row.Owners, playerOccurances[gameweekNum][player])
+		}
+		table.Rows = append(table.Rows, row)
+	}
+	table.sortBy(grpc_fpl.OwnershipSort_TOTAL)
+	return table
+}
+
+//sortBy sorts the rows of the table by the total, latest or peak ownership of the players, then by their total
+//ownership and name
+func (t *OwnershipTable) sortBy(by grpc_fpl.OwnershipSort) {
+	key := func(row OwnershipRow) int {
+		switch by {
+		case grpc_fpl.OwnershipSort_LATEST:
+			if len(row.Owners) == 0 {
+				return 0
+			}
+			return row.Owners[len(row.Owners)-1]
+		case grpc_fpl.OwnershipSort_PEAK:
+			return row.Peak()
+		}
+		return row.Total()
+	}
+	sort.Slice(t.Rows, func(i, j int) bool {
+		rowI, rowJ := t.Rows[i], t.Rows[j]
+		if keyI, keyJ := key(rowI), key(rowJ); keyI != keyJ {
+			return keyI > keyJ
+		}
+		if totalI, totalJ := rowI.Total(), rowJ.Total(); totalI != totalJ {
+			return totalI > totalJ
+		}
+		return rowI.Player < rowJ.Player
+	})
+}
+
+//PeakGameweek is the gameweek row was owned the most in, the earliest of them on ties, 0 when the table has
+//no gameweeks
+func (t *OwnershipTable) PeakGameweek(row OwnershipRow) int {
+	peak := -1
+	for i, owners := range row.Owners {
+		if peak < 0 || owners > row.Owners[peak] {
+			peak = i
+		}
+	}
+	if peak < 0 || peak >= len(t.Gameweeks) {
+		return 0
+	}
+	return t.Gameweeks[peak]
+}
+
+//Total is the sum of the owners of the player in every gameweek
+func (r OwnershipRow) Total() int {
+	total := 0
+	for _, owners := range r.Owners {
+		total += owners
+	}
+	return total
+}
+
+//Average is the average number of owners of the player per gameweek
+func (r OwnershipRow) Average() float64 {
+	if len(r.Owners) == 0 {
+		return 0
+	}
+	return float64(r.Total()) / float64(len(r.Owners))
+}
+
+//roundedAverage is the average of the player rounded to 2 decimals, as CSV exports write it
+func (r OwnershipRow) roundedAverage() float64 {
+	return math.Round(r.Average()*100) / 100
+}
+
+//Peak is the most owners the player had in a gameweek
+func (r OwnershipRow) Peak() int {
+	peak := 0
+	for _, owners := range r.Owners {
+		if owners > peak {
+			peak = owners
+		}
+	}
+	return peak
+}
+
+//leagueTable is the ownership table of the data scraped for a league or a cohort
+func leagueTable(leagueData *LeagueData) *OwnershipTable {
+	table := newOwnershipTable(leagueData.LeagueCode, leagueData.PlayerOccurances)
+	table.Cohort = leagueData.Cohort
+	table.SampleSize = leagueData.SampleSize
+	table.FetchedAt = leagueData.FetchedAt
+	return table
+}
+
+//streamWriter sends what is written to it in messages of at most exportChunkSize bytes, with the offset of their
+//data in the export. The first skip bytes, which a resuming client already has, are dropped
+type streamWriter struct {
+	stream grpc_fpl.FPL_GetDataForAllGameweeksServer
+	token  string
+	offset int64
+	skip   int64
+}
+
+func (w *streamWriter) Write(p []byte) (int, error) {
+	written := 0
+	if w.skip > 0 {
+		skipped := len(p)
+		if int64(skipped) > w.skip {
+			skipped = int(w.skip)
+		}
+		w.skip -= int64(skipped)
+		w.offset += int64(skipped)
+		written = skipped
+	}
+	for written < len(p) {
+		n := len(p) - written
+		if n > exportChunkSize {
+			n = exportChunkSize
+		}
+		//Send marshals the message before returning, so p can be reused by the caller
+		err := w.stream.Send(&grpc_fpl.AllGameweekData{Data: p[written : written+n], Offset: w.offset, ResumeToken: w.token})
+		if err != nil {
+			return written, err
+		}
+		written += n
+		w.offset += int64(n)
+	}
+	return written, nil
+}
+
+//exportToken identifies an export of the data of a league, so that a client resumes the same export it started
+func exportToken(req *grpc_fpl.AllGameweeksReq, gameweeks []int, leagueData *LeagueData) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%v:%v:%v:%v:%v:%v:%v",
+		leagueData.LeagueCode, leagueData.Cohort, leagueData.SampleSize, req.Format, req.Sort, gameweeks, leagueData.FetchedAt.UnixNano())))
+	return hex.EncodeToString(sum[:16])
+}
+
+//resumableExports keeps the data of exports that aren't in the store, cohorts and scrapes of some gameweeks, by resume
+//token, so that resuming them sends the rest of the same data instead of scraping it again
+type resumableExports struct {
+	mu      sync.Mutex
+	exports map[string]*resumableExport
+}
+
+type resumableExport struct {
+	leagueData *LeagueData
+	expiresAt  time.Time
+}
+
+func newResumableExports() *resumableExports {
+	return &resumableExports{exports: make(map[string]*resumableExport)}
+}
+
+//resumableExports returns the exports of the server that can be resumed, creating them on first use
+func (s *MyFPLServer) resumableExports() *resumableExports {
+	s.exportsOnce.Do(func() {
+		s.exports = newResumableExports()
+	})
+	return s.exports
+}
+
+//get returns the data of the export with token, unless it expired
+func (r *resumableExports) get(token string, now time.Time) (*LeagueData, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	export, ok := r.exports[token]
+	if !ok || now.After(export.expiresAt) {
+		return nil, false
+	}
+	return export.leagueData, true
+}
+
+//remember keeps the data of the export with token for resumableExportTTL. Expired exports are dropped first, then
+//the ones expiring soonest when there are still maxResumableExports of them
+func (r *resumableExports) remember(token string, leagueData *LeagueData, now time.Time) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for token, export := range r.exports {
+		if now.After(export.expiresAt) {
+			delete(r.exports, token)
+		}
+	}
+	for len(r.exports) >= maxResumableExports {
+		var soonest string
+		for token, export := range r.exports {
+			if soonest == "" || export.expiresAt.Before(r.exports[soonest].expiresAt) {
+				soonest = token
+			}
+		}
+		delete(r.exports, soonest)
+	}
+	r.exports[token] = &resumableExport{leagueData: leagueData, expiresAt: now.Add(resumableExportTTL)}
+}
+
+//export writes table with exporter straight into stream, buffering writes into full chunks and starting at offset.
+//The last message has no data but the SHA-256 digest of the whole export, for the client to check what it got
+func export(exporter Exporter, table *OwnershipTable, stream grpc_fpl.FPL_GetDataForAllGameweeksServer, offset int64, token string) error {
+	writer := &streamWriter{stream: stream, token: token, skip: offset}
+	buffered := bufio.NewWriterSize(writer, exportChunkSize)
+	digest := sha256.New()
+	if err := exporter.Export(io.MultiWriter(buffered, digest), table); err != nil {
+		return err
+	}
+	if err := buffered.Flush(); err != nil {
+		return err
+	}
+	if writer.skip > 0 {
+		return status.Errorf(codes.OutOfRange, "offset %v is past the end of the export, which is %v bytes", offset, writer.offset)
+	}
+	return stream.Send(&grpc_fpl.AllGameweekData{
+		Offset:      writer.offset,
+		ResumeToken: token,
+		Digest:      hex.EncodeToString(digest.Sum(nil)),
+	})
+}
+
+func gameweekHeader(gameweek int) string {
+	return fmt.Sprintf("Gameweek %v", gameweek)
+}
+
+//CSVExporter writes the league or cohort, sample size and fetch time as # comment lines, a header with a column for each
+//gameweek and the total, average and peak gameweek of the players, then a line for every player
+type CSVExporter struct{}
+
+func (CSVExporter) ContentType() string {
+	return "text/csv"
+}
+
+func (CSVExporter) Export(w io.Writer, table *OwnershipTable) error {
+	metadata := fmt.Sprintf("# League: %v\n", table.LeagueCode)
+	if table.Cohort != "" {
+		metadata = fmt.Sprintf("# Cohort: %v\n", table.Cohort)
+	}
+	if table.SampleSize > 0 {
+		metadata += fmt.Sprintf("# Sample size: %v\n", table.SampleSize)
+	}
+	if !table.FetchedAt.IsZero() {
+		metadata += fmt.Sprintf("# Fetched at: %v\n", table.FetchedAt.UTC().Format(time.RFC3339))
+	}
+	if _, err := io.WriteString(w, metadata); err != nil {
+		return errors.Errorf("error writing csv metadata : %v", err)
+	}
+
+	writer := csv.NewWriter(w)
+	record := []string{"Player"}
+	for _, gameweek := range table.Gameweeks {
+		record = append(record, gameweekHeader(gameweek))
+	}
+	record = append(record, "Total", "Average", "Peak gameweek")
+	if err := writer.Write(record); err != nil {
+		return errors.Errorf("error writing csv header : %v", err)
+	}
+	for _, row := range table.Rows {
+		record := []string{row.Player}
+		for _, owners := range row.Owners {
+			record = append(record, strconv.Itoa(owners))
+		}
+		record = append(record,
+			strconv.Itoa(row.Total()),
+			strconv.FormatFloat(row.Average(), 'f', 2, 64),
+			strconv.Itoa(table.PeakGameweek(row)),
+		)
+		if err := writer.Write(record); err != nil {
+			return errors.Errorf("error writing csv line of %v : %v", row.Player, err)
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
+
+type jsonTable struct {
+	LeagueCode int         `json:"leagueCode"`
+	Cohort     string      `json:"cohort,omitempty"`
+	SampleSize int         `json:"sampleSize,omitempty"`
+	FetchedAt  *time.Time  `json:"fetchedAt,omitempty"`
+	Gameweeks  []int       `json:"gameweeks"`
+	Players    []jsonOwner `json:"players"`
+}
+
+type jsonOwner struct {
+	Player       string  `json:"player"`
+	Owners       []int   `json:"owners"`
+	Total        int     `json:"total"`
+	Average      float64 `json:"average"`
+	PeakGameweek int     `json:"peakGameweek"`
+}
+
+//JSONExporter writes a single object, with the gameweeks and the owners of every player in each of them along with
+//their total, average and peak gameweek
+type JSONExporter struct{}
+
+func (JSONExporter) ContentType() string {
+	return "application/json"
+}
+
+func (JSONExporter) Export(w io.Writer, table *OwnershipTable) error {
+	out := jsonTable{
+		LeagueCode: table.LeagueCode,
+		Cohort:     table.Cohort,
+		SampleSize: table.SampleSize,
+		Gameweeks:  table.Gameweeks,
+		Players:    make([]jsonOwner, 0, len(table.Rows)),
+	}
+	if !table.FetchedAt.IsZero() {
+		out.FetchedAt = &table.FetchedAt
+	}
+	if out.Gameweeks == nil {
+		out.Gameweeks = []int{}
+	}
+	for _, row := range table.Rows {
+		out.Players = append(out.Players, jsonOwner{
+			Player:       row.Player,
+			Owners:       row.Owners,
+			Total:        row.Total(),
+			Average:      row.roundedAverage(),
+			PeakGameweek: table.PeakGameweek(row),
+		})
+	}
+	return json.NewEncoder(w).Encode(out)
+}
+
+type ndjsonOwner struct {
+	LeagueCode int    `json:"leagueCode"`
+	Cohort     string `json:"cohort,omitempty"`
+	Gameweek   int    `json:"gameweek"`
+	Player     string `json:"player"`
+	Owners     int    `json:"owners"`
+}
+
+//NDJSONExporter writes a JSON object per line for each player and gameweek, in the order of the table
+type NDJSONExporter struct{}
+
+func (NDJSONExporter) ContentType() string {
+	return "application/x-ndjson"
+}
+
+func (NDJSONExporter) Export(w io.Writer, table *OwnershipTable) error {
+	encoder := json.NewEncoder(w)
+	for _, row := range table.Rows {
+		for i, gameweek := range table.Gameweeks {
+			err := encoder.Encode(ndjsonOwner{LeagueCode: table.LeagueCode, Cohort: table.Cohort, Gameweek: gameweek, Player: row.Player, Owners: row.Owners[i]})
+			if err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+//MarkdownExporter writes a Markdown table, with the counts, total, average and peak gameweek of the players aligned
+//right
+type MarkdownExporter struct{}
+
+func (MarkdownExporter) ContentType() string {
+	return "text/markdown"
+}
+
+func (MarkdownExporter) Export(w io.Writer, table *OwnershipTable) error {
+	header := []string{"Player"}
+	separator := []string{"---"}
+	for _, gameweek := range table.Gameweeks {
+		header = append(header, gameweekHeader(gameweek))
+		separator = append(separator, "---:")
+	}
+	header = append(header, "Total", "Average", "Peak gameweek")
+	separator = append(separator, "---:", "---:", "---:")
+	if err := writeMarkdownRow(w, header); err != nil {
+		return err
+	}
+	if err := writeMarkdownRow(w, separator); err != nil {
+		return err
+	}
+	for _, row := range table.Rows {
+		cells := []string{strings.Replace(row.Player, "|", `\|`, -1)}
+		for _, owners := range row.Owners {
+			cells = append(cells, strconv.Itoa(owners))
+		}
+		cells = append(cells,
+			strconv.Itoa(row.Total()),
+			strconv.FormatFloat(row.Average(), 'f', 2, 64),
+			strconv.Itoa(table.PeakGameweek(row)),
+		)
+		if err := writeMarkdownRow(w, cells); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeMarkdownRow(w io.Writer, cells []string) error {
+	_, err := fmt.Fprintf(w, "| %v |\n", strings.Join(cells, " | "))
+	return err
+}
+
+//XLSXExporter writes an Excel workbook with a single sheet, a row for every player and a column for each gameweek,
+//then the total, average and peak gameweek of the players
+type XLSXExporter struct{}
+
+func (XLSXExporter) ContentType() string {
+	return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+}
+
+//xlsxParts are the parts of a workbook other than its sheet
+var xlsxParts = []struct {
+	name    string
+	content string
+}{
+	{"[Content_Types].xml", xml.Header + `<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`},
+	{"_rels/.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`},
+	{"xl/workbook.xml", xml.Header + `<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Ownership" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`},
+	{"xl/_rels/workbook.xml.rels", xml.Header + `<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`},
+}
+
+func (XLSXExporter) Export(w io.Writer, table *OwnershipTable) error {
+	archive := zip.NewWriter(w)
+	for _, part := range xlsxParts {
+		file, err := archive.Create(part.name)
+		if err != nil {
+			return errors.Errorf("error creating %v in xlsx : %v", part.name, err)
+		}
+		if _, err := io.WriteString(file, part.content); err != nil {
+			return errors.Errorf("error writing %v in xlsx : %v", part.name, err)
+		}
+	}
+
+	file, err := archive.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return errors.Errorf("error creating sheet in xlsx : %v", err)
+	}
+	sheet := bufio.NewWriter(file)
+	sheet.WriteString(xml.Header + `<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`)
+	header := []string{"Player"}
+	for _, gameweek := range table.Gameweeks {
+		header = append(header, gameweekHeader(gameweek))
+	}
+	header = append(header, "Total", "Average", "Peak gameweek")
+	writeXLSXRow(sheet, 1, header, nil)
+	for i, row := range table.Rows {
+		numbers := make([]float64, 0, len(row.Owners)+3)
+		for _, owners := range row.Owners {
+			numbers = append(numbers, float64(owners))
+		}
+		numbers = append(numbers, float64(row.Total()), row.roundedAverage(), float64(table.PeakGameweek(row)))
+		writeXLSXRow(sheet, i+2, []string{row.Player}, numbers)
+	}
+	sheet.WriteString(`</sheetData></worksheet>`)
+	if err := sheet.Flush(); err != nil {
+		return errors.Errorf("error writing sheet in xlsx : %v", err)
+	}
+	return archive.Close()
+}
+
+//writeXLSXRow writes texts then numbers as the cells of row, numbered from 1
+func writeXLSXRow(w *bufio.Writer, row int, texts []string, numbers []float64) {
+	fmt.Fprintf(w, `<row r="%v">`, row)
+	for column, value := range texts {
+		fmt.Fprintf(w, `<c r="%v%v" t="inlineStr"><is><t>`, xlsxColumn(column), row)
+		xml.EscapeText(w, []byte(value))
+		w.WriteString(`</t></is></c>`)
+	}
+	for i, value := range numbers {
+		fmt.Fprintf(w, `<c r="%v%v"><v>%v</v></c>`, xlsxColumn(len(texts)+i), row, strconv.FormatFloat(value, 'f', -1, 64))
+	}
+	w.WriteString(`</row>`)
+}
+
+//xlsxColumn is the name of a column numbered from 0, A to Z then AA and so on
+func xlsxColumn(column int) string {
+	name := ""
+	for column++; column > 0; column = (column - 1) / 26 {
+		name = string(rune('A'+(column-1)%26)) + name
+	}
+	return name
+}
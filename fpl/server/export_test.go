@@ -0,0 +1,300 @@
+package server_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"strings"
+	"testing"
+	"time"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/go-fantasy/fpl/mock"
+	"github.com/go-fantasy/fpl/server"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func exportTable() *server.OwnershipTable {
+	return &server.OwnershipTable{
+		LeagueCode: 313,
+		Gameweeks:  []int{1, 2},
+		Rows: []server.OwnershipRow{
+			{Player: "Messi", Owners: []int{3, 1}},
+			{Player: "Salah|Mo", Owners: []int{0, 2}},
+		},
+	}
+}
+
+func exportString(t *testing.T, exporter server.Exporter) string {
+	var out bytes.Buffer
+	require.Nil(t, exporter.Export(&out, exportTable()))
+	return out.String()
+}
+
+func TestTextExporters(t *testing.T) {
+	assert.Equal(t, "# League: 313\n"+
+		"Player,Gameweek 1,Gameweek 2,Total,Average,Peak gameweek\n"+
+		"Messi,3,1,4,2.00,1\n"+
+		"Salah|Mo,0,2,2,1.00,2\n", exportString(t, server.CSVExporter{}))
+
+	assert.JSONEq(t, `{
+		"leagueCode": 313,
+		"gameweeks": [1, 2],
+		"players": [
+			{"player": "Messi", "owners": [3, 1], "total": 4, "average": 2, "peakGameweek": 1},
+			{"player": "Salah|Mo", "owners": [0, 2], "total": 2, "average": 1, "peakGameweek": 2}
+		]
+	}`, exportString(t, server.JSONExporter{}))
+
+	assert.Equal(t, []string{
+		`{"leagueCode":313,"gameweek":1,"player":"Messi","owners":3}`,
+		`{"leagueCode":313,"gameweek":2,"player":"Messi","owners":1}`,
+		`{"leagueCode":313,"gameweek":1,"player":"Salah|Mo","owners":0}`,
+		`{"leagueCode":313,"gameweek":2,"player":"Salah|Mo","owners":2}`,
+	}, strings.Split(strings.TrimSpace(exportString(t, server.NDJSONExporter{})), "\n"))
+
+	assert.Equal(t, "| Player | Gameweek 1 | Gameweek 2 | Total | Average | Peak gameweek |\n"+
+		"| --- | ---: | ---: | ---: | ---: | ---: |\n"+
+		"| Messi | 3 | 1 | 4 | 2.00 | 1 |\n"+
+		`| Salah\|Mo | 0 | 2 | 2 | 1.00 | 2 |`+"\n", exportString(t, server.MarkdownExporter{}))
+}
+
+func TestXLSXExporter(t *testing.T) {
+	xlsx := exportString(t, server.XLSXExporter{})
+	archive, err := zip.NewReader(strings.NewReader(xlsx), int64(len(xlsx)))
+	require.Nil(t, err)
+
+	files := make(map[string]string)
+	for _, file := range archive.File {
+		r, err := file.Open()
+		require.Nil(t, err)
+		content, err := ioutil.ReadAll(r)
+		require.Nil(t, err)
+		files[file.Name] = string(content)
+	}
+	assert.Contains(t, files, "[Content_Types].xml")
+	assert.Contains(t, files, "xl/workbook.xml")
+	assert.Contains(t, files["xl/worksheets/sheet1.xml"],
+		`<row r="1"><c r="A1" t="inlineStr"><is><t>Player</t></is></c><c r="B1" t="inlineStr"><is><t>Gameweek 1</t></is></c>`)
+	assert.Contains(t, files["xl/worksheets/sheet1.xml"],
+		`<c r="D1" t="inlineStr"><is><t>Total</t></is></c><c r="E1" t="inlineStr"><is><t>Average</t></is></c>`+
+			`<c r="F1" t="inlineStr"><is><t>Peak gameweek</t></is></c></row>`)
+	assert.Contains(t, files["xl/worksheets/sheet1.xml"],
+		`<row r="2"><c r="A2" t="inlineStr"><is><t>Messi</t></is></c><c r="B2"><v>3</v></c><c r="C2"><v>1</v></c>`+
+			`<c r="D2"><v>4</v></c><c r="E2"><v>2</v></c><c r="F2"><v>1</v></c></row>`)
+}
+
+func TestParquetExporter(t *testing.T) {
+	parquet := []byte(exportString(t, server.ParquetExporter{}))
+	assert.Equal(t, "PAR1", string(parquet[:4]))
+	assert.Equal(t, "PAR1", string(parquet[len(parquet)-4:]))
+
+	footerLength := int(binary.LittleEndian.Uint32(parquet[len(parquet)-8:]))
+	footer := string(parquet[len(parquet)-8-footerLength : len(parquet)-8])
+	for _, column := range []string{"league_code", "cohort", "gameweek", "player", "owners"} {
+		assert.Contains(t, footer, column)
+	}
+	//A row per player and gameweek, every player name written twice
+	assert.Equal(t, 2, strings.Count(string(parquet), "Salah|Mo"))
+
+	//Every row of a cohort has its name
+	table := exportTable()
+	table.Cohort = "creators"
+	var out bytes.Buffer
+	require.Nil(t, server.ParquetExporter{}.Export(&out, table))
+	assert.Equal(t, 4, strings.Count(out.String(), "creators"))
+}
+
+//exportStream keeps the data and the messages sent to it
+type exportStream struct {
+	grpc.ServerStream
+	data     bytes.Buffer
+	messages []*grpc_fpl.AllGameweekData
+}
+
+func (x *exportStream) Context() context.Context {
+	return context.Background()
+}
+
+func (x *exportStream) Send(m *grpc_fpl.AllGameweekData) error {
+	x.data.Write(m.Data)
+	x.messages = append(x.messages, m)
+	return nil
+}
+
+//upperExporter writes the names of the players in upper case
+type upperExporter struct{}
+
+func (upperExporter) ContentType() string {
+	return "text/plain"
+}
+
+func (upperExporter) Export(w io.Writer, table *server.OwnershipTable) error {
+	for _, row := range table.Rows {
+		io.WriteString(w, strings.ToUpper(row.Player)+"\n")
+	}
+	return nil
+}
+
+func TestGetDataForAllGameweeksFormats(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	store := server.NewMemoryStore()
+	myFPLServer := &server.MyFPLServer{
+		Scraper:        mock_server.NewMockScraper(mockCtrl),
+		Store:          store,
+		WatchedLeagues: []int{313},
+	}
+	server.WithExporter(grpc_fpl.ExportFormat_MARKDOWN, upperExporter{})(myFPLServer)
+	fetchedAt := time.Now().UTC().Truncate(time.Second)
+	store.Set(&server.LeagueData{
+		LeagueCode:       313,
+		SampleSize:       10,
+		PlayerOccurances: map[int]map[string]int{1: {"Salah": 10}, 2: {"Salah": 9, "Messi": 1}},
+		FetchedAt:        fetchedAt,
+	})
+
+	stream := &exportStream{}
+	err := myFPLServer.GetDataForAllGameweeks(&grpc_fpl.AllGameweeksReq{LeagueCode: 313, Format: grpc_fpl.ExportFormat_JSON}, stream)
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	var table struct {
+		LeagueCode int       `json:"leagueCode"`
+		SampleSize int       `json:"sampleSize"`
+		FetchedAt  time.Time `json:"fetchedAt"`
+		Gameweeks  []int     `json:"gameweeks"`
+	}
+	assert.Nil(t, json.Unmarshal(stream.data.Bytes(), &table))
+	assert.Equal(t, 10, table.SampleSize)
+	assert.True(t, fetchedAt.Equal(table.FetchedAt))
+	assert.Equal(t, []int{1, 2}, table.Gameweeks)
+
+	stream = &exportStream{}
+	err = myFPLServer.GetDataForAllGameweeks(&grpc_fpl.AllGameweeksReq{LeagueCode: 313, Format: grpc_fpl.ExportFormat_MARKDOWN}, stream)
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, "SALAH\nMESSI\n", stream.data.String(), "Exporters should replace the default ones")
+
+	err = myFPLServer.GetDataForAllGameweeks(&grpc_fpl.AllGameweeksReq{LeagueCode: 313, Format: 42}, &exportStream{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetDataForAllGameweeksSort(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	store := server.NewMemoryStore()
+	myFPLServer := &server.MyFPLServer{
+		Scraper:        mock_server.NewMockScraper(mockCtrl),
+		Store:          store,
+		WatchedLeagues: []int{313},
+	}
+	fetchedAt := time.Now().UTC().Truncate(time.Second)
+	store.Set(&server.LeagueData{
+		LeagueCode: 313,
+		SampleSize: 10,
+		PlayerOccurances: map[int]map[string]int{
+			1: {"Salah": 10, "Kane": 5, "Messi": 1, "Rooney": 1},
+			2: {"Salah": 9, "Kane": 6, "Messi": 1},
+			3: {"Salah": 2, "Kane": 7, "Messi": 8},
+		},
+		FetchedAt: fetchedAt,
+	})
+
+	stream := &exportStream{}
+	err := myFPLServer.GetDataForAllGameweeks(&grpc_fpl.AllGameweeksReq{LeagueCode: 313}, stream)
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, "# League: 313\n"+
+		"# Sample size: 10\n"+
+		"# Fetched at: "+fetchedAt.Format(time.RFC3339)+"\n"+
+		"Player,Gameweek 1,Gameweek 2,Gameweek 3,Total,Average,Peak gameweek\n"+
+		"Salah,10,9,2,21,7.00,1\n"+
+		"Kane,5,6,7,18,6.00,3\n"+
+		"Messi,1,1,8,10,3.33,3\n"+
+		"Rooney,1,0,0,1,0.33,1\n", stream.data.String(), "Players no longer owned should be kept")
+
+	server.WithExporter(grpc_fpl.ExportFormat_MARKDOWN, upperExporter{})(myFPLServer)
+	for sort, players := range map[grpc_fpl.OwnershipSort]string{
+		grpc_fpl.OwnershipSort_TOTAL:  "SALAH\nKANE\nMESSI\nROONEY\n",
+		grpc_fpl.OwnershipSort_LATEST: "MESSI\nKANE\nSALAH\nROONEY\n",
+		grpc_fpl.OwnershipSort_PEAK:   "SALAH\nMESSI\nKANE\nROONEY\n",
+	} {
+		stream := &exportStream{}
+		err := myFPLServer.GetDataForAllGameweeks(&grpc_fpl.AllGameweeksReq{LeagueCode: 313, Format: grpc_fpl.ExportFormat_MARKDOWN, Sort: sort}, stream)
+		require.Nil(t, err, "Error %v was supposed to be nil ", err)
+		assert.Equal(t, players, stream.data.String(), "Unexpected order by %v", sort)
+	}
+
+	err = myFPLServer.GetDataForAllGameweeks(&grpc_fpl.AllGameweeksReq{LeagueCode: 313, Sort: 42}, &exportStream{})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
+func TestGetDataForAllGameweeksResume(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	store := server.NewMemoryStore()
+	myFPLServer := &server.MyFPLServer{
+		Scraper:        mock_server.NewMockScraper(mockCtrl),
+		Store:          store,
+		WatchedLeagues: []int{313},
+	}
+	store.Set(&server.LeagueData{
+		LeagueCode:       313,
+		SampleSize:       10,
+		PlayerOccurances: map[int]map[string]int{1: {"Salah": 10}, 2: {"Salah": 9, "Messi": 1}},
+		FetchedAt:        time.Now(),
+	})
+
+	full := &exportStream{}
+	err := myFPLServer.GetDataForAllGameweeks(&grpc_fpl.AllGameweeksReq{LeagueCode: 313}, full)
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	require.Equal(t, 2, len(full.messages), "The data should fit in a chunk, then comes the digest")
+	sum := sha256.Sum256(full.data.Bytes())
+	last := full.messages[1]
+	assert.Equal(t, hex.EncodeToString(sum[:]), last.Digest)
+	assert.Equal(t, int64(full.data.Len()), last.Offset)
+	assert.Empty(t, last.Data)
+	token := full.messages[0].ResumeToken
+	assert.NotEmpty(t, token)
+	assert.Equal(t, token, last.ResumeToken)
+
+	resumed := &exportStream{}
+	err = myFPLServer.GetDataForAllGameweeks(&grpc_fpl.AllGameweeksReq{LeagueCode: 313, Offset: 10, ResumeToken: token}, resumed)
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, full.data.String()[10:], resumed.data.String())
+	assert.Equal(t, int64(10), resumed.messages[0].Offset)
+	assert.Equal(t, last.Digest, resumed.messages[len(resumed.messages)-1].Digest, "The digest should cover the whole export")
+
+	resumed = &exportStream{}
+	err = myFPLServer.GetDataForAllGameweeks(&grpc_fpl.AllGameweeksReq{LeagueCode: 313, Offset: int64(full.data.Len()), ResumeToken: token}, resumed)
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, []*grpc_fpl.AllGameweekData{last}, resumed.messages, "Only the digest is left")
+
+	for _, req := range []struct {
+		offset int64
+		token  string
+		code   codes.Code
+	}{
+		{10, "", codes.InvalidArgument},
+		{-1, token, codes.InvalidArgument},
+		{10, "stale", codes.FailedPrecondition},
+		{int64(full.data.Len()) + 1, token, codes.OutOfRange},
+	} {
+		err = myFPLServer.GetDataForAllGameweeks(&grpc_fpl.AllGameweeksReq{LeagueCode: 313, Offset: req.offset, ResumeToken: req.token}, &exportStream{})
+		assert.Equal(t, req.code, status.Code(err), "Unexpected error %v resuming at %v with %q", err, req.offset, req.token)
+	}
+
+	err = myFPLServer.GetDataForAllGameweeks(&grpc_fpl.AllGameweeksReq{LeagueCode: 313, Format: grpc_fpl.ExportFormat_JSON, ResumeToken: token}, &exportStream{})
+	assert.Equal(t, codes.FailedPrecondition, status.Code(err), "The token of an export shouldn't resume one in another format")
+}
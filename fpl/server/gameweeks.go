@@ -0,0 +1,113 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//selectedGameweeks returns the gameweeks a selection asks for in order, or nil for every gameweek when there is no
+//selection. A selection is either a range, a list or the last gameweeks up to the current one
+func (s *MyFPLServer) selectedGameweeks(ctx context.Context, selection *grpc_fpl.GameweekSelection) ([]int, error) {
+	if selection == nil {
+		return nil, nil
+	}
+	isRange, isList, isLast := selection.From != 0 || selection.To != 0, len(selection.Gameweeks) > 0, selection.Last != 0
+	if (isRange && isList) || (isRange && isLast) || (isList && isLast) {
+		return nil, status.Errorf(codes.InvalidArgument, "gameweeks should be selected by a range, a list or the last ones, not several of them")
+	}
+
+	switch {
+	case isRange:
+		from, to := int(selection.From), int(selection.To)
+		if from == 0 {
+			from = 1
+		}
+		if to == 0 {
+			to = GameweekMax
+		}
+		if from < 1 || to > GameweekMax || from > to {
+			return nil, status.Errorf(codes.InvalidArgument, "gameweeks %v to %v aren't a range between 1 and %v", from, to, GameweekMax)
+		}
+		return gameweekRange(from, to), nil
+	case isList:
+		seen := make(map[int]bool)
+		gameweeks := []int{}
+		for _, gameweek := range selection.Gameweeks {
+			if gameweek < 1 || gameweek > GameweekMax {
+				return nil, status.Errorf(codes.InvalidArgument, "gameweek %v is not between 1 and %v", gameweek, GameweekMax)
+			}
+			if !seen[int(gameweek)] {
+				seen[int(gameweek)] = true
+				gameweeks = append(gameweeks, int(gameweek))
+			}
+		}
+		sort.Ints(gameweeks)
+		return gameweeks, nil
+	case isLast:
+		if selection.Last < 0 || selection.Last > GameweekMax {
+			return nil, status.Errorf(codes.InvalidArgument, "the last %v gameweeks can't be selected, it should be between 1 and %v", selection.Last, GameweekMax)
+		}
+		events, err := s.Scraper.GetEvents(ctx)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "error while getting the current gameweek : %v", err)
+		}
+		current := currentGameweek(events)
+		from := current - int(selection.Last) + 1
+		if from < 1 {
+			from = 1
+		}
+		return gameweekRange(from, current), nil
+	}
+	return nil, nil
+}
+
+//gameweekRange returns the gameweeks from from to to, none when to is before from
+func gameweekRange(from, to int) []int {
+	gameweeks := []int{}
+	for gameweek := from; gameweek <= to; gameweek++ {
+		gameweeks = append(gameweeks, gameweek)
+	}
+	return gameweeks
+}
+
+//only returns the data of the league in gameweeks alone, or all of it when gameweeks is nil
+func (d *LeagueData) only(gameweeks []int) *LeagueData {
+	if gameweeks == nil {
+		return d
+	}
+	selected := *d
+	selected.PlayerOccurances = make(map[int]map[string]int, len(gameweeks))
+	for _, gameweek := range gameweeks {
+		if playerOccurance, ok := d.PlayerOccurances[gameweek]; ok {
+			selected.PlayerOccurances[gameweek] = playerOccurance
+		}
+	}
+	return &selected
+}
+
+//gameweeksData returns the data of some gameweeks of a league, or of every gameweek when gameweeks is nil. The
+//stored data of the league is used when it is fresh enough, otherwise only the gameweeks asked for are scraped.
+//Those scrapes aren't stored, as the store keeps the data of every gameweek
+func (s *MyFPLServer) gameweeksData(ctx context.Context, leagueCode, sampleSize int, gameweeks []int) (*LeagueData, error) {
+	if gameweeks == nil {
+		return s.leagueData(ctx, leagueCode, sampleSize)
+	}
+	if leagueData, ok := s.cachedLeagueData(ctx, leagueCode, sampleSize); ok {
+		return leagueData.only(gameweeks), nil
+	}
+
+	//Identical requests running at the same time share a single scrape
+	key := fmt.Sprintf("%v:%v:%v", leagueCode, gameweeks, sampleSize)
+	result, err, _ := s.scrapes.Do(key, func() (interface{}, error) {
+		return s.scrapeGameweeks(s.sharedContext(ctx), leagueCode, sampleSize, gameweeks)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result.(*LeagueData), nil
+}
@@ -0,0 +1,125 @@
+package server_test
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/go-fantasy/fpl/mock"
+	"github.com/go-fantasy/fpl/server"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//exportHeader returns the header line of the CSV export of req
+func exportHeader(t *testing.T, myFPLServer *server.MyFPLServer, req *grpc_fpl.AllGameweeksReq) string {
+	stream := &exportStream{}
+	err := myFPLServer.GetDataForAllGameweeks(req, stream)
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	for _, line := range strings.Split(stream.data.String(), "\n") {
+		if strings.HasPrefix(line, "Player") {
+			return line
+		}
+	}
+	return ""
+}
+
+func TestGameweekSelectionFromStore(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testObj := mock_server.NewMockScraper(mockCtrl)
+
+	store := server.NewMemoryStore()
+	store.Set(&server.LeagueData{
+		LeagueCode:       313,
+		SampleSize:       10,
+		PlayerOccurances: map[int]map[string]int{1: {"Salah": 10}, 2: {"Salah": 9}, 3: {"Salah": 8}, 4: {"Salah": 7}},
+		FetchedAt:        time.Now(),
+	})
+	myFPLServer := &server.MyFPLServer{Scraper: testObj, Store: store, WatchedLeagues: []int{313}}
+
+	assert.Equal(t, "Player,Gameweek 2,Gameweek 3,Gameweek 4,Total,Average,Peak gameweek", exportHeader(t, myFPLServer, &grpc_fpl.AllGameweeksReq{
+		LeagueCode: 313,
+		Gameweeks:  &grpc_fpl.GameweekSelection{From: 2},
+	}))
+	assert.Equal(t, "Player,Gameweek 1,Gameweek 3,Total,Average,Peak gameweek", exportHeader(t, myFPLServer, &grpc_fpl.AllGameweeksReq{
+		LeagueCode: 313,
+		Gameweeks:  &grpc_fpl.GameweekSelection{Gameweeks: []int64{3, 1, 3, 20}},
+	}), "Gameweeks without data should be left out")
+
+	testObj.EXPECT().GetEvents(gomock.Any()).Return([]server.Event{{ID: 3, Finished: true}, {ID: 4, IsCurrent: true}, {ID: 5}}, nil).Times(1)
+	assert.Equal(t, "Player,Gameweek 3,Gameweek 4,Total,Average,Peak gameweek", exportHeader(t, myFPLServer, &grpc_fpl.AllGameweeksReq{
+		LeagueCode: 313,
+		Gameweeks:  &grpc_fpl.GameweekSelection{Last: 2},
+	}))
+
+	for _, selection := range []*grpc_fpl.GameweekSelection{
+		{From: 3, To: 2},
+		{To: 39},
+		{Gameweeks: []int64{0}},
+		{From: 1, Gameweeks: []int64{2}},
+		{Gameweeks: []int64{2}, Last: 1},
+		{Last: -1},
+	} {
+		err := myFPLServer.GetDataForAllGameweeks(&grpc_fpl.AllGameweeksReq{LeagueCode: 313, Gameweeks: selection}, &exportStream{})
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "Selection %v should be invalid", selection)
+	}
+}
+
+func TestGameweekSelectionScrapesOnlySelectedGameweeks(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testObj := mock_server.NewMockScraper(mockCtrl)
+
+	testObj.EXPECT().GetPlayerMapping(gomock.Any()).Return(map[int64]string{267: "Messi"}, nil).Times(1)
+	testObj.EXPECT().GetParticipantsInLeague(gomock.Any(), 313).Return(&[]int64{1, 2}, nil).Times(1)
+	for _, gameweek := range []int{5, 7} {
+		testObj.EXPECT().GetTeamInfoForParticipant(gomock.Any(), gomock.Any(), gameweek, gomock.Any()).
+			Return(map[string]int{"Messi": gameweek}, nil).Times(1)
+	}
+
+	store := server.NewMemoryStore()
+	myFPLServer := &server.MyFPLServer{Scraper: testObj, Store: store}
+	stream := &exportStream{}
+	err := myFPLServer.GetDataForAllGameweeks(&grpc_fpl.AllGameweeksReq{
+		LeagueCode: 313,
+		Gameweeks:  &grpc_fpl.GameweekSelection{Gameweeks: []int64{7, 5}},
+	}, stream)
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Contains(t, stream.data.String(), "Player,Gameweek 5,Gameweek 7,Total,Average,Peak gameweek\nMessi,5,7,12,6.00,7\n")
+
+	_, ok := store.Get(313, 10)
+	assert.False(t, ok, "A scrape of some gameweeks shouldn't be stored as the data of the league")
+}
+
+func TestGameweekSelectionResume(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testObj := mock_server.NewMockScraper(mockCtrl)
+
+	//The gameweeks are scraped once, resuming sends the rest of the same data
+	testObj.EXPECT().GetPlayerMapping(gomock.Any()).Return(map[int64]string{267: "Messi"}, nil).Times(1)
+	testObj.EXPECT().GetParticipantsInLeague(gomock.Any(), 5).Return(&[]int64{1, 2}, nil).Times(1)
+	testObj.EXPECT().GetTeamInfoForParticipant(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+		Return(map[string]int{"Messi": 2}, nil).Times(2)
+
+	myFPLServer := &server.MyFPLServer{Scraper: testObj, Store: server.NewMemoryStore()}
+	req := &grpc_fpl.AllGameweeksReq{LeagueCode: 5, Gameweeks: &grpc_fpl.GameweekSelection{From: 1, To: 2}}
+	full := &exportStream{}
+	err := myFPLServer.GetDataForAllGameweeks(req, full)
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+
+	resumed := &exportStream{}
+	err = myFPLServer.GetDataForAllGameweeks(&grpc_fpl.AllGameweeksReq{
+		LeagueCode:  5,
+		Gameweeks:   req.Gameweeks,
+		Offset:      1,
+		ResumeToken: full.messages[0].ResumeToken,
+	}, resumed)
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, full.data.String()[1:], resumed.data.String())
+}
@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+)
+
+const (
+	//FPLServiceName is the name the FPL service reports its health under
+	FPLServiceName = "grpc.FPL"
+	//DefaultHealthCheckInterval is how often the FPL site is probed when HealthCheckInterval isn't set
+	DefaultHealthCheckInterval = 30 * time.Second
+	//DefaultUnhealthyAfter is how long the FPL site can be unreachable before the server stops reporting SERVING
+	DefaultUnhealthyAfter = 2 * time.Minute
+)
+
+//newHealthServer creates the health service, reporting SERVING until the first probes of the FPL site fail
+func newHealthServer() *health.Server {
+	healthServer := health.NewServer()
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_SERVING)
+	healthServer.SetServingStatus(FPLServiceName, healthpb.HealthCheckResponse_SERVING)
+	return healthServer
+}
+
+//setHealth sets the status of the server and of the FPL service together
+func setHealth(healthServer *health.Server, status healthpb.HealthCheckResponse_ServingStatus) {
+	healthServer.SetServingStatus("", status)
+	healthServer.SetServingStatus(FPLServiceName, status)
+}
+
+//checkUpstream probes the FPL site every HealthCheckInterval until stop is closed. The server reports NOT_SERVING
+//once no probe has succeeded for UnhealthyAfter, so that a short outage of the site doesn't take it out of rotation
+func (s *MyFPLServer) checkUpstream(stop <-chan struct{}, healthServer *health.Server) {
+	interval := s.HealthCheckInterval
+	if interval <= 0 {
+		interval = DefaultHealthCheckInterval
+	}
+	unhealthyAfter := s.UnhealthyAfter
+	if unhealthyAfter <= 0 {
+		unhealthyAfter = DefaultUnhealthyAfter
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	lastSuccess := time.Now()
+	serving := true
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), interval)
+		_, err := s.Scraper.GetEvents(ctx)
+		cancel()
+
+		log := s.logger(context.Background())
+		switch {
+		case err == nil:
+			lastSuccess = time.Now()
+			if !serving {
+				log.Info("FPL site is reachable again, reporting SERVING")
+				setHealth(healthServer, healthpb.HealthCheckResponse_SERVING)
+				serving = true
+			}
+		case serving && time.Since(lastSuccess) >= unhealthyAfter:
+			log.WithError(err).WithField("since", lastSuccess.Format(time.RFC3339)).Warn("FPL site is unreachable, reporting NOT_SERVING")
+			setHealth(healthServer, healthpb.HealthCheckResponse_NOT_SERVING)
+			serving = false
+		default:
+			log.WithError(err).Debug("health probe of the FPL site failed")
+		}
+
+		select {
+		case <-stop:
+			return
+		case <-ticker.C:
+		}
+	}
+}
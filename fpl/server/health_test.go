@@ -0,0 +1,118 @@
+package server_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-fantasy/fpl/mock"
+	"github.com/go-fantasy/fpl/server"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1alpha"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestHealthFollowsUpstream(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testObj := mock_server.NewMockScraper(mockCtrl)
+
+	var reachable atomic.Value
+	reachable.Store(true)
+	testObj.EXPECT().GetEvents(gomock.Any()).DoAndReturn(func(context.Context) ([]server.Event, error) {
+		if reachable.Load().(bool) {
+			return []server.Event{}, nil
+		}
+		return nil, errors.New("connection refused")
+	}).AnyTimes()
+
+	myFPLServer := &server.MyFPLServer{
+		Scraper:             testObj,
+		HealthCheck:         true,
+		HealthCheckInterval: time.Millisecond * 10,
+		UnhealthyAfter:      time.Millisecond * 50,
+	}
+	lis := bufconn.Listen(1024 * 1024)
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() {
+		served <- myFPLServer.Serve(ctx, lis)
+	}()
+
+	conn, err := dialBufconn(lis)
+	assert.Nil(t, err)
+	defer conn.Close()
+	healthClient := healthpb.NewHealthClient(conn)
+
+	waitForStatus := func(service string, expected healthpb.HealthCheckResponse_ServingStatus) {
+		deadline := time.Now().Add(time.Second * 5)
+		for {
+			resp, err := healthClient.Check(context.Background(), &healthpb.HealthCheckRequest{Service: service})
+			if err == nil && resp.Status == expected {
+				return
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("Service %q didn't report %v, last response %v, error %v", service, expected, resp, err)
+			}
+			time.Sleep(time.Millisecond * 10)
+		}
+	}
+
+	waitForStatus("", healthpb.HealthCheckResponse_SERVING)
+	waitForStatus(server.FPLServiceName, healthpb.HealthCheckResponse_SERVING)
+
+	reachable.Store(false)
+	waitForStatus(server.FPLServiceName, healthpb.HealthCheckResponse_NOT_SERVING)
+	waitForStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+
+	reachable.Store(true)
+	waitForStatus(server.FPLServiceName, healthpb.HealthCheckResponse_SERVING)
+
+	cancel()
+	select {
+	case err := <-served:
+		assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+	case <-time.After(time.Second * 5):
+		t.Fatal("Serve didn't return after its context was cancelled")
+	}
+}
+
+func TestReflection(t *testing.T) {
+	for _, enabled := range []bool{true, false} {
+		myFPLServer := &server.MyFPLServer{Reflection: enabled}
+		lis := bufconn.Listen(1024 * 1024)
+		ctx, cancel := context.WithCancel(context.Background())
+		go myFPLServer.Serve(ctx, lis)
+
+		conn, err := dialBufconn(lis)
+		assert.Nil(t, err)
+
+		stream, err := reflectionpb.NewServerReflectionClient(conn).ServerReflectionInfo(context.Background())
+		assert.Nil(t, err)
+		err = stream.Send(&reflectionpb.ServerReflectionRequest{
+			MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+		})
+		assert.Nil(t, err)
+		resp, err := stream.Recv()
+
+		if enabled {
+			assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+			var services []string
+			for _, service := range resp.GetListServicesResponse().GetService() {
+				services = append(services, service.Name)
+			}
+			assert.Contains(t, services, server.FPLServiceName)
+		} else {
+			assert.Equal(t, codes.Unimplemented, status.Code(err))
+		}
+
+		conn.Close()
+		cancel()
+	}
+}
@@ -0,0 +1,230 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"io"
+	"sort"
+	"strconv"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/sirupsen/logrus"
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/basicfont"
+	"golang.org/x/image/math/fixed"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+//Layout of heatmaps, in pixels. Labels are written in the 7x13 basic font
+const (
+	heatmapCellWidth  = 28
+	heatmapCellHeight = 18
+	heatmapMargin     = 8
+	heatmapCharWidth  = 7
+)
+
+var (
+	heatmapBackground = color.RGBA{255, 255, 255, 255}
+	heatmapText       = color.RGBA{33, 33, 33, 255}
+	heatmapLow        = color.RGBA{255, 247, 236, 255}
+	heatmapHigh       = color.RGBA{179, 0, 0, 255}
+)
+
+//GetHeatmap is the gRPC method rendering the ownership of players in every gameweek of a league or a cohort as
+//an image
+func (s *MyFPLServer) GetHeatmap(ctx context.Context, req *grpc_fpl.HeatmapReq) (*grpc_fpl.Heatmap, error) {
+	sampleSize := sampleSizeOrDefault(int(req.SampleSize))
+	ctx = withLogger(ctx, s.logger(ctx).WithFields(logrus.Fields{
+		"league":      req.LeagueCode,
+		"sample_size": sampleSize,
+		"format":      req.Format,
+		"cohort":      req.Cohort,
+	}))
+	if err := checkLeagueOrCohort(req.LeagueCode, req.Cohort); err != nil {
+		return nil, err
+	}
+	if _, ok := grpc_fpl.ImageFormat_name[int32(req.Format)]; !ok {
+		return nil, status.Errorf(codes.InvalidArgument, "unknown image format %v", req.Format)
+	}
+	if req.Top < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "top %v should not be negative", req.Top)
+	}
+	leagueData, err := s.groupData(ctx, int(req.LeagueCode), req.Cohort, sampleSize, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	exporter := HeatmapExporter{Format: req.Format, Top: int(req.Top)}
+	var heatmap bytes.Buffer
+	if err := exporter.Export(&heatmap, leagueTable(leagueData)); err != nil {
+		return nil, status.Errorf(codes.Internal, "error while rendering heatmap of %v : %v", leagueData.name(), err)
+	}
+	return &grpc_fpl.Heatmap{Image: heatmap.Bytes(), ContentType: exporter.ContentType()}, nil
+}
+
+//HeatmapExporter renders a table as a heatmap of players over gameweeks, the most owned players in total on top.
+//Cells are coloured by the share of the sample owning the player, or relative to the most owned player when the
+//table doesn't have a sample size
+type HeatmapExporter struct {
+	Format grpc_fpl.ImageFormat
+	//Top is how many players are shown, every player when 0
+	Top int
+}
+
+func (h HeatmapExporter) ContentType() string {
+	if h.Format == grpc_fpl.ImageFormat_SVG {
+		return "image/svg+xml"
+	}
+	return "image/png"
+}
+
+func (h HeatmapExporter) Export(w io.Writer, table *OwnershipTable) error {
+	layout := newHeatmapLayout(table, h.Top)
+	if h.Format == grpc_fpl.ImageFormat_SVG {
+		return layout.writeSVG(w)
+	}
+	return png.Encode(w, layout.drawPNG())
+}
+
+//heatmapLayout is where everything of a heatmap goes
+type heatmapLayout struct {
+	gameweeks   []int
+	rows        []OwnershipRow
+	max         int
+	labelWidth  int
+	headerWidth int
+	width       int
+	height      int
+}
+
+func newHeatmapLayout(table *OwnershipTable, top int) *heatmapLayout {
+	rows := make([]OwnershipRow, len(table.Rows))
+	copy(rows, table.Rows)
+	max := table.SampleSize
+	longest := 0
+	for _, row := range rows {
+		if table.SampleSize <= 0 && row.Peak() > max {
+			max = row.Peak()
+		}
+		if len(row.Player) > longest {
+			longest = len(row.Player)
+		}
+	}
+	sort.SliceStable(rows, func(i, j int) bool {
+		if rows[i].Total() != rows[j].Total() {
+			return rows[i].Total() > rows[j].Total()
+		}
+		return rows[i].Player < rows[j].Player
+	})
+	if top > 0 && len(rows) > top {
+		rows = rows[:top]
+	}
+	if max <= 0 {
+		max = 1
+	}
+
+	layout := &heatmapLayout{
+		gameweeks:   table.Gameweeks,
+		rows:        rows,
+		max:         max,
+		labelWidth:  longest*heatmapCharWidth + 2*heatmapMargin,
+		headerWidth: heatmapCellHeight + heatmapMargin,
+	}
+	layout.width = layout.labelWidth + len(layout.gameweeks)*heatmapCellWidth + heatmapMargin
+	layout.height = layout.headerWidth + len(layout.rows)*heatmapCellHeight + heatmapMargin
+	return layout
+}
+
+//cellColour scales from heatmapLow for nobody to heatmapHigh for the whole sample
+func (l *heatmapLayout) cellColour(owners int) color.RGBA {
+	fraction := float64(owners) / float64(l.max)
+	if fraction > 1 {
+		fraction = 1
+	}
+	mix := func(low, high uint8) uint8 {
+		return uint8(float64(low) + fraction*(float64(high)-float64(low)))
+	}
+	return color.RGBA{mix(heatmapLow.R, heatmapHigh.R), mix(heatmapLow.G, heatmapHigh.G), mix(heatmapLow.B, heatmapHigh.B), 255}
+}
+
+//textColour keeps counts readable on dark cells
+func (l *heatmapLayout) textColour(owners int) color.RGBA {
+	if float64(owners)/float64(l.max) > 0.5 {
+		return heatmapBackground
+	}
+	return heatmapText
+}
+
+//cell is the top left corner of the cell of a player and gameweek, both numbered from 0
+func (l *heatmapLayout) cell(row, column int) (int, int) {
+	return l.labelWidth + column*heatmapCellWidth, l.headerWidth + row*heatmapCellHeight
+}
+
+func (l *heatmapLayout) drawPNG() image.Image {
+	img := image.NewRGBA(image.Rect(0, 0, l.width, l.height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(heatmapBackground), image.Point{}, draw.Src)
+	text := func(s string, x, y int, c color.Color) {
+		drawer := &font.Drawer{Dst: img, Src: image.NewUniform(c), Face: basicfont.Face7x13, Dot: fixed.P(x, y)}
+		drawer.DrawString(s)
+	}
+	//Text is drawn from its baseline, which is about 4 pixels above the bottom of a centred line
+	baseline := (heatmapCellHeight+basicfont.Face7x13.Height)/2 - 3
+
+	for column, gameweek := range l.gameweeks {
+		x, _ := l.cell(0, column)
+		label := strconv.Itoa(gameweek)
+		text(label, x+(heatmapCellWidth-len(label)*heatmapCharWidth)/2, heatmapMargin/2+baseline, heatmapText)
+	}
+	for row, ownership := range l.rows {
+		_, y := l.cell(row, 0)
+		text(ownership.Player, heatmapMargin, y+baseline, heatmapText)
+		for column, owners := range ownership.Owners {
+			x, y := l.cell(row, column)
+			draw.Draw(img, image.Rect(x+1, y+1, x+heatmapCellWidth, y+heatmapCellHeight), image.NewUniform(l.cellColour(owners)), image.Point{}, draw.Src)
+			label := strconv.Itoa(owners)
+			text(label, x+(heatmapCellWidth-len(label)*heatmapCharWidth)/2, y+baseline, l.textColour(owners))
+		}
+	}
+	return img
+}
+
+func svgColour(c color.RGBA) string {
+	return fmt.Sprintf("#%02x%02x%02x", c.R, c.G, c.B)
+}
+
+func (l *heatmapLayout) writeSVG(w io.Writer) error {
+	var svg bytes.Buffer
+	fmt.Fprintf(&svg, `<svg xmlns="http://www.w3.org/2000/svg" width="%v" height="%v" viewBox="0 0 %v %v" font-family="monospace" font-size="11">`,
+		l.width, l.height, l.width, l.height)
+	fmt.Fprintf(&svg, `<rect width="100%%" height="100%%" fill="%v"/>`, svgColour(heatmapBackground))
+	for column, gameweek := range l.gameweeks {
+		x, _ := l.cell(0, column)
+		fmt.Fprintf(&svg, `<text x="%v" y="%v" text-anchor="middle" fill="%v">%v</text>`,
+			x+heatmapCellWidth/2, l.headerWidth-heatmapMargin, svgColour(heatmapText), gameweek)
+	}
+	for row, ownership := range l.rows {
+		var player bytes.Buffer
+		xml.EscapeText(&player, []byte(ownership.Player))
+		_, y := l.cell(row, 0)
+		fmt.Fprintf(&svg, `<text x="%v" y="%v" dominant-baseline="middle" fill="%v">%v</text>`,
+			heatmapMargin, y+heatmapCellHeight/2, svgColour(heatmapText), player.String())
+		for column, owners := range ownership.Owners {
+			x, y := l.cell(row, column)
+			fmt.Fprintf(&svg, `<g><title>%v, gameweek %v: %v</title>`, player.String(), l.gameweeks[column], owners)
+			fmt.Fprintf(&svg, `<rect x="%v" y="%v" width="%v" height="%v" fill="%v"/>`,
+				x+1, y+1, heatmapCellWidth-1, heatmapCellHeight-1, svgColour(l.cellColour(owners)))
+			fmt.Fprintf(&svg, `<text x="%v" y="%v" text-anchor="middle" dominant-baseline="middle" fill="%v">%v</text></g>`,
+				x+heatmapCellWidth/2, y+heatmapCellHeight/2, svgColour(l.textColour(owners)), owners)
+		}
+	}
+	svg.WriteString("</svg>\n")
+	_, err := svg.WriteTo(w)
+	return err
+}
@@ -0,0 +1,77 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"image/png"
+	"strings"
+	"testing"
+	"time"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/go-fantasy/fpl/mock"
+	"github.com/go-fantasy/fpl/server"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestHeatmapExporter(t *testing.T) {
+	table := exportTable()
+	table.Rows = append(table.Rows, server.OwnershipRow{Player: "Kane", Owners: []int{1, 0}})
+
+	var svg bytes.Buffer
+	require.Nil(t, server.HeatmapExporter{Format: grpc_fpl.ImageFormat_SVG, Top: 2}.Export(&svg, table))
+	assert.True(t, strings.HasPrefix(svg.String(), "<svg "), "Unexpected SVG %v", svg.String())
+	messi, salah := strings.Index(svg.String(), ">Messi<"), strings.Index(svg.String(), ">Salah|Mo<")
+	assert.True(t, messi > 0 && salah > messi, "Players should be sorted by total ownership")
+	assert.NotContains(t, svg.String(), "Kane", "Only the top players should be shown")
+	assert.Contains(t, svg.String(), "<title>Messi, gameweek 2: 1</title>")
+
+	var full, top bytes.Buffer
+	require.Nil(t, server.HeatmapExporter{}.Export(&full, table))
+	require.Nil(t, server.HeatmapExporter{Top: 1}.Export(&top, table))
+	fullImage, err := png.Decode(&full)
+	require.Nil(t, err)
+	topImage, err := png.Decode(&top)
+	require.Nil(t, err)
+	assert.Equal(t, fullImage.Bounds().Dx(), topImage.Bounds().Dx())
+	assert.True(t, fullImage.Bounds().Dy() > topImage.Bounds().Dy(), "A row should be drawn for each player")
+}
+
+func TestGetHeatmap(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	store := server.NewMemoryStore()
+	myFPLServer := &server.MyFPLServer{
+		Scraper:        mock_server.NewMockScraper(mockCtrl),
+		Store:          store,
+		WatchedLeagues: []int{313},
+	}
+	store.Set(&server.LeagueData{
+		LeagueCode:       313,
+		SampleSize:       10,
+		PlayerOccurances: map[int]map[string]int{1: {"Salah": 10}, 2: {"Salah": 9, "Messi": 1}},
+		FetchedAt:        time.Now(),
+	})
+
+	heatmap, err := myFPLServer.GetHeatmap(context.Background(), &grpc_fpl.HeatmapReq{LeagueCode: 313})
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, "image/png", heatmap.ContentType)
+	_, err = png.Decode(bytes.NewReader(heatmap.Image))
+	assert.Nil(t, err)
+
+	heatmap, err = myFPLServer.GetHeatmap(context.Background(), &grpc_fpl.HeatmapReq{LeagueCode: 313, Format: grpc_fpl.ImageFormat_SVG, Top: 1})
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, "image/svg+xml", heatmap.ContentType)
+	assert.Contains(t, string(heatmap.Image), "Salah")
+	assert.NotContains(t, string(heatmap.Image), "Messi")
+
+	_, err = myFPLServer.GetHeatmap(context.Background(), &grpc_fpl.HeatmapReq{LeagueCode: 313, Format: 7})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	_, err = myFPLServer.GetHeatmap(context.Background(), &grpc_fpl.HeatmapReq{LeagueCode: 313, Top: -1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
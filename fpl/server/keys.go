@@ -0,0 +1,69 @@
+package server
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+//NewKeyStore creates a key store holding keys. Every key needs a name and a value, both unique
+func NewKeyStore(keys ...APIKey) (*MemoryKeyStore, error) {
+	store := &MemoryKeyStore{
+		keys: make(map[string]APIKey, len(keys)),
+	}
+	names := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		if key.Name == "" || key.Key == "" {
+			return nil, errors.Errorf("API keys need a name and a key")
+		}
+		if names[key.Name] {
+			return nil, errors.Errorf("API key name %v is used more than once", key.Name)
+		}
+		if _, ok := store.keys[key.Key]; ok {
+			return nil, errors.Errorf("API key %v has the same key as another one", key.Name)
+		}
+		if key.Quota < 0 || key.RateLimit < 0 || key.Burst < 0 {
+			return nil, errors.Errorf("API key %v has a negative quota or rate limit", key.Name)
+		}
+		names[key.Name] = true
+		store.keys[key.Key] = key
+	}
+	return store, nil
+}
+
+//LoadKeyStore creates a key store from a JSON file holding an array of API keys, like
+//[{"name": "dashboard", "key": "...", "quota": 1000, "rateLimit": 1, "burst": 5}]
+func LoadKeyStore(file string) (*MemoryKeyStore, error) {
+	content, err := ioutil.ReadFile(file)
+	if err != nil {
+		return nil, errors.Errorf("error reading API keys from %v : %v", file, err)
+	}
+	var keys []APIKey
+	if err := json.Unmarshal(content, &keys); err != nil {
+		return nil, errors.Errorf("error parsing API keys from %v : %v", file, err)
+	}
+	return NewKeyStore(keys...)
+}
+
+//Lookup returns the API key with the given value
+func (m *MemoryKeyStore) Lookup(key string) (*APIKey, bool) {
+	apiKey, ok := m.keys[key]
+	if !ok {
+		return nil, false
+	}
+	return &apiKey, true
+}
+
+//List returns every API key, sorted by name
+func (m *MemoryKeyStore) List() []APIKey {
+	keys := make([]APIKey, 0, len(m.keys))
+	for _, key := range m.keys {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		return keys[i].Name < keys[j].Name
+	})
+	return keys
+}
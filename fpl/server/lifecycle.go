@@ -0,0 +1,228 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/keepalive"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+//DefaultShutdownTimeout is how long in-flight calls get to finish by default when the server is shut down
+const DefaultShutdownTimeout = 30 * time.Second
+
+//MinKeepaliveInterval is the shortest interval clients can ping the server at, with or without calls in flight.
+//Clients pinging more often are disconnected with GOAWAY too_many_pings
+const MinKeepaliveInterval = 30 * time.Second
+
+//Start listens on port and serves gRPC until ctx is cancelled or serving fails, see Serve
+func (s *MyFPLServer) Start(ctx context.Context, port string) error {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%v", port))
+	if err != nil {
+		return status.Errorf(codes.Internal, "error while starting server : %v", err)
+	}
+	return s.Serve(ctx, lis)
+}
+
+//Serve serves gRPC on lis until ctx is cancelled or serving fails. Cancelling ctx stops the server gracefully,
+//giving in-flight calls ShutdownTimeout to finish, and Serve only returns once they have.
+//A server can only be served once
+func (s *MyFPLServer) Serve(ctx context.Context, lis net.Listener) error {
+	serverOptions := []grpc.ServerOption{
+		//Interceptors run in order, the first one being the outermost
+		grpc.ChainUnaryInterceptor(
+			s.unaryTracingInterceptor,
+			s.unaryLoggingInterceptor,
+			s.Metrics.unaryInterceptor,
+			s.unaryAuthInterceptor,
+		),
+		grpc.ChainStreamInterceptor(
+			s.streamTracingInterceptor,
+			s.streamLoggingInterceptor,
+			s.Metrics.streamInterceptor,
+			s.streamAuthInterceptor,
+		),
+		//Clients of client.WithKeepalive ping idle connections too
+		grpc.KeepaliveEnforcementPolicy(keepalive.EnforcementPolicy{
+			MinTime:             MinKeepaliveInterval,
+			PermitWithoutStream: true,
+		}),
+	}
+	if s.TLSConfig != nil {
+		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(s.TLSConfig)))
+	}
+	grpcServer := grpc.NewServer(serverOptions...)
+	grpc_fpl.RegisterFPLServer(grpcServer, s)
+	var healthServer *health.Server
+	if s.HealthCheck {
+		healthServer = newHealthServer()
+		healthpb.RegisterHealthServer(grpcServer, healthServer)
+	}
+	if s.Reflection {
+		reflection.Register(grpcServer)
+	}
+
+	s.mu.Lock()
+	if s.grpcServer != nil {
+		s.mu.Unlock()
+		lis.Close()
+		return errors.Errorf("server has already been started")
+	}
+	s.grpcServer = grpcServer
+	s.health = healthServer
+	s.mu.Unlock()
+
+	if s.MetricsPort != "" {
+		metricsServer, err := s.startMetricsServer()
+		if err != nil {
+			lis.Close()
+			return err
+		}
+		defer metricsServer.Close()
+	}
+	if s.DashboardPort != "" {
+		dashboardServer, err := s.startDashboardServer()
+		if err != nil {
+			lis.Close()
+			return err
+		}
+		defer dashboardServer.Close()
+	}
+
+	stop := make(chan struct{})
+	defer close(stop)
+	if len(s.WatchedLeagues) > 0 && s.Store != nil {
+		go s.runScheduler(stop)
+	}
+	if healthServer != nil {
+		go s.checkUpstream(stop, healthServer)
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		s.logger(ctx).WithFields(logrus.Fields{
+			"address": lis.Addr().String(),
+			"tls":     s.TLSConfig != nil,
+		}).Info("started grpc server")
+		serveErr <- grpcServer.Serve(lis)
+	}()
+
+	select {
+	case err := <-serveErr:
+		//Serve only returns nil when the server was stopped with Stop or GracefulStop
+		if err != nil && err != grpc.ErrServerStopped {
+			s.Stop()
+			return errors.Errorf("error while serving gRPC : %v", err)
+		}
+		return nil
+	case <-ctx.Done():
+		timeout := s.ShutdownTimeout
+		if timeout <= 0 {
+			timeout = DefaultShutdownTimeout
+		}
+		s.logger(ctx).WithField("timeout", timeout.String()).Info("shutting down grpc server, waiting for in-flight calls")
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), timeout)
+		defer cancel()
+		if err := s.GracefulStop(shutdownCtx); err != nil {
+			return err
+		}
+		<-serveErr
+		return nil
+	}
+}
+
+//startMetricsServer serves the metrics on MetricsPort in the background, at /metrics
+func (s *MyFPLServer) startMetricsServer() (*http.Server, error) {
+	lis, err := net.Listen("tcp", fmt.Sprintf(":%v", s.MetricsPort))
+	if err != nil {
+		return nil, errors.Errorf("error while starting metrics server : %v", err)
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", s.Metrics.Handler())
+	metricsServer := &http.Server{Handler: mux}
+	go func() {
+		log := s.logger(context.Background()).WithField("address", lis.Addr().String())
+		log.Info("serving metrics at /metrics")
+		if err := metricsServer.Serve(lis); err != nil && err != http.ErrServerClosed {
+			log.WithError(err).Error("error while serving metrics")
+		}
+	}()
+	return metricsServer, nil
+}
+
+//Stop closes every connection and cancels in-flight calls straight away
+func (s *MyFPLServer) Stop() {
+	s.closeQuit()
+	if grpcServer := s.server(); grpcServer != nil {
+		grpcServer.Stop()
+	}
+}
+
+//GracefulStop stops accepting new calls and waits for in-flight ones to finish. Streams that never end on
+//their own, like Subscribe and GetLivePoints, are ended straight away. When ctx is done first, the connections
+//are closed so that the contexts of the calls still running are cancelled, and an error is returned without
+//waiting for them any longer
+func (s *MyFPLServer) GracefulStop(ctx context.Context) error {
+	s.closeQuit()
+	grpcServer := s.server()
+	if grpcServer == nil {
+		return nil
+	}
+	//Health checks keep being answered while in-flight calls finish, so tell the callers to go elsewhere
+	if healthServer := s.healthServer(); healthServer != nil {
+		healthServer.Shutdown()
+	}
+
+	stopped := make(chan struct{})
+	go func() {
+		grpcServer.GracefulStop()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		go grpcServer.Stop()
+		return errors.Errorf("in-flight calls were cancelled after the shutdown deadline : %v", ctx.Err())
+	}
+}
+
+func (s *MyFPLServer) server() *grpc.Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.grpcServer
+}
+
+func (s *MyFPLServer) healthServer() *health.Server {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.health
+}
+
+//quitting is closed when the server starts shutting down, so that long running streams can end
+func (s *MyFPLServer) quitting() <-chan struct{} {
+	s.quitOnce.Do(func() {
+		s.quit = make(chan struct{})
+	})
+	return s.quit
+}
+
+func (s *MyFPLServer) closeQuit() {
+	s.quitting()
+	s.stopOnce.Do(func() {
+		close(s.quit)
+	})
+}
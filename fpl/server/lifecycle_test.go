@@ -0,0 +1,95 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/go-fantasy/fpl/mock"
+	"github.com/go-fantasy/fpl/server"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestServeEndsStreamsOnCancel(t *testing.T) {
+	myFPLServer := newSubscribeServer(t)
+	lis := bufconn.Listen(1024 * 1024)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	served := make(chan error, 1)
+	go func() {
+		served <- myFPLServer.Serve(ctx, lis)
+	}()
+
+	conn, err := dialBufconn(lis)
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	stream, err := grpc_fpl.NewFPLClient(conn).Subscribe(context.Background(), &grpc_fpl.SubscribeReq{LeagueCode: 313})
+	assert.Nil(t, err)
+	_, err = stream.Recv()
+	assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+
+	cancel()
+	for err == nil {
+		_, err = stream.Recv()
+	}
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+
+	select {
+	case err := <-served:
+		assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+	case <-time.After(time.Second * 5):
+		t.Fatal("Serve didn't return after its context was cancelled")
+	}
+
+	err = myFPLServer.Serve(context.Background(), bufconn.Listen(1024))
+	assert.NotNil(t, err, "A stopped server shouldn't be served again")
+}
+
+func TestGracefulStopCancelsCallsAfterDeadline(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testObj := mock_server.NewMockScraper(mockCtrl)
+
+	entered := make(chan struct{})
+	release := make(chan struct{})
+	testObj.EXPECT().GetPlayerMapping(gomock.Any()).DoAndReturn(func(context.Context) (map[int64]string, error) {
+		close(entered)
+		<-release
+		return map[int64]string{}, nil
+	}).Times(1)
+
+	myFPLServer := &server.MyFPLServer{Scraper: testObj}
+	lis := bufconn.Listen(1024 * 1024)
+	go myFPLServer.Serve(context.Background(), lis)
+
+	conn, err := dialBufconn(lis)
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	called := make(chan error, 1)
+	go func() {
+		_, err := grpc_fpl.NewFPLClient(conn).GetNumberOfPlayers(context.Background(), &grpc_fpl.NumPlayerRequest{})
+		called <- err
+	}()
+	<-entered
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Millisecond*50)
+	defer cancel()
+	err = myFPLServer.GracefulStop(ctx)
+	assert.NotNil(t, err, "The call still running should have been given up on")
+
+	close(release)
+	<-called
+}
+
+func TestStartWithInvalidPort(t *testing.T) {
+	myFPLServer := &server.MyFPLServer{}
+	err := myFPLServer.Start(context.Background(), "-1")
+	assert.NotNil(t, err)
+}
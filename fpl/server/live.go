@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultLiveRefresh = time.Minute
+	minLiveRefresh     = 10 * time.Second
+)
+
+//GetLivePoints is the gRPC method to stream the live points and projected rank of the top managers in a league, or
+//of the entries of a cohort. The picks are fetched once, and the live points are refreshed every refreshSeconds
+//until the client goes away. A refresh that fails is logged and tried again at the next one
+func (s *MyFPLServer) GetLivePoints(req *grpc_fpl.LiveReq, stream grpc_fpl.FPL_GetLivePointsServer) error {
+	gameweek := int(req.Gameweek)
+	if gameweek < 1 || gameweek > GameweekMax {
+		return status.Errorf(codes.InvalidArgument, "gameweek %v is not between 1 and %v", gameweek, GameweekMax)
+	}
+	ctx := withLogger(stream.Context(), s.logger(stream.Context()).WithFields(logrus.Fields{
+		"league":   req.LeagueCode,
+		"gameweek": gameweek,
+		"cohort":   req.Cohort,
+	}))
+	if err := checkLeagueOrCohort(req.LeagueCode, req.Cohort); err != nil {
+		return err
+	}
+
+	playerMap, picks, err := s.groupPicks(ctx, int(req.LeagueCode), req.Cohort, gameweek, int(req.SampleSize))
+	if err != nil {
+		return err
+	}
+
+	refresh := time.Duration(req.RefreshSeconds) * time.Second
+	if refresh <= 0 {
+		refresh = defaultLiveRefresh
+	}
+	if refresh < minLiveRefresh {
+		refresh = minLiveRefresh
+	}
+	ticker := time.NewTicker(refresh)
+	defer ticker.Stop()
+
+	for {
+		livePoints, err := s.Scraper.GetLivePoints(ctx, gameweek)
+		if err != nil {
+			s.logger(ctx).WithError(err).Warnf("error while fetching live points, retrying in %v", refresh)
+		} else {
+			err = stream.Send(&grpc_fpl.LiveStandings{
+				Gameweek:  int64(gameweek),
+				Managers:  calculateLiveStandings(playerMap, picks, livePoints),
+				UpdatedAt: time.Now().Unix(),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.quitting():
+			return status.Errorf(codes.Unavailable, "server is shutting down")
+		case <-ticker.C:
+		}
+	}
+}
+
+//leaguePicks fetches the picks of the top participants of a league for a gameweek, along with the names of
+//the players
+func (s *MyFPLServer) leaguePicks(ctx context.Context, leagueCode, gameweek, sampleSize int) (map[int64]string, map[int64]*ParticipantTeamInfo, error) {
+	playerMap, err := s.Scraper.GetPlayerMapping(ctx)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "error while getting player mapping : %v", err)
+	}
+
+	participants, err := s.Scraper.GetParticipantsInLeague(ctx, leagueCode)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "error in GetParticipantsInLeague : %v", err)
+	}
+	topLeagueParticipants := topParticipants(participants, sampleSize)
+
+	picks, err := s.Scraper.GetPicksForParticipants(ctx, gameweek, &topLeagueParticipants)
+	if err != nil {
+		return nil, nil, status.Errorf(codes.Internal, "error while fetching picks for gameweek %v : %v", gameweek, err)
+	}
+	return playerMap, picks, nil
+}
+
+//captaincy counts how many managers captained each player
+func captaincy(playerMap map[int64]string, picks map[int64]*ParticipantTeamInfo) map[string]int {
+	captains := make(map[string]int)
+	for _, teamInfo := range picks {
+		for _, player := range teamInfo.TeamPlayers {
+			if player.IsCaptain {
+				captains[playerMap[player.Element]]++
+			}
+		}
+	}
+	return captains
+}
+
+//calculateLiveStandings works out the live gameweek score of every manager and orders them by their projected total.
+//Automatic substitutions are not applied, so benched players only count when a chip gives them a multiplier
+func calculateLiveStandings(playerMap map[int64]string, picks map[int64]*ParticipantTeamInfo, livePoints map[int64]int) []*grpc_fpl.LiveManager {
+	var managers []*grpc_fpl.LiveManager
+	previousTotals := make(map[int64]int64)
+
+	for entry, teamInfo := range picks {
+		manager := &grpc_fpl.LiveManager{Entry: entry}
+		for _, player := range teamInfo.TeamPlayers {
+			manager.LivePoints += int64(livePoints[player.Element] * player.Multiplier)
+			if player.IsCaptain {
+				manager.Captain = playerMap[player.Element]
+			}
+		}
+		manager.LivePoints -= int64(teamInfo.EntryHistory.EventTransfersCost)
+
+		//The total of the gameweek already has the transfer cost taken off, unlike its points
+		history := teamInfo.EntryHistory
+		previousTotals[entry] = int64(history.TotalPoints - history.Points + history.EventTransfersCost)
+		manager.TotalPoints = previousTotals[entry] + manager.LivePoints
+		managers = append(managers, manager)
+	}
+
+	sort.Slice(managers, func(i, j int) bool {
+		if previousTotals[managers[i].Entry] != previousTotals[managers[j].Entry] {
+			return previousTotals[managers[i].Entry] > previousTotals[managers[j].Entry]
+		}
+		return managers[i].Entry < managers[j].Entry
+	})
+	for rank, manager := range managers {
+		manager.PreviousRank = int64(rank + 1)
+	}
+
+	sort.Slice(managers, func(i, j int) bool {
+		if managers[i].TotalPoints != managers[j].TotalPoints {
+			return managers[i].TotalPoints > managers[j].TotalPoints
+		}
+		return managers[i].PreviousRank < managers[j].PreviousRank
+	})
+	for rank, manager := range managers {
+		manager.LiveRank = int64(rank + 1)
+	}
+
+	return managers
+}
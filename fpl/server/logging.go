@@ -0,0 +1,143 @@
+package server
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"os"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+//RequestIDHeader is the gRPC metadata key of the request id. Calls without one are given a random id
+const RequestIDHeader = "x-request-id"
+
+type loggerKey struct{}
+
+//NewLogger creates a logger writing to stderr at level (debug, info, warn or error), formatted as text or json
+func NewLogger(level, format string) (*logrus.Logger, error) {
+	logLevel, err := logrus.ParseLevel(level)
+	if err != nil {
+		return nil, errors.Errorf("invalid log level %v : %v", level, err)
+	}
+
+	logger := logrus.New()
+	logger.Out = os.Stderr
+	logger.Level = logLevel
+	switch format {
+	case "json":
+		logger.Formatter = &logrus.JSONFormatter{}
+	case "text", "":
+		logger.Formatter = &logrus.TextFormatter{FullTimestamp: true}
+	default:
+		return nil, errors.Errorf("invalid log format %v, it should be text or json", format)
+	}
+	return logger, nil
+}
+
+//withLogger returns a copy of ctx carrying log, so that everything done for a call logs with its fields
+func withLogger(ctx context.Context, log logrus.FieldLogger) context.Context {
+	return context.WithValue(ctx, loggerKey{}, log)
+}
+
+//loggerFrom returns the logger carried by ctx, or fallback when there is none
+func loggerFrom(ctx context.Context, fallback logrus.FieldLogger) logrus.FieldLogger {
+	if log, ok := ctx.Value(loggerKey{}).(logrus.FieldLogger); ok {
+		return log
+	}
+	if fallback != nil {
+		return fallback
+	}
+	return logrus.StandardLogger()
+}
+
+func (s *MyFPLServer) logger(ctx context.Context) logrus.FieldLogger {
+	return loggerFrom(ctx, s.Logger)
+}
+
+func (s *MyFPLScraper) logger(ctx context.Context) logrus.FieldLogger {
+	return loggerFrom(ctx, s.Logger)
+}
+
+//sharedContext is the context of work shared between calls, like a scrape in singleflight. It logs with the
+//fields of ctx and continues its trace, but isn't cancelled with it, so that a caller going away doesn't fail the others
+func (s *MyFPLServer) sharedContext(ctx context.Context) context.Context {
+	shared := withLogger(context.Background(), s.logger(ctx))
+	return trace.ContextWithSpan(shared, trace.SpanFromContext(ctx))
+}
+
+//unaryLoggingInterceptor and streamLoggingInterceptor give every call a logger with its request id and method,
+//and log how each call ended
+func (s *MyFPLServer) unaryLoggingInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	ctx, log := s.callLogger(ctx, info.FullMethod)
+	resp, err := handler(ctx, req)
+	logCall(log, err, start)
+	return resp, err
+}
+
+func (s *MyFPLServer) streamLoggingInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	ctx, log := s.callLogger(stream.Context(), info.FullMethod)
+	err := handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	logCall(log, err, start)
+	return err
+}
+
+func (s *MyFPLServer) callLogger(ctx context.Context, method string) (context.Context, logrus.FieldLogger) {
+	var requestID string
+	if md, ok := metadata.FromIncomingContext(ctx); ok && len(md.Get(RequestIDHeader)) > 0 {
+		requestID = md.Get(RequestIDHeader)[0]
+	} else {
+		requestID = newRequestID()
+	}
+	fields := logrus.Fields{
+		"request_id": requestID,
+		"method":     method,
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.IsValid() {
+		fields["trace_id"] = spanContext.TraceID().String()
+	}
+	log := s.logger(ctx).WithFields(fields)
+	return withLogger(ctx, log), log
+}
+
+func logCall(log logrus.FieldLogger, err error, start time.Time) {
+	log = log.WithFields(logrus.Fields{
+		"code":     status.Code(err).String(),
+		"duration": time.Since(start).String(),
+	})
+	switch status.Code(err) {
+	case codes.OK, codes.Canceled:
+		log.Info("finished call")
+	case codes.Internal, codes.Unknown, codes.DataLoss:
+		log.WithError(err).Error("finished call")
+	default:
+		log.WithError(err).Warn("finished call")
+	}
+}
+
+func newRequestID() string {
+	id := make([]byte, 8)
+	if _, err := rand.Read(id); err != nil {
+		return "unknown"
+	}
+	return hex.EncodeToString(id)
+}
+
+//contextStream replaces the context of a stream, so that interceptors can pass values down to the handler
+type contextStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (c *contextStream) Context() context.Context {
+	return c.ctx
+}
@@ -0,0 +1,67 @@
+package server_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/go-fantasy/fpl/mock"
+	"github.com/go-fantasy/fpl/server"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestNewLogger(t *testing.T) {
+	_, err := server.NewLogger("debug", "json")
+	assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+
+	_, err = server.NewLogger("loud", "json")
+	assert.NotNil(t, err)
+	_, err = server.NewLogger("info", "xml")
+	assert.NotNil(t, err)
+}
+
+func TestLoggingInterceptor(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testObj := mock_server.NewMockScraper(mockCtrl)
+	testObj.EXPECT().GetParticipantsInLeague(gomock.Any(), 313).Return(&[]int64{1, 2}, nil).Times(1)
+
+	var out bytes.Buffer
+	logger, err := server.NewLogger("info", "json")
+	assert.Nil(t, err)
+	logger.Out = &out
+
+	myFPLServer := &server.MyFPLServer{Scraper: testObj, Logger: logger}
+	lis := bufconn.Listen(1024 * 1024)
+	go myFPLServer.Serve(context.Background(), lis)
+	defer myFPLServer.Stop()
+
+	conn, err := dialBufconn(lis)
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	ctx := metadata.AppendToOutgoingContext(context.Background(), server.RequestIDHeader, "abc123")
+	_, err = grpc_fpl.NewFPLClient(conn).GetParticipantsInLeague(ctx, &grpc_fpl.LeagueCode{LeagueCode: 313})
+	assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+
+	var finished map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(out.String()), "\n") {
+		var entry map[string]interface{}
+		assert.Nil(t, json.Unmarshal([]byte(line), &entry), "Log line %v should be json", line)
+		if entry["msg"] == "finished call" {
+			finished = entry
+		}
+	}
+	if assert.NotNil(t, finished, "The call should have been logged") {
+		assert.Equal(t, "abc123", finished["request_id"])
+		assert.Equal(t, "/grpc.FPL/GetParticipantsInLeague", finished["method"])
+		assert.Equal(t, "OK", finished["code"])
+		assert.Equal(t, "info", finished["level"])
+	}
+}
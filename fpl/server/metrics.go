@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/status"
+)
+
+//NewMetrics creates the metrics of a server in their own registry, along with the Go runtime and process metrics
+func NewMetrics() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		rpcRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "fpl_grpc_requests_total",
+			Help: "gRPC calls handled, by method and status code.",
+		}, []string{"method", "code"}),
+		rpcDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "fpl_grpc_request_duration_seconds",
+			Help:    "Time taken to handle gRPC calls, by method. Streams are timed until they end.",
+			Buckets: prometheus.ExponentialBuckets(0.005, 4, 9),
+		}, []string{"method"}),
+		upstreamRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "fpl_upstream_requests_total",
+			Help: "Requests made to the FPL site, by endpoint and HTTP status, or error when no response came back.",
+		}, []string{"endpoint", "status"}),
+		scrapeDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Name:    "fpl_gameweek_scrape_duration_seconds",
+			Help:    "Time taken to fetch the picks of the top participants of a league for a gameweek.",
+			Buckets: prometheus.ExponentialBuckets(0.1, 2, 9),
+		}, []string{"gameweek"}),
+		cacheRequests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: "fpl_cache_requests_total",
+			Help: "Lookups of league data in the store, by result (hit or miss).",
+		}, []string{"result"}),
+	}
+	m.registry.MustRegister(
+		m.rpcRequests,
+		m.rpcDuration,
+		m.upstreamRequests,
+		m.scrapeDuration,
+		m.cacheRequests,
+		prometheus.NewGoCollector(),
+		prometheus.NewProcessCollector(prometheus.ProcessCollectorOpts{}),
+	)
+	return m
+}
+
+//Handler serves the metrics in the Prometheus text format
+func (m *Metrics) Handler() http.Handler {
+	if m == nil {
+		return http.NotFoundHandler()
+	}
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+func (m *Metrics) observeRPC(method string, err error, start time.Time) {
+	if m == nil {
+		return
+	}
+	m.rpcRequests.WithLabelValues(method, status.Code(err).String()).Inc()
+	m.rpcDuration.WithLabelValues(method).Observe(time.Since(start).Seconds())
+}
+
+func (m *Metrics) observeUpstream(URL string, resp *http.Response) {
+	if m == nil {
+		return
+	}
+	statusCode := "error"
+	if resp != nil {
+		statusCode = strconv.Itoa(resp.StatusCode)
+	}
+	m.upstreamRequests.WithLabelValues(endpoint(URL), statusCode).Inc()
+}
+
+func (m *Metrics) observeScrape(gameweek int, start time.Time) {
+	if m == nil {
+		return
+	}
+	m.scrapeDuration.WithLabelValues(strconv.Itoa(gameweek)).Observe(time.Since(start).Seconds())
+}
+
+func (m *Metrics) observeCache(hit bool) {
+	if m == nil {
+		return
+	}
+	result := "miss"
+	if hit {
+		result = "hit"
+	}
+	m.cacheRequests.WithLabelValues(result).Inc()
+}
+
+//unaryInterceptor and streamInterceptor time every gRPC call and count it by status code
+func (m *Metrics) unaryInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	start := time.Now()
+	resp, err := handler(ctx, req)
+	m.observeRPC(info.FullMethod, err, start)
+	return resp, err
+}
+
+func (m *Metrics) streamInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	start := time.Now()
+	err := handler(srv, stream)
+	m.observeRPC(info.FullMethod, err, start)
+	return err
+}
+
+//endpoint turns a URL of the FPL site into a label, replacing ids with :id so that every league, entry
+//and gameweek share the same label
+func endpoint(URL string) string {
+	parsedURL, err := url.Parse(URL)
+	if err != nil {
+		return "unknown"
+	}
+	segments := strings.Split(parsedURL.Path, "/")
+	for i, segment := range segments {
+		if _, err := strconv.Atoi(segment); err == nil {
+			segments[i] = ":id"
+		}
+	}
+	return strings.Join(segments, "/")
+}
@@ -0,0 +1,77 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/go-fantasy/fpl/mock"
+	"github.com/go-fantasy/fpl/server"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func scrapeMetrics(t *testing.T, metrics *server.Metrics) string {
+	recorder := httptest.NewRecorder()
+	metrics.Handler().ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	return recorder.Body.String()
+}
+
+func TestMetricsForRPCs(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testObj := mock_server.NewMockScraper(mockCtrl)
+	testObj.EXPECT().GetPlayerMapping(gomock.Any()).Return(map[int64]string{267: "Messi"}, nil).Times(1)
+	testObj.EXPECT().GetParticipantsInLeague(gomock.Any(), 313).Return(nil, assert.AnError).Times(1)
+
+	metrics := server.NewMetrics()
+	myFPLServer := &server.MyFPLServer{Scraper: testObj, Metrics: metrics}
+	lis := bufconn.Listen(1024 * 1024)
+	go myFPLServer.Serve(context.Background(), lis)
+	defer myFPLServer.Stop()
+
+	conn, err := dialBufconn(lis)
+	assert.Nil(t, err)
+	defer conn.Close()
+	client := grpc_fpl.NewFPLClient(conn)
+
+	_, err = client.GetNumberOfPlayers(context.Background(), &grpc_fpl.NumPlayerRequest{})
+	assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+	_, err = client.GetParticipantsInLeague(context.Background(), &grpc_fpl.LeagueCode{LeagueCode: 313})
+	assert.NotNil(t, err)
+
+	body := scrapeMetrics(t, metrics)
+	assert.Contains(t, body, `fpl_grpc_requests_total{code="OK",method="/grpc.FPL/GetNumberOfPlayers"} 1`)
+	assert.Contains(t, body, `fpl_grpc_requests_total{code="Internal",method="/grpc.FPL/GetParticipantsInLeague"} 1`)
+	assert.Contains(t, body, `fpl_grpc_request_duration_seconds_count{method="/grpc.FPL/GetNumberOfPlayers"} 1`)
+	assert.Contains(t, body, "go_goroutines")
+}
+
+func TestMetricsForUpstreamRequests(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/drf/entry/42/event/3/picks" {
+			w.Write([]byte("{}"))
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer ts.Close()
+
+	metrics := server.NewMetrics()
+	client := &server.MyFPLClient{HttpClient: ts.Client(), Metrics: metrics}
+
+	for _, URL := range []string{"/drf/entry/42/event/3/picks", "/drf/entry/43/event/3/picks", "/drf/leagues-classic-standings/313"} {
+		body, err := client.MakeRequest(context.Background(), ts.URL+URL)
+		assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+		assert.NotNil(t, body)
+	}
+
+	body := scrapeMetrics(t, metrics)
+	assert.Contains(t, body, `fpl_upstream_requests_total{endpoint="/drf/entry/:id/event/:id/picks",status="200"} 1`)
+	assert.Contains(t, body, `fpl_upstream_requests_total{endpoint="/drf/entry/:id/event/:id/picks",status="404"} 1`)
+	assert.Contains(t, body, `fpl_upstream_requests_total{endpoint="/drf/leagues-classic-standings/:id",status="404"} 1`)
+}
@@ -0,0 +1,131 @@
+package server
+
+import (
+	"crypto/tls"
+	"time"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+)
+
+//Option configures the server created by New
+type Option func(*MyFPLServer)
+
+//WithStore sets the store used to cache scraped league data
+func WithStore(store Store) Option {
+	return func(s *MyFPLServer) {
+		s.Store = store
+	}
+}
+
+//WithWatchedLeagues sets the leagues that the background scheduler keeps fresh
+func WithWatchedLeagues(leagueCodes ...int) Option {
+	return func(s *MyFPLServer) {
+		s.WatchedLeagues = leagueCodes
+	}
+}
+
+//WithCacheTTL sets how long data for a league that isn't watched is served from the store
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(s *MyFPLServer) {
+		s.CacheTTL = ttl
+	}
+}
+
+//WithRefreshInterval sets how often leagues with subscribers are refreshed
+func WithRefreshInterval(interval time.Duration) Option {
+	return func(s *MyFPLServer) {
+		s.RefreshInterval = interval
+	}
+}
+
+//WithShutdownTimeout sets how long in-flight calls get to finish when the server is shut down
+func WithShutdownTimeout(timeout time.Duration) Option {
+	return func(s *MyFPLServer) {
+		s.ShutdownTimeout = timeout
+	}
+}
+
+//WithMetricsPort serves the metrics of the server to Prometheus on port, next to the gRPC server
+func WithMetricsPort(port string) Option {
+	return func(s *MyFPLServer) {
+		s.MetricsPort = port
+	}
+}
+
+//WithDashboardPort serves the web dashboard on port, next to the gRPC server
+func WithDashboardPort(port string) Option {
+	return func(s *MyFPLServer) {
+		s.DashboardPort = port
+	}
+}
+
+//WithLogger sets the logger of the server, which every call adds its own fields to
+func WithLogger(logger logrus.FieldLogger) Option {
+	return func(s *MyFPLServer) {
+		s.Logger = logger
+	}
+}
+
+//WithTracerProvider sets the provider creating the spans of every call
+func WithTracerProvider(provider trace.TracerProvider) Option {
+	return func(s *MyFPLServer) {
+		s.TracerProvider = provider
+	}
+}
+
+//WithHealthCheck registers the gRPC health service or not, and sets how often the FPL site is probed and how long
+//it can be unreachable before the server reports NOT_SERVING. Zero durations keep the defaults
+func WithHealthCheck(enabled bool, interval, unhealthyAfter time.Duration) Option {
+	return func(s *MyFPLServer) {
+		s.HealthCheck = enabled
+		s.HealthCheckInterval = interval
+		s.UnhealthyAfter = unhealthyAfter
+	}
+}
+
+//WithReflection registers gRPC server reflection or not
+func WithReflection(enabled bool) Option {
+	return func(s *MyFPLServer) {
+		s.Reflection = enabled
+	}
+}
+
+//WithTLSConfig serves gRPC over TLS with config, see LoadTLSConfig
+func WithTLSConfig(config *tls.Config) Option {
+	return func(s *MyFPLServer) {
+		s.TLSConfig = config
+	}
+}
+
+//WithKeyStore makes callers authenticate with one of the API keys of store, see LoadKeyStore
+func WithKeyStore(store KeyStore) Option {
+	return func(s *MyFPLServer) {
+		s.KeyStore = store
+	}
+}
+
+//WithCohorts sets the cohorts RPCs can look at instead of a league, see LoadCohortStore
+func WithCohorts(store CohortStore) Option {
+	return func(s *MyFPLServer) {
+		s.Cohorts = store
+	}
+}
+
+//WithQuotaPeriod sets the period API key quotas are counted over
+func WithQuotaPeriod(period time.Duration) Option {
+	return func(s *MyFPLServer) {
+		s.QuotaPeriod = period
+	}
+}
+
+//WithExporter sends the data of GetDataForAllGameweeks requested in format with exporter
+func WithExporter(format grpc_fpl.ExportFormat, exporter Exporter) Option {
+	return func(s *MyFPLServer) {
+		if s.Exporters == nil {
+			s.Exporters = make(map[grpc_fpl.ExportFormat]Exporter)
+		}
+		s.Exporters[format] = exporter
+	}
+}
@@ -0,0 +1,268 @@
+package server
+
+import (
+	"bytes"
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+//ParquetExporter writes a Parquet file with the league_code, cohort, gameweek, player and owners columns, the
+//cohort being empty for leagues. It has a row per player and gameweek rather than a column per gameweek, so that
+//its schema doesn't change as the season goes on, and a single uncompressed row group
+type ParquetExporter struct{}
+
+func (ParquetExporter) ContentType() string {
+	return "application/vnd.apache.parquet"
+}
+
+func (ParquetExporter) Export(w io.Writer, table *OwnershipTable) error {
+	leagueCodes := &parquetColumn{name: "league_code", physicalType: parquetInt64}
+	cohorts := &parquetColumn{name: "cohort", physicalType: parquetByteArray, utf8: true}
+	gameweeks := &parquetColumn{name: "gameweek", physicalType: parquetInt32}
+	players := &parquetColumn{name: "player", physicalType: parquetByteArray, utf8: true}
+	owners := &parquetColumn{name: "owners", physicalType: parquetInt32}
+	numRows := 0
+	for _, row := range table.Rows {
+		for i, gameweek := range table.Gameweeks {
+			leagueCodes.appendInt64(int64(table.LeagueCode))
+			cohorts.appendByteArray(table.Cohort)
+			gameweeks.appendInt32(int32(gameweek))
+			players.appendByteArray(row.Player)
+			owners.appendInt32(int32(row.Owners[i]))
+			numRows++
+		}
+	}
+	if err := writeParquet(w, numRows, leagueCodes, cohorts, gameweeks, players, owners); err != nil {
+		return errors.Errorf("error writing parquet : %v", err)
+	}
+	return nil
+}
+
+//Values of the enums of the Parquet metadata
+const (
+	parquetInt32     = 1
+	parquetInt64     = 2
+	parquetByteArray = 6
+
+	parquetRequired     = 0
+	parquetUTF8         = 0
+	parquetPlain        = 0
+	parquetRLE          = 3
+	parquetUncompressed = 0
+	parquetDataPage     = 0
+)
+
+var parquetMagic = []byte("PAR1")
+
+//parquetColumn is a required column, with its values encoded PLAIN
+type parquetColumn struct {
+	name         string
+	physicalType int32
+	utf8         bool
+	numValues    int
+	values       bytes.Buffer
+}
+
+func (c *parquetColumn) appendInt32(value int32) {
+	binary.Write(&c.values, binary.LittleEndian, value)
+	c.numValues++
+}
+
+func (c *parquetColumn) appendInt64(value int64) {
+	binary.Write(&c.values, binary.LittleEndian, value)
+	c.numValues++
+}
+
+func (c *parquetColumn) appendByteArray(value string) {
+	binary.Write(&c.values, binary.LittleEndian, uint32(len(value)))
+	c.values.WriteString(value)
+	c.numValues++
+}
+
+//countingWriter keeps track of the offset in the file being written
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+//writeParquet writes columns as a single row group, each column in a single data page
+func writeParquet(w io.Writer, numRows int, columns ...*parquetColumn) error {
+	file := &countingWriter{w: w}
+	if _, err := file.Write(parquetMagic); err != nil {
+		return err
+	}
+
+	offsets := make([]int64, len(columns))
+	sizes := make([]int64, len(columns))
+	var totalSize int64
+	for i, column := range columns {
+		header := &compactWriter{}
+		header.beginStruct()
+		header.i32Field(1, parquetDataPage)
+		header.i32Field(2, int32(column.values.Len()))
+		header.i32Field(3, int32(column.values.Len()))
+		header.structField(5)
+		header.i32Field(1, int32(column.numValues))
+		header.i32Field(2, parquetPlain)
+		header.i32Field(3, parquetRLE)
+		header.i32Field(4, parquetRLE)
+		header.endStruct()
+		header.endStruct()
+
+		offsets[i] = file.n
+		sizes[i] = int64(header.Len() + column.values.Len())
+		totalSize += sizes[i]
+		if _, err := file.Write(header.Bytes()); err != nil {
+			return err
+		}
+		if _, err := file.Write(column.values.Bytes()); err != nil {
+			return err
+		}
+	}
+
+	footer := &compactWriter{}
+	footer.beginStruct()
+	footer.i32Field(1, 1)
+	footer.listField(2, compactStruct, len(columns)+1)
+	footer.beginStruct()
+	footer.binaryField(4, "schema")
+	footer.i32Field(5, int32(len(columns)))
+	footer.endStruct()
+	for _, column := range columns {
+		footer.beginStruct()
+		footer.i32Field(1, column.physicalType)
+		footer.i32Field(3, parquetRequired)
+		footer.binaryField(4, column.name)
+		if column.utf8 {
+			footer.i32Field(6, parquetUTF8)
+		}
+		footer.endStruct()
+	}
+	footer.i64Field(3, int64(numRows))
+	footer.listField(4, compactStruct, 1)
+	footer.beginStruct()
+	footer.listField(1, compactStruct, len(columns))
+	for i, column := range columns {
+		footer.beginStruct()
+		footer.i64Field(2, offsets[i])
+		footer.structField(3)
+		footer.i32Field(1, column.physicalType)
+		footer.listField(2, compactI32, 2)
+		footer.writeVarint(zigzag(parquetPlain))
+		footer.writeVarint(zigzag(parquetRLE))
+		footer.listField(3, compactBinary, 1)
+		footer.writeBinary(column.name)
+		footer.i32Field(4, parquetUncompressed)
+		footer.i64Field(5, int64(column.numValues))
+		footer.i64Field(6, sizes[i])
+		footer.i64Field(7, sizes[i])
+		footer.i64Field(9, offsets[i])
+		footer.endStruct()
+		footer.endStruct()
+	}
+	footer.i64Field(2, totalSize)
+	footer.i64Field(3, int64(numRows))
+	footer.endStruct()
+	footer.binaryField(6, "go-fantasy")
+	footer.endStruct()
+
+	if _, err := file.Write(footer.Bytes()); err != nil {
+		return err
+	}
+	if err := binary.Write(file, binary.LittleEndian, uint32(footer.Len())); err != nil {
+		return err
+	}
+	_, err := file.Write(parquetMagic)
+	return err
+}
+
+//Types of the thrift compact protocol
+const (
+	compactI32    = 5
+	compactI64    = 6
+	compactBinary = 8
+	compactList   = 9
+	compactStruct = 12
+)
+
+//compactWriter encodes thrift structs with the compact protocol, which the Parquet metadata is written in.
+//Every struct, nested or in a list, starts with beginStruct and ends with endStruct
+type compactWriter struct {
+	bytes.Buffer
+	lastFields []int16
+}
+
+func zigzag(n int64) uint64 {
+	return uint64((n << 1) ^ (n >> 63))
+}
+
+func (c *compactWriter) writeVarint(n uint64) {
+	var buf [binary.MaxVarintLen64]byte
+	c.Write(buf[:binary.PutUvarint(buf[:], n)])
+}
+
+func (c *compactWriter) writeBinary(value string) {
+	c.writeVarint(uint64(len(value)))
+	c.WriteString(value)
+}
+
+func (c *compactWriter) beginStruct() {
+	c.lastFields = append(c.lastFields, 0)
+}
+
+func (c *compactWriter) endStruct() {
+	c.WriteByte(0)
+	c.lastFields = c.lastFields[:len(c.lastFields)-1]
+}
+
+//fieldHeader writes the id of a field as a delta from the previous field of the struct when it can
+func (c *compactWriter) fieldHeader(id int16, fieldType byte) {
+	last := &c.lastFields[len(c.lastFields)-1]
+	if delta := id - *last; delta > 0 && delta <= 15 {
+		c.WriteByte(byte(delta)<<4 | fieldType)
+	} else {
+		c.WriteByte(fieldType)
+		c.writeVarint(zigzag(int64(id)))
+	}
+	*last = id
+}
+
+func (c *compactWriter) i32Field(id int16, value int32) {
+	c.fieldHeader(id, compactI32)
+	c.writeVarint(zigzag(int64(value)))
+}
+
+func (c *compactWriter) i64Field(id int16, value int64) {
+	c.fieldHeader(id, compactI64)
+	c.writeVarint(zigzag(value))
+}
+
+func (c *compactWriter) binaryField(id int16, value string) {
+	c.fieldHeader(id, compactBinary)
+	c.writeBinary(value)
+}
+
+//structField starts a nested struct, ended with endStruct
+func (c *compactWriter) structField(id int16) {
+	c.fieldHeader(id, compactStruct)
+	c.beginStruct()
+}
+
+//listField starts a list of size elements of elementType, which are written next
+func (c *compactWriter) listField(id int16, elementType byte, size int) {
+	c.fieldHeader(id, compactList)
+	if size < 15 {
+		c.WriteByte(byte(size)<<4 | elementType)
+		return
+	}
+	c.WriteByte(0xf0 | elementType)
+	c.writeVarint(uint64(size))
+}
@@ -0,0 +1,118 @@
+package server
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+//DefaultCacheTTL is how long data for a league that isn't watched is served from the store by default
+const DefaultCacheTTL = 10 * time.Minute
+
+const (
+	//idleRefreshInterval is the longest the scheduler sleeps when nothing is happening
+	idleRefreshInterval = 6 * time.Hour
+	//deadlineRefreshInterval applies in the run up to a deadline, and just after it when the picks are revealed
+	deadlineRefreshInterval = 15 * time.Minute
+	//matchRefreshInterval applies while matches are being played, and for a while after they finish
+	matchRefreshInterval = 5 * time.Minute
+
+	//maxWatchedAge is how long watched leagues are served from the store, a few of the longest waits of the
+	//scheduler, so that they are scraped again when its refreshes keep failing
+	maxWatchedAge = 3 * idleRefreshInterval
+
+	beforeDeadlineWindow = 6 * time.Hour
+	afterDeadlineWindow  = time.Hour
+	afterKickoffWindow   = 4 * time.Hour
+)
+
+//runScheduler refreshes every watched league in the store until stop is closed,
+//waiting between refreshes for as long as NextRefresh says based on the FPL calendar
+func (s *MyFPLServer) runScheduler(stop <-chan struct{}) {
+	ctx := withLogger(context.Background(), s.logger(context.Background()).WithField("job", "scheduler"))
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-stop:
+			return
+		case <-timer.C:
+		}
+
+		for _, leagueCode := range s.WatchedLeagues {
+			leagueCtx := withLogger(ctx, s.logger(ctx).WithField("league", leagueCode))
+			leagueCtx, span := s.tracer().Start(leagueCtx, "refreshWatchedLeague", trace.WithAttributes(attribute.Int("league", leagueCode)))
+			_, err := s.refreshLeagueData(leagueCtx, leagueCode, defaultSampleSize)
+			endSpan(span, err)
+			if err != nil {
+				s.logger(leagueCtx).WithError(err).Warn("error while refreshing watched league")
+			}
+		}
+
+		wait, err := s.nextRefresh(ctx, time.Now())
+		if err != nil {
+			s.logger(ctx).WithError(err).Warnf("error while working out the next refresh, retrying in %v", deadlineRefreshInterval)
+			wait = deadlineRefreshInterval
+		}
+		s.logger(ctx).WithFields(logrus.Fields{
+			"leagues":      len(s.WatchedLeagues),
+			"next_refresh": wait.String(),
+		}).Info("refreshed watched leagues")
+		timer.Reset(wait)
+	}
+}
+
+func (s *MyFPLServer) nextRefresh(ctx context.Context, now time.Time) (time.Duration, error) {
+	events, err := s.Scraper.GetEvents(ctx)
+	if err != nil {
+		return 0, err
+	}
+
+	var fixtures []Fixture
+	if gameweek := currentGameweek(events); gameweek > 0 {
+		fixtures, err = s.Scraper.GetFixtures(ctx, gameweek)
+		if err != nil {
+			return 0, err
+		}
+	}
+	return NextRefresh(events, fixtures, now), nil
+}
+
+//NextRefresh works out how long to wait before refreshing watched leagues again. Refreshes are frequent while
+//the matches of the current gameweek are played and around deadlines, otherwise the scheduler idles until
+//the next deadline or kickoff comes close
+func NextRefresh(events []Event, fixtures []Fixture, now time.Time) time.Duration {
+	wait := idleRefreshInterval
+
+	for _, fixture := range fixtures {
+		if fixture.KickoffTime.IsZero() {
+			continue
+		}
+		sinceKickoff := now.Sub(fixture.KickoffTime)
+		if (fixture.Started && !fixture.Finished) || (sinceKickoff >= 0 && sinceKickoff < afterKickoffWindow) {
+			return matchRefreshInterval
+		}
+		if sinceKickoff < 0 && -sinceKickoff < wait {
+			wait = -sinceKickoff
+		}
+	}
+
+	for _, event := range events {
+		untilDeadline := event.DeadlineTime.Sub(now)
+		if untilDeadline < beforeDeadlineWindow && untilDeadline > -afterDeadlineWindow {
+			return deadlineRefreshInterval
+		}
+		if untilDeadline > 0 && untilDeadline-beforeDeadlineWindow < wait {
+			wait = untilDeadline - beforeDeadlineWindow
+		}
+	}
+
+	if wait < deadlineRefreshInterval {
+		wait = deadlineRefreshInterval
+	}
+	return wait
+}
@@ -0,0 +1,119 @@
+package server_test
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/go-fantasy/fpl/mock"
+	"github.com/go-fantasy/fpl/server"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNextRefresh(t *testing.T) {
+	now := time.Date(2018, 10, 20, 12, 0, 0, 0, time.UTC)
+	events := []server.Event{
+		{ID: 9, DeadlineTime: now.Add(-7 * 24 * time.Hour), Finished: true},
+		{ID: 10, DeadlineTime: now.Add(-90 * time.Minute), IsCurrent: true},
+		{ID: 11, DeadlineTime: now.Add(7 * 24 * time.Hour), IsNext: true},
+	}
+
+	//A match is being played
+	fixtures := []server.Fixture{
+		{KickoffTime: now.Add(-30 * time.Minute), Started: true},
+		{KickoffTime: now.Add(26 * time.Hour)},
+	}
+	assert.Equal(t, 5*time.Minute, server.NextRefresh(events, fixtures, now))
+
+	//Just after the deadline, before the first kickoff
+	fixtures = []server.Fixture{
+		{KickoffTime: now.Add(2 * time.Hour)},
+	}
+	assert.Equal(t, 15*time.Minute, server.NextRefresh(events[:2], fixtures, now.Add(-60*time.Minute)))
+
+	//Between matches the scheduler sleeps until the next kickoff
+	assert.Equal(t, 2*time.Hour, server.NextRefresh(events, fixtures, now))
+
+	//Once the gameweek is played it idles, but wakes up ahead of the next deadline
+	fixtures = []server.Fixture{
+		{KickoffTime: now.Add(-3 * 24 * time.Hour), Started: true, Finished: true},
+	}
+	assert.Equal(t, 6*time.Hour, server.NextRefresh(events, fixtures, now))
+	assert.Equal(t, 3*time.Hour, server.NextRefresh(events, fixtures, now.Add(7*24*time.Hour-9*time.Hour)))
+	assert.Equal(t, 15*time.Minute, server.NextRefresh(events, fixtures, now.Add(7*24*time.Hour-5*time.Hour)))
+}
+
+func TestGetDataForAllGameweeksFromStore(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	//Nothing is scraped for a watched league, the scheduler keeps it in the store
+	testObj := mock_server.NewMockScraper(mockCtrl)
+	store := server.NewMemoryStore()
+	myFPLServer := &server.MyFPLServer{
+		Scraper:        testObj,
+		Store:          store,
+		WatchedLeagues: []int{313},
+	}
+
+	playerOccurances := map[int]map[string]int{
+		1: {"Salah": 10},
+		2: {"Salah": 9},
+	}
+	store.Set(&server.LeagueData{
+		LeagueCode:       313,
+		SampleSize:       10,
+		PlayerOccurances: playerOccurances,
+		FetchedAt:        time.Now().Add(-5 * time.Hour),
+	})
+
+	stream := &mockStream{}
+	err := myFPLServer.GetDataForAllGameweeks(&grpc_fpl.AllGameweeksReq{LeagueCode: 313}, stream)
+	assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.True(t, strings.HasSuffix(string(stream.data), "Player,Gameweek 1,Gameweek 2,Total,Average,Peak gameweek\nSalah,10,9,19,9.50,1\n"),
+		"The stored data should be sent, not %v", string(stream.data))
+
+	playerOccurance, err := myFPLServer.GetDataForGameweek(context.Background(), &grpc_fpl.GameweekReq{LeagueCode: 313, Gameweek: 2})
+	assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, int32(9), playerOccurance.PlayerOccurance["Salah"])
+}
+
+func TestWatchedLeagueStaleData(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	//The scheduler doesn't refresh other sample sizes, and data it failed to refresh for long enough is stale
+	testObj := mock_server.NewMockScraper(mockCtrl)
+	testObj.EXPECT().GetPlayerMapping(gomock.Any()).Return(map[int64]string{267: "Messi"}, nil).Times(2)
+	testObj.EXPECT().GetParticipantsInLeague(gomock.Any(), 313).Return(&[]int64{1, 2}, nil).Times(2)
+	testObj.EXPECT().GetTeamInfoForParticipant(gomock.Any(), gomock.Any(), 1, gomock.Any()).Return(map[string]int{"Messi": 2}, nil).Times(2)
+
+	store := server.NewMemoryStore()
+	myFPLServer := &server.MyFPLServer{
+		Scraper:        testObj,
+		Store:          store,
+		WatchedLeagues: []int{313},
+		CacheTTL:       time.Minute,
+	}
+	store.Set(&server.LeagueData{
+		LeagueCode:       313,
+		SampleSize:       10,
+		PlayerOccurances: map[int]map[string]int{1: {"Salah": 10}},
+		FetchedAt:        time.Now().Add(-7 * 24 * time.Hour),
+	})
+	store.Set(&server.LeagueData{
+		LeagueCode:       313,
+		SampleSize:       50,
+		PlayerOccurances: map[int]map[string]int{1: {"Salah": 50}},
+		FetchedAt:        time.Now().Add(-time.Hour),
+	})
+
+	for _, sampleSize := range []int64{10, 50} {
+		playerOccurance, err := myFPLServer.GetDataForGameweek(context.Background(), &grpc_fpl.GameweekReq{LeagueCode: 313, Gameweek: 1, SampleSize: sampleSize})
+		assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+		assert.Equal(t, map[string]int32{"Messi": 2}, playerOccurance.PlayerOccurance, "Sample of %v should have been scraped again", sampleSize)
+	}
+}
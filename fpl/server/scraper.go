@@ -1,16 +1,17 @@
 package server
 
 import (
-	"encoding/csv"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io/ioutil"
 	"net/http"
-	"os"
 	"strconv"
 	"time"
 
 	"github.com/pkg/errors"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 )
 
 /*
@@ -20,23 +21,68 @@ const (
 	teamURL         = "https://fantasy.premierleague.com/drf/entry/%v/event/%v/picks"
 	allPlayersURL   = "https://fantasy.premierleague.com/drf/bootstrap-static"
 	participantsURL = "https://fantasy.premierleague.com/drf/leagues-classic-standings/%v?phase=1&le-page=1&ls-page=1"
-	csvFileName     = "temp-%v-%v.csv"
+	liveURL         = "https://fantasy.premierleague.com/drf/event/%v/live"
+	fixturesURL     = "https://fantasy.premierleague.com/drf/fixtures/?event=%v"
 	GameweekMax     = 38
+
+	defaultSampleSize = 10
 )
 
 /* Structure of JSON
 
+entry_history
+    points	99
+    total_points	99
+    event_transfers_cost	0
 picks
     0
     element	260
+    position	1
+    is_captain	false
+    multiplier	1
     1
     element	247
+    position	2
+    is_captain	true
+    multiplier	2
 */
 type ParticipantTeamInfo struct {
-	TeamPlayers []TeamPlayers `json:"picks"`
+	EntryHistory EntryHistory  `json:"entry_history"`
+	TeamPlayers  []TeamPlayers `json:"picks"`
+}
+type EntryHistory struct {
+	Points             int `json:"points"`
+	TotalPoints        int `json:"total_points"`
+	EventTransfersCost int `json:"event_transfers_cost"`
 }
 type TeamPlayers struct {
-	Element int64 `json:"element"`
+	Element    int64 `json:"element"`
+	Position   int   `json:"position"`
+	IsCaptain  bool  `json:"is_captain"`
+	Multiplier int   `json:"multiplier"`
+}
+
+/* Structure of JSON
+
+elements
+    1
+        stats
+            minutes	90
+            total_points	2
+    2
+        stats
+            minutes	0
+            total_points	0
+*/
+type LiveData struct {
+	Elements map[string]LiveElement `json:"elements"`
+}
+type LiveElement struct {
+	Stats LiveStats `json:"stats"`
+}
+type LiveStats struct {
+	Minutes     int `json:"minutes"`
+	TotalPoints int `json:"total_points"`
 }
 
 /* Structure of JSON
@@ -74,6 +120,47 @@ type Players struct {
 
 /* Structure of JSON
 
+events
+    0
+    id	1
+    name	"Gameweek 1"
+    deadline_time	"2018-08-10T18:00:00Z"
+    finished	true
+    data_checked	true
+    is_current	false
+    is_next	false
+*/
+type AllEvents struct {
+	Events []Event `json:"events"`
+}
+type Event struct {
+	ID           int       `json:"id"`
+	Name         string    `json:"name"`
+	DeadlineTime time.Time `json:"deadline_time"`
+	Finished     bool      `json:"finished"`
+	DataChecked  bool      `json:"data_checked"`
+	IsCurrent    bool      `json:"is_current"`
+	IsNext       bool      `json:"is_next"`
+}
+
+/* Structure of JSON
+
+    0
+    id	1
+    kickoff_time	"2018-08-10T19:00:00Z"
+    started	true
+    finished	true
+    event	1
+*/
+type Fixture struct {
+	ID          int       `json:"id"`
+	KickoffTime time.Time `json:"kickoff_time"`
+	Started     bool      `json:"started"`
+	Finished    bool      `json:"finished"`
+}
+
+/* Structure of JSON
+
 standings
     has_next	true
     number	1
@@ -105,13 +192,13 @@ type LeagueResults struct {
 }
 
 //GetTeamInfoForParticipant gets a map of players and their picks for a gameweek for all participants provided
-func (s *MyFPLScraper) GetTeamInfoForParticipant(playerMap map[int64]string, gameweek int, topLeagueParticipants *[]int64) (map[string]int, error) {
+func (s *MyFPLScraper) GetTeamInfoForParticipant(ctx context.Context, playerMap map[int64]string, gameweek int, topLeagueParticipants *[]int64) (map[string]int, error) {
 
 	playerOccuranceForGameweek := make(map[string]int)
 	for _, participant := range *topLeagueParticipants {
 		teamURL := fmt.Sprintf(teamURL, participant, gameweek)
 
-		response, err := s.MakeRequest(teamURL)
+		response, err := s.MakeRequest(ctx, teamURL)
 		if err != nil {
 			return nil, err
 		}
@@ -131,9 +218,9 @@ func (s *MyFPLScraper) GetTeamInfoForParticipant(playerMap map[int64]string, gam
 	return playerOccuranceForGameweek, nil
 }
 
-func (s *MyFPLScraper) GetPlayerMapping() (map[int64]string, error) {
+func (s *MyFPLScraper) GetPlayerMapping(ctx context.Context) (map[int64]string, error) {
 
-	response, err := s.MakeRequest(allPlayersURL)
+	response, err := s.MakeRequest(ctx, allPlayersURL)
 	if err != nil {
 		return nil, err
 	}
@@ -149,16 +236,50 @@ func (s *MyFPLScraper) GetPlayerMapping() (map[int64]string, error) {
 		playerMap[player.ID] = player.WebName
 	}
 
-	numPlayers := len(playerMap)
-	fmt.Printf("Fetched data of %v premier league players \n", strconv.Itoa(numPlayers))
+	s.logger(ctx).WithField("players", len(playerMap)).Debug("fetched premier league players")
 	return playerMap, nil
 
 }
 
-func (s *MyFPLScraper) GetParticipantsInLeague(leagueCode int) (*[]int64, error) {
+//GetEvents gets the deadline and status of every gameweek in the season
+func (s *MyFPLScraper) GetEvents(ctx context.Context) ([]Event, error) {
+
+	response, err := s.MakeRequest(ctx, allPlayersURL)
+	if err != nil {
+		return nil, err
+	}
+
+	allEvents := new(AllEvents)
+	err = json.Unmarshal(response, &allEvents)
+	if err != nil {
+		return nil, errors.Errorf("error unmarshalling response for GetEvents : %v", err)
+	}
+
+	return allEvents.Events, nil
+}
+
+//GetFixtures gets the kickoff time and status of every match in a gameweek
+func (s *MyFPLScraper) GetFixtures(ctx context.Context, gameweek int) ([]Fixture, error) {
+	fixturesURL := fmt.Sprintf(fixturesURL, gameweek)
+
+	response, err := s.MakeRequest(ctx, fixturesURL)
+	if err != nil {
+		return nil, err
+	}
+
+	var fixtures []Fixture
+	err = json.Unmarshal(response, &fixtures)
+	if err != nil {
+		return nil, errors.Errorf("could not parse response for GetFixtures for gameweek %v: %v", gameweek, err)
+	}
+
+	return fixtures, nil
+}
+
+func (s *MyFPLScraper) GetParticipantsInLeague(ctx context.Context, leagueCode int) (*[]int64, error) {
 	participantsURL := fmt.Sprintf(participantsURL, leagueCode)
 
-	response, err := s.MakeRequest(participantsURL)
+	response, err := s.MakeRequest(ctx, participantsURL)
 	if err != nil {
 		return nil, err
 	}
@@ -174,77 +295,97 @@ func (s *MyFPLScraper) GetParticipantsInLeague(leagueCode int) (*[]int64, error)
 		leagueParticipantsData = append(leagueParticipantsData, participant.Entry)
 	}
 
-	fmt.Printf("Fetched %v participants in league\n", strconv.Itoa(len(leagueParticipantsData)))
+	s.logger(ctx).WithFields(logrus.Fields{
+		"league":       leagueCode,
+		"participants": len(leagueParticipantsData),
+	}).Debug("fetched participants in league")
 	return &leagueParticipantsData, nil
 }
 
-func (s *MyFPLScraper) WriteToFile(playerOccurances map[int]map[string]int, leagueCode int) (string, error) {
-	fmt.Println("Writing to file ...")
-
-	fileName := fmt.Sprintf(csvFileName, time.Now().Format("2006-01-02"), leagueCode)
-	file, err := os.Create(fileName)
-	if err != nil {
-		return "", errors.Errorf("error creating file %v : %v", fileName, err)
-	}
-	defer file.Close()
+//GetPicksForParticipants gets the full picks, including multipliers and the gameweek history, of every participant provided
+func (s *MyFPLScraper) GetPicksForParticipants(ctx context.Context, gameweek int, participants *[]int64) (map[int64]*ParticipantTeamInfo, error) {
 
-	writer := csv.NewWriter(file)
-	defer writer.Flush()
+	picksForParticipants := make(map[int64]*ParticipantTeamInfo)
+	for _, participant := range *participants {
+		teamURL := fmt.Sprintf(teamURL, participant, gameweek)
 
-	numOfGameweeks := len(playerOccurances)
-	//Headers
-	var record []string
-	record = append(record, "Player")
-	for gameweekNum := 1; gameweekNum <= numOfGameweeks; gameweekNum++ {
-		record = append(record, fmt.Sprintf("Gameweek %v", gameweekNum))
-	}
+		response, err := s.MakeRequest(ctx, teamURL)
+		if err != nil {
+			return nil, err
+		}
 
-	err = writer.Write(record)
-	if err != nil {
-		return "", errors.Errorf("error writing to file %v: %v", fileName, err)
+		participantTeamInfo := new(ParticipantTeamInfo)
+		err = json.Unmarshal(response, &participantTeamInfo)
+		if err != nil {
+			return nil, errors.Errorf("error unmarshalling response URL %v for GetPicksForParticipants: %v", teamURL, err)
+		}
+		picksForParticipants[participant] = participantTeamInfo
 	}
 
-	allPlayersInLatestGameweek := playerOccurances[numOfGameweeks]
+	return picksForParticipants, nil
+}
 
-	for player := range allPlayersInLatestGameweek {
+//GetLivePoints gets the points scored so far by every premier league player in a gameweek
+func (s *MyFPLScraper) GetLivePoints(ctx context.Context, gameweek int) (map[int64]int, error) {
+	liveURL := fmt.Sprintf(liveURL, gameweek)
 
-		var record []string
-		record = append(record, string(player))
+	response, err := s.MakeRequest(ctx, liveURL)
+	if err != nil {
+		return nil, err
+	}
 
-		for gameweekNum := 1; gameweekNum <= numOfGameweeks; gameweekNum++ {
-			playerOccuranceForGameweek := playerOccurances[gameweekNum]
-			record = append(record, strconv.Itoa(playerOccuranceForGameweek[player]))
-		}
+	liveData := new(LiveData)
+	err = json.Unmarshal(response, &liveData)
+	if err != nil {
+		return nil, errors.Errorf("could not parse response for GetLivePoints for gameweek %v: %v", gameweek, err)
+	}
 
-		err := writer.Write(record)
+	livePoints := make(map[int64]int)
+	for element, liveElement := range liveData.Elements {
+		playerID, err := strconv.ParseInt(element, 10, 64)
 		if err != nil {
-			return "", errors.Errorf("error writing to file %v : %v", fileName, err)
+			return nil, errors.Errorf("invalid player id %v in live data for gameweek %v", element, gameweek)
 		}
+		livePoints[playerID] = liveElement.Stats.TotalPoints
 	}
-	return fileName, nil
-}
 
-func (client *MyFPLClient) MakeRequest(URL string) ([]byte, error) {
+	return livePoints, nil
+}
 
-	var err error
-	customErr := errors.Errorf("error with request to %v : %v", URL, err)
+//MakeRequest gets URL from the FPL site. The request is cancelled along with ctx
+func (client *MyFPLClient) MakeRequest(ctx context.Context, URL string) (body []byte, err error) {
+	start := time.Now()
+	log := loggerFrom(ctx, client.Logger).WithField("url", URL)
+	ctx, span := startSpan(ctx, "GET "+endpoint(URL), attribute.String("http.url", URL))
+	defer func() { endSpan(span, err) }()
 
 	req, err := http.NewRequest(http.MethodGet, URL, nil)
 	if err != nil {
-		return nil, customErr
+		return nil, errors.Errorf("error with request to %v : %v", URL, err)
 	}
+	req = req.WithContext(ctx)
 
 	req.Header.Set("User-Agent", "pg-fpl")
 
 	resp, err := client.HttpClient.Do(req)
+	client.Metrics.observeUpstream(URL, resp)
+	if resp != nil {
+		span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+	}
 	if err != nil {
-		return nil, customErr
+		log.WithError(err).Warn("request to the FPL site failed")
+		return nil, errors.Errorf("error with request to %v : %v", URL, err)
 	}
+	defer resp.Body.Close()
 
-	body, err := ioutil.ReadAll(resp.Body)
+	body, err = ioutil.ReadAll(resp.Body)
 	if err != nil {
-		return nil, customErr
+		return nil, errors.Errorf("error with request to %v : %v", URL, err)
 	}
 
+	log.WithFields(logrus.Fields{
+		"status":   resp.StatusCode,
+		"duration": time.Since(start).String(),
+	}).Debug("requested the FPL site")
 	return body, nil
 }
@@ -1,8 +1,10 @@
 package server_test
 
 import (
+	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/go-fantasy/fpl/mock"
 	"github.com/go-fantasy/fpl/server"
@@ -138,11 +140,11 @@ func TestGetTeamInfoForParticipant(t *testing.T) {
       }
    ]
 }`
-	firstcall := testObj.EXPECT().MakeRequest(gomock.Any()).Do(func(s string) {
+	firstcall := testObj.EXPECT().MakeRequest(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, s string) {
 		fmt.Printf("Calling MakeRequest with %v url \n\n", s)
 	}).Return([]byte(b1), nil).Times(1)
 
-	testObj.EXPECT().MakeRequest(gomock.Any()).Do(func(s string) {
+	testObj.EXPECT().MakeRequest(gomock.Any(), gomock.Any()).Do(func(ctx context.Context, s string) {
 		fmt.Printf("Calling MakeRequest with %v url \n\n", s)
 	}).Return([]byte(b2), nil).After(firstcall).Times(1)
 
@@ -150,7 +152,7 @@ func TestGetTeamInfoForParticipant(t *testing.T) {
 		Client: testObj,
 	}
 	//participantsInLeague := []int64{2575352, 3614956, 8995, 8450}
-	playerOccuranceForGameweek, err := testScraper.GetTeamInfoForParticipant(playerMap, 1, &[]int64{1, 2})
+	playerOccuranceForGameweek, err := testScraper.GetTeamInfoForParticipant(context.Background(), playerMap, 1, &[]int64{1, 2})
 	assert.Nil(t, err)
 
 	for _, player := range playerMap {
@@ -295,11 +297,11 @@ func TestGetPlayerMapping(t *testing.T) {
 ]
 }`
 
-	testObj.EXPECT().MakeRequest(gomock.Any()).Return([]byte(b), nil).Times(1)
+	testObj.EXPECT().MakeRequest(gomock.Any(), gomock.Any()).Return([]byte(b), nil).Times(1)
 	testScraper := &server.MyFPLScraper{
 		Client: testObj,
 	}
-	playerMap, err := testScraper.GetPlayerMapping()
+	playerMap, err := testScraper.GetPlayerMapping(context.Background())
 	assert.Equal(t, len(playerMap), 2)
 	assert.Nil(t, err)
 
@@ -383,14 +385,122 @@ func TestGetParticipantsInLeague(t *testing.T) {
       ]
    }
 }`
-	testObj.EXPECT().MakeRequest(gomock.Any()).Return([]byte(b), nil).Times(1)
+	testObj.EXPECT().MakeRequest(gomock.Any(), gomock.Any()).Return([]byte(b), nil).Times(1)
 
 	testScraper := &server.MyFPLScraper{
 		Client: testObj,
 	}
-	leagueParticipants, err := testScraper.GetParticipantsInLeague(1)
+	leagueParticipants, err := testScraper.GetParticipantsInLeague(context.Background(), 1)
 	//fmt.Println(*leagueParticipants)
 
 	assert.Nil(t, err)
 	assert.Equal(t, 4, len(*leagueParticipants))
 }
+
+func TestGetLivePoints(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testObj := mock_server.NewMockClient(mockCtrl)
+
+	b := `{
+   "fixtures":[
+
+   ],
+   "elements":{
+      "247":{
+         "explain":[
+
+         ],
+         "stats":{
+            "yellow_cards":0,
+            "goals_scored":1,
+            "minutes":90,
+            "bonus":3,
+            "total_points":11
+         }
+      },
+      "267":{
+         "explain":[
+
+         ],
+         "stats":{
+            "yellow_cards":1,
+            "goals_scored":0,
+            "minutes":60,
+            "bonus":0,
+            "total_points":1
+         }
+      }
+   }
+}`
+	testObj.EXPECT().MakeRequest(gomock.Any(), gomock.Any()).Return([]byte(b), nil).Times(1)
+
+	testScraper := &server.MyFPLScraper{
+		Client: testObj,
+	}
+	livePoints, err := testScraper.GetLivePoints(context.Background(), 1)
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(livePoints))
+	assert.Equal(t, 11, livePoints[247])
+	assert.Equal(t, 1, livePoints[267])
+}
+
+func TestGetEvents(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+
+	testObj := mock_server.NewMockClient(mockCtrl)
+
+	b := `{
+  "events": [
+    {
+      "id": 1,
+      "name": "Gameweek 1",
+      "deadline_time": "2018-08-10T18:00:00Z",
+      "average_entry_score": 53,
+      "finished": true,
+      "data_checked": true,
+      "highest_scoring_entry": 890626,
+      "deadline_time_epoch": 1533924000,
+      "deadline_time_game_offset": 3600,
+      "deadline_time_formatted": "10 Aug 19:00",
+      "highest_score": 137,
+      "is_previous": true,
+      "is_current": false,
+      "is_next": false
+    },
+    {
+      "id": 2,
+      "name": "Gameweek 2",
+      "deadline_time": "2018-08-18T10:30:00Z",
+      "average_entry_score": 0,
+      "finished": false,
+      "data_checked": false,
+      "highest_scoring_entry": null,
+      "deadline_time_epoch": 1534588200,
+      "deadline_time_game_offset": 3600,
+      "deadline_time_formatted": "18 Aug 11:30",
+      "highest_score": null,
+      "is_previous": false,
+      "is_current": true,
+      "is_next": false
+    }
+  ],
+  "elements": [
+  ]
+}`
+	testObj.EXPECT().MakeRequest(gomock.Any(), gomock.Any()).Return([]byte(b), nil).Times(1)
+
+	testScraper := &server.MyFPLScraper{
+		Client: testObj,
+	}
+	events, err := testScraper.GetEvents(context.Background())
+
+	assert.Nil(t, err)
+	assert.Equal(t, 2, len(events))
+	assert.True(t, events[0].Finished)
+	assert.True(t, events[1].IsCurrent)
+	assert.Equal(t, time.Date(2018, 8, 18, 10, 30, 0, 0, time.UTC), events[1].DeadlineTime)
+}
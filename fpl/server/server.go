@@ -2,24 +2,22 @@ package server
 
 import (
 	"fmt"
-	"io"
-	"net"
 	"net/http"
-	"os"
 	"sync"
 	"time"
 
 	"google.golang.org/grpc/codes"
 
 	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
 	"golang.org/x/net/context"
-	"google.golang.org/grpc"
 	"google.golang.org/grpc/status"
 )
 
 //GetNumberOfPlayers is the gRPC method to get number of players
-func (s *MyFPLServer) GetNumberOfPlayers(context.Context, *grpc_fpl.NumPlayerRequest) (*grpc_fpl.NumPlayers, error) {
-	playerMap, err := s.Scraper.GetPlayerMapping()
+func (s *MyFPLServer) GetNumberOfPlayers(cxt context.Context, req *grpc_fpl.NumPlayerRequest) (*grpc_fpl.NumPlayers, error) {
+	playerMap, err := s.Scraper.GetPlayerMapping(cxt)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "error while getting player mapping : %v", err)
 	}
@@ -29,90 +27,286 @@ func (s *MyFPLServer) GetNumberOfPlayers(context.Context, *grpc_fpl.NumPlayerReq
 
 //GetParticipantsInLeague is the gRPC method to get number of participants in a league
 func (s *MyFPLServer) GetParticipantsInLeague(cxt context.Context, leagueCode *grpc_fpl.LeagueCode) (*grpc_fpl.NumParticipants, error) {
-	leagueParticipants, err := s.Scraper.GetParticipantsInLeague(int(leagueCode.LeagueCode))
-	numParticipants := len(*leagueParticipants)
+	cxt = withLogger(cxt, s.logger(cxt).WithField("league", leagueCode.LeagueCode))
+	leagueParticipants, err := s.Scraper.GetParticipantsInLeague(cxt, int(leagueCode.LeagueCode))
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "error while getting participants in league : %v", err)
 	}
+	numParticipants := len(*leagueParticipants)
 	return &grpc_fpl.NumParticipants{NumParticipants: int64(numParticipants)}, nil
 }
 
 //GetDataForGameweek is the gRPC method to get player occurances for a single gameweek
 func (s *MyFPLServer) GetDataForGameweek(cxt context.Context, req *grpc_fpl.GameweekReq) (*grpc_fpl.PlayerOccuranceData, error) {
-	playerMap, err := s.Scraper.GetPlayerMapping()
+	leagueCode, gameweek, sampleSize := int(req.LeagueCode), int(req.Gameweek), sampleSizeOrDefault(int(req.SampleSize))
+	cxt = withLogger(cxt, s.logger(cxt).WithFields(logrus.Fields{
+		"league":      leagueCode,
+		"gameweek":    gameweek,
+		"sample_size": sampleSize,
+		"cohort":      req.Cohort,
+	}))
+	if err := checkLeagueOrCohort(req.LeagueCode, req.Cohort); err != nil {
+		return nil, err
+	}
+	if req.Cohort != "" {
+		cohortData, err := s.cohortData(cxt, req.Cohort, []int{gameweek})
+		if err != nil {
+			return nil, err
+		}
+		return &grpc_fpl.PlayerOccuranceData{
+			PlayerOccurance: toPlayerOccuranceResult(cohortData.PlayerOccurances[gameweek]),
+		}, nil
+	}
+	if leagueData, ok := s.cachedLeagueData(cxt, leagueCode, sampleSize); ok {
+		if playerOccuranceForGameweek, ok := leagueData.PlayerOccurances[gameweek]; ok {
+			return &grpc_fpl.PlayerOccuranceData{
+				PlayerOccurance: toPlayerOccuranceResult(playerOccuranceForGameweek),
+			}, nil
+		}
+	}
+
+	//Identical requests running at the same time share a single scrape
+	key := fmt.Sprintf("%v:%v:%v", leagueCode, gameweek, sampleSize)
+	result, err, _ := s.scrapes.Do(key, func() (interface{}, error) {
+		return s.scrapeGameweek(s.sharedContext(cxt), leagueCode, gameweek, sampleSize)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	playerOccuranceForGameweek := result.(map[string]int)
+	if len(playerOccuranceForGameweek) > 0 {
+		playerOccuranceData := &grpc_fpl.PlayerOccuranceData{
+			PlayerOccurance: toPlayerOccuranceResult(playerOccuranceForGameweek),
+		}
+		return playerOccuranceData, nil
+	}
+	return nil, nil
+}
+
+//scrapeGameweek fetches the player occurances of the top participants in a league for a single gameweek
+func (s *MyFPLServer) scrapeGameweek(ctx context.Context, leagueCode, gameweek, sampleSize int) (playerOccuranceForGameweek map[string]int, err error) {
+	ctx, span := startSpan(ctx, "scrapeGameweek",
+		attribute.Int("league", leagueCode),
+		attribute.Int("gameweek", gameweek),
+		attribute.Int("sample_size", sampleSize),
+	)
+	defer func() { endSpan(span, err) }()
+
+	playerMap, err := s.Scraper.GetPlayerMapping(ctx)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "error while getting player mapping : %v", err)
 	}
-	s.PlayerMap = playerMap
 
-	participants, err := s.Scraper.GetParticipantsInLeague(int(req.LeagueCode))
+	participants, err := s.Scraper.GetParticipantsInLeague(ctx, leagueCode)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "error while getting participants in league : %v", err)
 	}
-	s.LeagueParticipants = participants
 
-	leagueParticipants := *s.LeagueParticipants
-	var topLeagueParticipants []int64
-	if len(leagueParticipants) > 10 {
-		topLeagueParticipants = leagueParticipants[0:10]
-	} else {
-		topLeagueParticipants = leagueParticipants[:]
+	topLeagueParticipants := topParticipants(participants, sampleSize)
+	s.logger(ctx).Debug("fetching data for gameweek")
+	start := time.Now()
+	playerOccuranceForGameweek, err = s.Scraper.GetTeamInfoForParticipant(ctx, playerMap, gameweek, &topLeagueParticipants)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "error while Fetching data for gameweek %v : %v", gameweek, err)
+	}
+	s.Metrics.observeScrape(gameweek, start)
+	return playerOccuranceForGameweek, nil
+}
+
+//GetDataForAllGameweeks is the gRPC method to get player occurances for all available gameweeks, or the ones the
+//request selects, in CSV or in the format of the request, with the players in the order the request asks for.
+//Exports carry a resume token and the offset of every chunk, so that a client can resume one from where its stream
+//broke
+func (s *MyFPLServer) GetDataForAllGameweeks(req *grpc_fpl.AllGameweeksReq, stream grpc_fpl.FPL_GetDataForAllGameweeksServer) error {
+	sampleSize := sampleSizeOrDefault(int(req.SampleSize))
+	ctx := withLogger(stream.Context(), s.logger(stream.Context()).WithFields(logrus.Fields{
+		"league":      req.LeagueCode,
+		"sample_size": sampleSize,
+		"format":      req.Format,
+		"sort":        req.Sort,
+		"offset":      req.Offset,
+		"gameweeks":   req.Gameweeks,
+		"cohort":      req.Cohort,
+	}))
+	exporter, ok := s.exporter(req.Format)
+	if !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown export format %v", req.Format)
+	}
+	if _, ok := grpc_fpl.OwnershipSort_name[int32(req.Sort)]; !ok {
+		return status.Errorf(codes.InvalidArgument, "unknown sort %v", req.Sort)
+	}
+	if err := checkLeagueOrCohort(req.LeagueCode, req.Cohort); err != nil {
+		return err
+	}
+	if req.Offset < 0 || (req.Offset > 0 && req.ResumeToken == "") {
+		return status.Errorf(codes.InvalidArgument, "resuming at offset %v needs the resume token of the export", req.Offset)
+	}
+	gameweeks, err := s.selectedGameweeks(ctx, req.Gameweeks)
+	if err != nil {
+		return err
 	}
-	fmt.Printf("Fetching data for gameweek %v\n", req.Gameweek)
-	playerOccuranceForGameweek, err := s.Scraper.GetTeamInfoForParticipant(playerMap, int(req.Gameweek), &topLeagueParticipants)
+	leagueData, token, err := s.exportLeagueData(ctx, req, sampleSize, gameweeks)
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "error while Fetching data for gameweek %v : %v", int(req.Gameweek), err)
+		return err
 	}
 
-	if len(playerOccuranceForGameweek) > 0 {
-		playerOccuranceData := &grpc_fpl.PlayerOccuranceData{
-			PlayerOccurance: make(map[string]int32),
+	table := leagueTable(leagueData)
+	table.sortBy(req.Sort)
+	if err := export(exporter, table, stream, req.Offset, token); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return err
+		}
+		return status.Errorf(codes.Internal, "error while exporting %v as %v : %v", leagueData.name(), req.Format, err)
+	}
+	return nil
+}
+
+//exportLeagueData returns the data of the gameweeks to export and the resume token of the export. Resumed exports
+//get the data their token was issued for even when it isn't fresh anymore, as the start of the export was made of
+//it: the data in the store, or the data scraped for the export of a cohort or of some gameweeks
+func (s *MyFPLServer) exportLeagueData(ctx context.Context, req *grpc_fpl.AllGameweeksReq, sampleSize int, gameweeks []int) (*LeagueData, string, error) {
+	if req.ResumeToken != "" {
+		if leagueData, ok := s.resumableExports().get(req.ResumeToken, time.Now()); ok {
+			//The token of another export, of another format or cohort, mustn't resume this one with its data
+			if exportToken(req, gameweeks, leagueData) != req.ResumeToken {
+				return nil, "", status.Errorf(codes.FailedPrecondition, "the resume token was issued for another export than %v, it should be restarted from the beginning", leagueData.name())
+			}
+			return leagueData, req.ResumeToken, nil
 		}
-		playerOccuranceResult := make(map[string]int32)
-		for player, occurance := range playerOccuranceForGameweek {
-			playerOccuranceResult[player] = int32(occurance)
+	}
+	if req.ResumeToken != "" && req.Cohort == "" && s.Store != nil {
+		if leagueData, ok := s.Store.Get(int(req.LeagueCode), sampleSize); ok {
+			leagueData = leagueData.only(gameweeks)
+			if exportToken(req, gameweeks, leagueData) == req.ResumeToken {
+				return leagueData, req.ResumeToken, nil
+			}
 		}
-		playerOccuranceData.PlayerOccurance = playerOccuranceResult
-		return playerOccuranceData, nil
 	}
-	return nil, nil
+	leagueData, err := s.groupData(ctx, int(req.LeagueCode), req.Cohort, sampleSize, gameweeks)
+	if err != nil {
+		return nil, "", err
+	}
+	token := exportToken(req, gameweeks, leagueData)
+	if req.ResumeToken != "" && req.ResumeToken != token {
+		return nil, "", status.Errorf(codes.FailedPrecondition, "%v changed since the export was started, it should be restarted from the beginning", leagueData.name())
+	}
+	if gameweeks != nil || req.Cohort != "" {
+		//Cohorts and scrapes of some gameweeks aren't stored, so they are kept for the export to be resumed
+		s.resumableExports().remember(token, leagueData, time.Now())
+	}
+	return leagueData, token, nil
+}
+
+//leagueData returns the data of every gameweek of a league, from the store when it is fresh enough or by scraping it
+func (s *MyFPLServer) leagueData(ctx context.Context, leagueCode, sampleSize int) (*LeagueData, error) {
+	if leagueData, ok := s.cachedLeagueData(ctx, leagueCode, sampleSize); ok {
+		return leagueData, nil
+	}
+	return s.refreshLeagueData(ctx, leagueCode, sampleSize)
+}
+
+//refreshLeagueData scrapes every gameweek of a league and saves the result in the store.
+//Identical requests running at the same time share a single scrape and its result
+func (s *MyFPLServer) refreshLeagueData(ctx context.Context, leagueCode, sampleSize int) (*LeagueData, error) {
+	key := fmt.Sprintf("%v:all:%v", leagueCode, sampleSize)
+	result, err, shared := s.scrapes.Do(key, func() (interface{}, error) {
+		leagueData, err := s.scrapeGameweeks(s.sharedContext(ctx), leagueCode, sampleSize, gameweekRange(1, GameweekMax))
+		if err != nil {
+			return nil, err
+		}
+		if s.Store != nil {
+			s.Store.Set(leagueData)
+		}
+		return leagueData, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	if shared {
+		s.logger(ctx).Debug("shared the scrape of another call")
+	}
+	return result.(*LeagueData), nil
 }
 
-//GetDataForAllGameweeks is the gRPC method to get player occurances for all available gameweeks in a csv format
-func (s *MyFPLServer) GetDataForAllGameweeks(req *grpc_fpl.LeagueCode, stream grpc_fpl.FPL_GetDataForAllGameweeksServer) error {
-	playerMap, err := s.Scraper.GetPlayerMapping()
+//cachedLeagueData returns the stored data of a league. The default sample of watched leagues is served from the
+//store for up to maxWatchedAge, since the scheduler keeps it fresh, while other data is only served from it for
+//CacheTTL
+func (s *MyFPLServer) cachedLeagueData(ctx context.Context, leagueCode, sampleSize int) (*LeagueData, bool) {
+	if s.Store == nil {
+		return nil, false
+	}
+	leagueData, ok := s.Store.Get(leagueCode, sampleSize)
+	ttl := s.CacheTTL
+	if sampleSize == defaultSampleSize && s.isWatched(leagueCode) {
+		ttl = maxWatchedAge
+	}
+	hit := ok && time.Since(leagueData.FetchedAt) < ttl
+	s.Metrics.observeCache(hit)
+	if !hit {
+		return nil, false
+	}
+	s.logger(ctx).WithField("fetched_at", leagueData.FetchedAt).Debug("serving league data from the store")
+	return leagueData, true
+}
+
+//scrapeGameweeks fetches the player occurances of the top participants in a league in gameweeks, with a go-routine
+//per gameweek
+func (s *MyFPLServer) scrapeGameweeks(ctx context.Context, leagueCode, sampleSize int, gameweeks []int) (leagueData *LeagueData, err error) {
+	ctx, span := startSpan(ctx, "scrapeGameweeks",
+		attribute.Int("league", leagueCode),
+		attribute.Int("sample_size", sampleSize),
+		attribute.Int("gameweeks", len(gameweeks)),
+	)
+	defer func() { endSpan(span, err) }()
+
+	playerMap, err := s.Scraper.GetPlayerMapping(ctx)
 	if err != nil {
-		return status.Errorf(codes.Internal, "error while getting player mapping : %v", err)
+		return nil, status.Errorf(codes.Internal, "error while getting player mapping : %v", err)
 	}
-	s.PlayerMap = playerMap
 
-	participants, err := s.Scraper.GetParticipantsInLeague(int(req.LeagueCode))
+	participants, err := s.Scraper.GetParticipantsInLeague(ctx, leagueCode)
 	if err != nil {
-		return status.Errorf(codes.Internal, "error in GetParticipantsInLeague : %v", err)
+		return nil, status.Errorf(codes.Internal, "error in GetParticipantsInLeague : %v", err)
 	}
-	s.LeagueParticipants = participants
+	topLeagueParticipants := topParticipants(participants, sampleSize)
+	playerOccurances := s.scrapeEntries(ctx, playerMap, topLeagueParticipants, gameweeks)
+
+	s.logger(ctx).WithField("gameweeks", len(playerOccurances)).Info("scraped league")
+	return &LeagueData{
+		LeagueCode:       leagueCode,
+		SampleSize:       sampleSize,
+		PlayerMap:        playerMap,
+		Participants:     topLeagueParticipants,
+		PlayerOccurances: playerOccurances,
+		FetchedAt:        time.Now(),
+	}, nil
+}
 
+//scrapeEntries fetches the player occurances of entries in gameweeks, with a go-routine per gameweek. Gameweeks
+//that haven't been played yet are left out
+func (s *MyFPLServer) scrapeEntries(ctx context.Context, playerMap map[int64]string, entries []int64, gameweeks []int) map[int]map[string]int {
 	var wg sync.WaitGroup
 	playerOccuranceChan := make(chan map[int]map[string]int)
 
-	for gameweek := 1; gameweek <= GameweekMax; gameweek++ {
+	for _, gameweek := range gameweeks {
 		wg.Add(1)
 		go func(gameweek int, playerOccuranceChan chan map[int]map[string]int) {
 			defer wg.Done()
 
-			leagueParticipants := *s.LeagueParticipants
-			var topLeagueParticipants []int64
-			if len(leagueParticipants) > 10 {
-				topLeagueParticipants = leagueParticipants[0:10]
-			} else {
-				topLeagueParticipants = leagueParticipants[:]
-			}
-			fmt.Printf("Fetching data for gameweek %v\n", gameweek)
+			ctx := withLogger(ctx, s.logger(ctx).WithField("gameweek", gameweek))
+			ctx, span := startSpan(ctx, "scrapeGameweek", attribute.Int("gameweek", gameweek))
+			s.logger(ctx).Debug("fetching data for gameweek")
 
-			playerOccuranceForGameweek, err := s.Scraper.GetTeamInfoForParticipant(playerMap, gameweek, &topLeagueParticipants)
+			//Gameweeks that haven't been played yet have no picks, so errors are skipped
+			start := time.Now()
+			playerOccuranceForGameweek, err := s.Scraper.GetTeamInfoForParticipant(ctx, playerMap, gameweek, &entries)
+			endSpan(span, err)
 			if err != nil {
-				//return nil, status.Errorf(codes.Internal, "error while Fetching data for gameweek %v : %v", int(req.Gameweek), err)
+				s.logger(ctx).WithError(err).Debug("skipping gameweek")
+				return
 			}
+			s.Metrics.observeScrape(gameweek, start)
 			if len(playerOccuranceForGameweek) > 0 {
 				playerOccuranceForGameweekMap := make(map[int]map[string]int)
 				playerOccuranceForGameweekMap[gameweek] = playerOccuranceForGameweek
@@ -126,83 +320,68 @@ func (s *MyFPLServer) GetDataForAllGameweeks(req *grpc_fpl.LeagueCode, stream gr
 		close(playerOccuranceChan)
 	}()
 
+	playerOccurances := make(map[int]map[string]int)
 	for playerOccuranceForGameweekMap := range playerOccuranceChan {
 		for gameweekNum, playerOccuranceForGameweek := range playerOccuranceForGameweekMap {
-			fmt.Printf("Data fetched for gameweek %v!\n", gameweekNum)
-			s.PlayerOccurances[gameweekNum] = playerOccuranceForGameweek
+			playerOccurances[gameweekNum] = playerOccuranceForGameweek
 		}
 	}
-	fileName, err := s.Scraper.WriteToFile(s.PlayerOccurances, int(req.LeagueCode))
-	if err != nil {
-		return status.Errorf(codes.Internal, fmt.Sprintf("error while writing to file %v : %v", fileName, err))
-	}
-	defer func() error {
-		fmt.Println("removing temp file ", fileName)
-		err := os.Remove(fileName)
-		if err != nil {
-			return status.Errorf(codes.Internal, "error while deleting temp file %v : %v", fileName, err)
+	return playerOccurances
+}
+
+func (s *MyFPLServer) isWatched(leagueCode int) bool {
+	for _, watchedLeague := range s.WatchedLeagues {
+		if watchedLeague == leagueCode {
+			return true
 		}
-		return nil
-	}()
+	}
+	return false
+}
 
-	file, err := os.Open(fileName)
-	if err != nil {
-		return status.Errorf(codes.Internal, "error while opening file %v : %v", fileName, err)
+//sampleSizeOrDefault returns the default sample size when a request didn't ask for one
+func sampleSizeOrDefault(sampleSize int) int {
+	if sampleSize <= 0 {
+		return defaultSampleSize
 	}
+	return sampleSize
+}
 
-	buf := make([]byte, 200)
-	for {
-		n, err := file.Read(buf)
-		if err == io.EOF {
-			return nil
-		}
-		if err != nil {
-			return status.Errorf(codes.Internal, "error while writing to file %v : %v", fileName, err)
-		}
-		stream.Send(&grpc_fpl.AllGameweekData{
-			Data: buf[:n],
-		})
+//topParticipants returns the first sampleSize participants of a league, or the default sample if none was asked for
+func topParticipants(participants *[]int64, sampleSize int) []int64 {
+	sampleSize = sampleSizeOrDefault(sampleSize)
+	leagueParticipants := *participants
+	if len(leagueParticipants) > sampleSize {
+		return leagueParticipants[0:sampleSize]
 	}
+	return leagueParticipants[:]
 }
 
 //New is a helper function to create the main struct
-func New() FPLServer {
+func New(opts ...Option) FPLServer {
 	var httpClient = &http.Client{
 		Timeout: time.Second * 10,
 	}
+	metrics := NewMetrics()
 
 	myFPLServer := &MyFPLServer{
-		PlayerMap:        make(map[int64]string),
-		PlayerOccurances: make(map[int]map[string]int),
+		RefreshInterval: defaultRefreshInterval,
 		Scraper: &MyFPLScraper{
 			Client: &MyFPLClient{
 				HttpClient: httpClient,
+				Metrics:    metrics,
 			},
 		},
+		Metrics:         metrics,
+		Store:           NewMemoryStore(),
+		Cohorts:         &MemoryCohortStore{cohorts: make(map[string]Cohort)},
+		CacheTTL:        DefaultCacheTTL,
+		ShutdownTimeout: DefaultShutdownTimeout,
+		HealthCheck:     true,
+		Reflection:      true,
 	}
-
-	return myFPLServer
-}
-
-//Start will start the gRPC server
-func (s *MyFPLServer) Start(port string) error {
-	err := startgRPCServer(s, port)
-	if err != nil {
-		return err
+	for _, opt := range opts {
+		opt(myFPLServer)
 	}
-	return nil
-}
 
-//startgRPCServer is the official call to start the gRPC server
-func startgRPCServer(myFPLServer *MyFPLServer, port string) error {
-	lis, err := net.Listen("tcp", fmt.Sprintf(":%v", port))
-	if err != nil {
-		return status.Errorf(codes.Internal, "error while starting server : %v", err)
-	}
-	// Creates a new gRPC server
-	grpcServer := grpc.NewServer()
-	grpc_fpl.RegisterFPLServer(grpcServer, myFPLServer)
-	fmt.Printf("started grpc server at port %v ...\n", port)
-	grpcServer.Serve(lis)
-	return nil
+	return myFPLServer
 }
@@ -2,8 +2,12 @@ package server_test
 
 import (
 	"context"
+	"errors"
 	"log"
-	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -41,9 +45,7 @@ func (suite *TestServer) SetupSuite() {
 
 	testObj := mock_server.NewMockScraper(mockCtrl)
 	myFPLServer := &server.MyFPLServer{
-		PlayerMap:        make(map[int64]string),
-		PlayerOccurances: make(map[int]map[string]int),
-		Scraper:          testObj,
+		Scraper: testObj,
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
@@ -63,7 +65,7 @@ func (suite *TestServer) SetupTest() {
 func (s *TestServer) TestGetNumberOfPlayers() {
 	t := s.T()
 
-	s.mockScraper.EXPECT().GetPlayerMapping().Return(s.playerMap, nil).Times(1)
+	s.mockScraper.EXPECT().GetPlayerMapping(gomock.Any()).Return(s.playerMap, nil).Times(1)
 
 	numPlayers, err := s.myServer.GetNumberOfPlayers(s.ctx, &grpc_fpl.NumPlayerRequest{})
 	assert.Nil(t, err)
@@ -85,9 +87,9 @@ func (s *TestServer) TestGetDataForGameweek() {
 	for _, player := range s.playerMap {
 		playerOccuranceForGameweek[player] = expectedOccurance
 	}
-	s.mockScraper.EXPECT().GetPlayerMapping().Return(s.playerMap, nil).Times(1)
-	s.mockScraper.EXPECT().GetParticipantsInLeague(gomock.Any()).Return(&[]int64{1, 2}, nil).Times(1)
-	s.mockScraper.EXPECT().GetTeamInfoForParticipant(gomock.Any(), gomock.Any(), gomock.Any()).
+	s.mockScraper.EXPECT().GetPlayerMapping(gomock.Any()).Return(s.playerMap, nil).Times(1)
+	s.mockScraper.EXPECT().GetParticipantsInLeague(gomock.Any(), gomock.Any()).Return(&[]int64{1, 2}, nil).Times(1)
+	s.mockScraper.EXPECT().GetTeamInfoForParticipant(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(playerOccuranceForGameweek, nil).Times(1)
 	playerOccurance, err := s.myServer.GetDataForGameweek(s.ctx, &grpc_fpl.GameweekReq{LeagueCode: 1, Gameweek: 1})
 	assert.Nil(t, err, "Error %v was supposed to be nil ", err)
@@ -101,10 +103,15 @@ func (s *TestServer) TestGetDataForGameweek() {
 
 type mockStream struct {
 	grpc.ServerStream
+	data []byte
+}
+
+func (x *mockStream) Context() context.Context {
+	return context.Background()
 }
 
 func (x *mockStream) Send(m *grpc_fpl.AllGameweekData) error {
-	log.Println("Calling mock send function!!")
+	x.data = append(x.data, m.Data...)
 	return nil
 }
 
@@ -112,46 +119,190 @@ func (s *TestServer) TestGetDataForAllGameweeks() {
 	t := s.T()
 
 	leagueCode := int64(1)
-	s.mockScraper.EXPECT().GetPlayerMapping().Return(s.playerMap, nil).Times(1)
-	s.mockScraper.EXPECT().GetParticipantsInLeague(gomock.Any()).Return(&[]int64{1, 2}, nil).Times(1)
+	s.mockScraper.EXPECT().GetPlayerMapping(gomock.Any()).Return(s.playerMap, nil).Times(1)
+	s.mockScraper.EXPECT().GetParticipantsInLeague(gomock.Any(), gomock.Any()).Return(&[]int64{1, 2}, nil).Times(1)
 
 	playerOccuranceForGameweek := make(map[string]int)
 	for _, player := range s.playerMap {
 		playerOccuranceForGameweek[player] = 2
 	}
-	s.mockScraper.EXPECT().GetTeamInfoForParticipant(gomock.Any(), gomock.Any(), gomock.Any()).
+	s.mockScraper.EXPECT().GetTeamInfoForParticipant(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
 		Return(playerOccuranceForGameweek, nil).AnyTimes()
 
-	//Create temp file
-	fileName, err := getTempFile()
-	assert.NotNil(t, fileName)
+	stream := &mockStream{}
+	err := s.myServer.GetDataForAllGameweeks(&grpc_fpl.AllGameweeksReq{LeagueCode: leagueCode}, stream)
+	assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+
+	var lines []string
+	for _, line := range strings.Split(strings.TrimSpace(string(stream.data)), "\n") {
+		if !strings.HasPrefix(line, "#") {
+			lines = append(lines, line)
+		}
+	}
+	assert.Equal(t, len(s.playerMap)+1, len(lines), "There should be a header and a line for every player")
+	assert.Equal(t, server.GameweekMax+4, len(strings.Split(lines[0], ",")), "There should be a column for every gameweek and the totals")
+	for _, line := range lines[1:] {
+		assert.True(t, strings.HasSuffix(line, strings.Repeat(",2", server.GameweekMax)+",76,2.00,1"), "Unexpected line %v", line)
+	}
+
+	files, err := filepath.Glob("temp-*.csv")
 	assert.Nil(t, err)
+	assert.Empty(t, files, "Nothing should be written to disk")
+}
 
-	s.mockScraper.EXPECT().WriteToFile(gomock.Any(), gomock.Any()).
-		Do(func(playerOccurances map[int]map[string]int, leagueCode int) {
-			assert.Equal(t, leagueCode, leagueCode, "League code not matching!!")
-			assert.Equal(t, len(playerOccurances), server.GameweekMax, "Length of playerOccurances not matching! %v", len(playerOccurances))
+type mockLiveStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	cancel context.CancelFunc
+	sent   []*grpc_fpl.LiveStandings
+}
 
-			for gameweek := 1; gameweek <= server.GameweekMax; gameweek++ {
-				assert.Equal(t, playerOccurances[gameweek], playerOccuranceForGameweek, "playerOccuranceForGameweek not matching! %v ", gameweek)
-			}
+func (x *mockLiveStream) Context() context.Context {
+	return x.ctx
+}
 
-		}).Return(fileName, nil).Times(1)
+func (x *mockLiveStream) Send(m *grpc_fpl.LiveStandings) error {
+	x.sent = append(x.sent, m)
+	x.cancel()
+	return nil
+}
 
-	err = s.myServer.GetDataForAllGameweeks(&grpc_fpl.LeagueCode{LeagueCode: leagueCode}, &mockStream{})
+func (s *TestServer) TestGetLivePoints() {
+	t := s.T()
+
+	s.mockScraper.EXPECT().GetPlayerMapping(gomock.Any()).Return(s.playerMap, nil).Times(1)
+	s.mockScraper.EXPECT().GetParticipantsInLeague(gomock.Any(), gomock.Any()).Return(&[]int64{1, 2}, nil).Times(1)
+
+	picks := map[int64]*server.ParticipantTeamInfo{
+		1: {
+			EntryHistory: server.EntryHistory{Points: 10, TotalPoints: 110},
+			TeamPlayers: []server.TeamPlayers{
+				{Element: 267, Multiplier: 2, IsCaptain: true},
+				{Element: 247, Multiplier: 1},
+				{Element: 454, Multiplier: 0},
+			},
+		},
+		2: {
+			EntryHistory: server.EntryHistory{Points: 20, TotalPoints: 105, EventTransfersCost: 4},
+			TeamPlayers: []server.TeamPlayers{
+				{Element: 454, Multiplier: 2, IsCaptain: true},
+				{Element: 247, Multiplier: 1},
+			},
+		},
+	}
+	s.mockScraper.EXPECT().GetPicksForParticipants(gomock.Any(), 1, gomock.Any()).Return(picks, nil).Times(1)
+	s.mockScraper.EXPECT().GetLivePoints(gomock.Any(), 1).Return(map[int64]int{267: 2, 247: 6, 454: 12}, nil).Times(1)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stream := &mockLiveStream{ctx: ctx, cancel: cancel}
+	err := s.myServer.GetLivePoints(&grpc_fpl.LiveReq{LeagueCode: 1, Gameweek: 1}, stream)
 	assert.Nil(t, err, "Error %v was supposed to be nil ", err)
-	_, err = os.Open(fileName)
-	assert.NotNil(t, err, "File %v should have been deleted! ", fileName)
+	assert.Equal(t, 1, len(stream.sent))
+
+	managers := stream.sent[0].Managers
+	assert.Equal(t, 2, len(managers))
+
+	//Manager 2 started 11 points behind, after a 4 point hit, but overtakes with Salah captained
+	assert.Equal(t, int64(2), managers[0].Entry)
+	assert.Equal(t, int64(26), managers[0].LivePoints)
+	assert.Equal(t, int64(115), managers[0].TotalPoints)
+	assert.Equal(t, int64(1), managers[0].LiveRank)
+	assert.Equal(t, int64(2), managers[0].PreviousRank)
+	assert.Equal(t, "Salah", managers[0].Captain)
+
+	assert.Equal(t, int64(1), managers[1].Entry)
+	assert.Equal(t, int64(10), managers[1].LivePoints)
+	assert.Equal(t, int64(110), managers[1].TotalPoints)
+	assert.Equal(t, int64(2), managers[1].LiveRank)
+	assert.Equal(t, int64(1), managers[1].PreviousRank)
+}
 
+func (s *TestServer) TestGetLivePointsKeepsStreamingAfterAFailedRefresh() {
+	t := s.T()
+
+	s.mockScraper.EXPECT().GetPlayerMapping(gomock.Any()).Return(s.playerMap, nil).Times(1)
+	s.mockScraper.EXPECT().GetParticipantsInLeague(gomock.Any(), gomock.Any()).Return(&[]int64{1}, nil).Times(1)
+	s.mockScraper.EXPECT().GetPicksForParticipants(gomock.Any(), 1, gomock.Any()).Return(map[int64]*server.ParticipantTeamInfo{}, nil).Times(1)
+
+	//The client goes away while the stream waits for the next refresh
+	ctx, cancel := context.WithCancel(context.Background())
+	s.mockScraper.EXPECT().GetLivePoints(gomock.Any(), 1).DoAndReturn(func(context.Context, int) (map[int64]int, error) {
+		cancel()
+		return nil, errors.New("FPL is down")
+	}).Times(1)
+
+	stream := &mockLiveStream{ctx: ctx, cancel: cancel}
+	err := s.myServer.GetLivePoints(&grpc_fpl.LiveReq{LeagueCode: 1, Gameweek: 1}, stream)
+	assert.Nil(t, err, "A failed refresh shouldn't end the stream, got %v", err)
+	assert.Empty(t, stream.sent)
 }
 
-func getTempFile() (string, error) {
-	scraper := &server.MyFPLScraper{
-		Client: &server.MyFPLClient{
-			HttpClient: nil,
-		},
+func (s *TestServer) TestGetLivePointsInvalidGameweek() {
+	t := s.T()
+
+	err := s.myServer.GetLivePoints(&grpc_fpl.LiveReq{LeagueCode: 1, Gameweek: 0}, &mockLiveStream{})
+	assert.NotNil(t, err)
+}
+
+//waitForSingleflight waits until calls goroutines started by the test are in a singleflight call, one running it and
+//the others waiting for its result, failing the test when they aren't after a few seconds
+func waitForSingleflight(t *testing.T, calls int) {
+	deadline := time.Now().Add(5 * time.Second)
+	for {
+		buf := make([]byte, 1<<20)
+		n := runtime.Stack(buf, true)
+		for n == len(buf) {
+			buf = make([]byte, 2*len(buf))
+			n = runtime.Stack(buf, true)
+		}
+		inCall := 0
+		for _, stack := range strings.Split(string(buf[:n]), "\n\n") {
+			if strings.Contains(stack, "singleflight.(*Group).Do") && strings.Contains(stack, t.Name()) {
+				inCall++
+			}
+		}
+		if inCall >= calls {
+			return
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("%v calls were expected in singleflight, only %v are", calls, inCall)
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestGetDataForGameweekSharesConcurrentScrapes(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testObj := mock_server.NewMockScraper(mockCtrl)
+
+	//The scrape is held until every request has been made, so that they all overlap
+	release := make(chan struct{})
+	testObj.EXPECT().GetPlayerMapping(gomock.Any()).DoAndReturn(func(context.Context) (map[int64]string, error) {
+		<-release
+		return map[int64]string{267: "Messi"}, nil
+	}).Times(1)
+	testObj.EXPECT().GetParticipantsInLeague(gomock.Any(), 313).Return(&[]int64{1, 2}, nil).Times(1)
+	testObj.EXPECT().GetTeamInfoForParticipant(gomock.Any(), gomock.Any(), 5, gomock.Any()).Return(map[string]int{"Messi": 2}, nil).Times(1)
+
+	myFPLServer := &server.MyFPLServer{Scraper: testObj}
+
+	var wg sync.WaitGroup
+	results := make([]*grpc_fpl.PlayerOccuranceData, 3)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			playerOccurance, err := myFPLServer.GetDataForGameweek(context.Background(), &grpc_fpl.GameweekReq{LeagueCode: 313, Gameweek: 5})
+			assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+			results[i] = playerOccurance
+		}(i)
+	}
+	waitForSingleflight(t, len(results))
+	close(release)
+	wg.Wait()
+
+	for _, playerOccurance := range results {
+		assert.Equal(t, int32(2), playerOccurance.PlayerOccurance["Messi"])
 	}
-	playerOccuranceForAllGameweeks := make(map[int]map[string]int)
-	fileName, err := scraper.WriteToFile(playerOccuranceForAllGameweeks, 1)
-	return fileName, err
 }
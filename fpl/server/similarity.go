@@ -0,0 +1,254 @@
+package server
+
+import (
+	"context"
+	"math"
+	"sort"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/sirupsen/logrus"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	//defaultSimilarityThreshold is the similarity clusters of managers are merged down to when a request doesn't ask
+	defaultSimilarityThreshold = 0.5
+	//maxSimilarityManagers is how many managers can be compared, as every pair of them is
+	maxSimilarityManagers = 200
+	//squadSize is the number of picks of a squad, and so the size of cluster templates
+	squadSize = 15
+)
+
+//GetSimilarity is the gRPC method comparing the squads of the top managers in a league, or of the entries of a
+//cohort, in a gameweek. Every pair of managers gets the weighted Jaccard similarity of their picks, and managers are
+//grouped into clusters whose average similarity is at least the threshold of the request, each with the template
+//of the cluster and the manager closest to the others
+func (s *MyFPLServer) GetSimilarity(ctx context.Context, req *grpc_fpl.SimilarityReq) (*grpc_fpl.Similarity, error) {
+	gameweek, sampleSize := int(req.Gameweek), sampleSizeOrDefault(int(req.SampleSize))
+	ctx = withLogger(ctx, s.logger(ctx).WithFields(logrus.Fields{
+		"league":      req.LeagueCode,
+		"cohort":      req.Cohort,
+		"gameweek":    gameweek,
+		"sample_size": sampleSize,
+	}))
+	if err := checkLeagueOrCohort(req.LeagueCode, req.Cohort); err != nil {
+		return nil, err
+	}
+	if gameweek < 1 || gameweek > GameweekMax {
+		return nil, status.Errorf(codes.InvalidArgument, "gameweek %v is not between 1 and %v", gameweek, GameweekMax)
+	}
+	if req.Cohort != "" {
+		//Every entry of a cohort is compared, whatever the sample size
+		cohort, err := s.cohort(req.Cohort)
+		if err != nil {
+			return nil, err
+		}
+		sampleSize = len(cohort.Entries)
+	}
+	if sampleSize > maxSimilarityManagers {
+		return nil, status.Errorf(codes.InvalidArgument, "at most %v managers can be compared, not %v", maxSimilarityManagers, sampleSize)
+	}
+	//A threshold of 0, which puts every manager in the same cluster, has to be asked for with hasThreshold
+	threshold := req.Threshold
+	if threshold == 0 && !req.HasThreshold {
+		threshold = defaultSimilarityThreshold
+	}
+	if threshold < 0 || threshold > 1 {
+		return nil, status.Errorf(codes.InvalidArgument, "threshold %v is not between 0 and 1", req.Threshold)
+	}
+
+	playerMap, picks, err := s.groupPicks(ctx, int(req.LeagueCode), req.Cohort, gameweek, sampleSize)
+	if err != nil {
+		return nil, err
+	}
+	return similarity(playerMap, picks, gameweek, threshold), nil
+}
+
+//similarity works out the similarity of every pair of managers and their clusters, managers being in the order of
+//their entries
+func similarity(playerMap map[int64]string, picks map[int64]*ParticipantTeamInfo, gameweek int, threshold float64) *grpc_fpl.Similarity {
+	entries := make([]int64, 0, len(picks))
+	for entry := range picks {
+		entries = append(entries, entry)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i] < entries[j]
+	})
+	squads := make([]map[int64]float64, len(entries))
+	for i, entry := range entries {
+		squads[i] = squadWeights(picks[entry])
+	}
+
+	similarities := make([][]float64, len(entries))
+	total, pairs := 0.0, 0
+	for i := range entries {
+		similarities[i] = make([]float64, len(entries))
+		for j := range entries {
+			switch {
+			case i == j:
+				similarities[i][j] = 1
+			case j < i:
+				similarities[i][j] = similarities[j][i]
+			default:
+				similarities[i][j] = weightedJaccard(squads[i], squads[j])
+				total += similarities[i][j]
+				pairs++
+			}
+		}
+	}
+
+	result := &grpc_fpl.Similarity{Gameweek: int64(gameweek), Entries: entries, Threshold: threshold}
+	if pairs > 0 {
+		result.AverageSimilarity = roundSimilarity(total / float64(pairs))
+	}
+	for i, entry := range entries {
+		row := make([]float64, len(entries))
+		for j := range entries {
+			row[j] = roundSimilarity(similarities[i][j])
+		}
+		result.Managers = append(result.Managers, &grpc_fpl.ManagerSimilarity{Entry: entry, Similarities: row})
+	}
+	for _, members := range clusterManagers(similarities, threshold) {
+		cluster := &grpc_fpl.ManagerCluster{
+			Representative: entries[medoid(similarities, members)],
+			Template:       clusterTemplate(playerMap, squads, members),
+			Cohesion:       roundSimilarity(linkage(similarities, members, members)),
+		}
+		for _, member := range members {
+			cluster.Entries = append(cluster.Entries, entries[member])
+		}
+		result.Clusters = append(result.Clusters, cluster)
+	}
+	return result
+}
+
+//squadWeights weighs every pick of a squad by its multiplier, so that captains count double. Benched players have
+//no multiplier but still weigh 1, as the whole squad is compared
+func squadWeights(teamInfo *ParticipantTeamInfo) map[int64]float64 {
+	weights := make(map[int64]float64, len(teamInfo.TeamPlayers))
+	for _, player := range teamInfo.TeamPlayers {
+		weights[player.Element] = math.Max(1, float64(player.Multiplier))
+	}
+	return weights
+}
+
+//weightedJaccard is the sum of the smaller weights of the players in both squads over the sum of the larger weights
+//of the players in either, 1 for identical squads and 0 for squads without a player in common
+func weightedJaccard(a, b map[int64]float64) float64 {
+	var intersection, union float64
+	for player, weight := range a {
+		intersection += math.Min(weight, b[player])
+		union += math.Max(weight, b[player])
+	}
+	for player, weight := range b {
+		if _, ok := a[player]; !ok {
+			union += weight
+		}
+	}
+	if union == 0 {
+		return 0
+	}
+	return intersection / union
+}
+
+//clusterManagers groups managers by average linkage: starting with a cluster per manager, the two clusters with the
+//highest average similarity between their managers are merged until none is at least threshold. Clusters come
+//biggest first, with their managers in order
+func clusterManagers(similarities [][]float64, threshold float64) [][]int {
+	clusters := make([][]int, len(similarities))
+	for i := range similarities {
+		clusters[i] = []int{i}
+	}
+	for len(clusters) > 1 {
+		first, second, best := -1, -1, threshold
+		for i := range clusters {
+			for j := i + 1; j < len(clusters); j++ {
+				if linked := linkage(similarities, clusters[i], clusters[j]); linked >= best && (first < 0 || linked > best) {
+					first, second, best = i, j, linked
+				}
+			}
+		}
+		if first < 0 {
+			break
+		}
+		clusters[first] = append(clusters[first], clusters[second]...)
+		sort.Ints(clusters[first])
+		clusters = append(clusters[:second], clusters[second+1:]...)
+	}
+	sort.SliceStable(clusters, func(i, j int) bool {
+		if len(clusters[i]) != len(clusters[j]) {
+			return len(clusters[i]) > len(clusters[j])
+		}
+		return clusters[i][0] < clusters[j][0]
+	})
+	return clusters
+}
+
+//linkage is the average similarity between the managers of two clusters, leaving out managers paired with
+//themselves. A cluster of a single manager is linked to itself by 1
+func linkage(similarities [][]float64, a, b []int) float64 {
+	total, pairs := 0.0, 0
+	for _, i := range a {
+		for _, j := range b {
+			if i != j {
+				total += similarities[i][j]
+				pairs++
+			}
+		}
+	}
+	if pairs == 0 {
+		return 1
+	}
+	return total / float64(pairs)
+}
+
+//medoid returns the manager of a cluster with the highest average similarity to the others, the first one on ties
+func medoid(similarities [][]float64, members []int) int {
+	best, bestSimilarity := members[0], -1.0
+	for _, member := range members {
+		if linked := linkage(similarities, []int{member}, members); linked > bestSimilarity {
+			best, bestSimilarity = member, linked
+		}
+	}
+	return best
+}
+
+//clusterTemplate returns the players picked by at least half the managers of a cluster, the most picked first and
+//no more than a squad
+func clusterTemplate(playerMap map[int64]string, squads []map[int64]float64, members []int) []string {
+	//Players are counted by element, as different players can have the same name
+	counts := make(map[int64]int)
+	for _, member := range members {
+		for player := range squads[member] {
+			counts[player]++
+		}
+	}
+	var elements []int64
+	for player, count := range counts {
+		if 2*count >= len(members) {
+			elements = append(elements, player)
+		}
+	}
+	sort.Slice(elements, func(i, j int) bool {
+		if counts[elements[i]] != counts[elements[j]] {
+			return counts[elements[i]] > counts[elements[j]]
+		}
+		if playerMap[elements[i]] != playerMap[elements[j]] {
+			return playerMap[elements[i]] < playerMap[elements[j]]
+		}
+		return elements[i] < elements[j]
+	})
+	if len(elements) > squadSize {
+		elements = elements[:squadSize]
+	}
+	template := make([]string, 0, len(elements))
+	for _, player := range elements {
+		template = append(template, playerMap[player])
+	}
+	return template
+}
+
+func roundSimilarity(similarity float64) float64 {
+	return math.Round(similarity*10000) / 10000
+}
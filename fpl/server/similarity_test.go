@@ -0,0 +1,103 @@
+package server_test
+
+import (
+	"context"
+	"testing"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/go-fantasy/fpl/mock"
+	"github.com/go-fantasy/fpl/server"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestGetSimilarity(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testObj := mock_server.NewMockScraper(mockCtrl)
+
+	//Managers 1 and 2 have the same squad but 2 captains Messi, 3 swaps Kane for Son and 4 has nothing in common
+	captained := picksOf(301, 100)
+	captained.TeamPlayers = append(captained.TeamPlayers, server.TeamPlayers{Element: 267, IsCaptain: true, Multiplier: 2})
+	testObj.EXPECT().GetPlayerMapping(gomock.Any()).Return(map[int64]string{
+		267: "Messi", 301: "Salah", 100: "Kane", 200: "Son", 400: "Alisson", 500: "Ederson", 600: "Pope",
+	}, nil).AnyTimes()
+	testObj.EXPECT().GetParticipantsInLeague(gomock.Any(), 313).Return(&[]int64{1, 2, 3, 4}, nil).AnyTimes()
+	testObj.EXPECT().GetPicksForParticipants(gomock.Any(), 5, &[]int64{1, 2, 3, 4}).Return(map[int64]*server.ParticipantTeamInfo{
+		1: picksOf(267, 301, 100),
+		2: captained,
+		3: picksOf(267, 301, 200),
+		4: picksOf(400, 500, 600),
+	}, nil).AnyTimes()
+	myFPLServer := &server.MyFPLServer{Scraper: testObj}
+	ctx := context.Background()
+
+	similarity, err := myFPLServer.GetSimilarity(ctx, &grpc_fpl.SimilarityReq{LeagueCode: 313, Gameweek: 5})
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, []int64{1, 2, 3, 4}, similarity.Entries)
+	assert.Equal(t, []float64{1, 0.75, 0.5, 0}, similarity.Managers[0].Similarities)
+	assert.Equal(t, []float64{0.75, 1, 0.4, 0}, similarity.Managers[1].Similarities, "Captains should weigh double")
+	assert.Equal(t, 0.275, similarity.AverageSimilarity)
+	assert.Equal(t, 0.5, similarity.Threshold)
+	assert.Equal(t, []*grpc_fpl.ManagerCluster{
+		{Entries: []int64{1, 2}, Representative: 1, Template: []string{"Kane", "Messi", "Salah"}, Cohesion: 0.75},
+		{Entries: []int64{3}, Representative: 3, Template: []string{"Messi", "Salah", "Son"}, Cohesion: 1},
+		{Entries: []int64{4}, Representative: 4, Template: []string{"Alisson", "Ederson", "Pope"}, Cohesion: 1},
+	}, similarity.Clusters)
+
+	similarity, err = myFPLServer.GetSimilarity(ctx, &grpc_fpl.SimilarityReq{LeagueCode: 313, Gameweek: 5, Threshold: 0.4})
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	require.Len(t, similarity.Clusters, 2, "A lower threshold should merge manager 3 into the first cluster")
+	assert.Equal(t, []int64{1, 2, 3}, similarity.Clusters[0].Entries)
+	assert.Equal(t, int64(1), similarity.Clusters[0].Representative)
+	assert.Equal(t, []string{"Messi", "Salah", "Kane"}, similarity.Clusters[0].Template, "Players picked by half the cluster should make its template")
+
+	similarity, err = myFPLServer.GetSimilarity(ctx, &grpc_fpl.SimilarityReq{LeagueCode: 313, Gameweek: 5, HasThreshold: true})
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, float64(0), similarity.Threshold)
+	require.Len(t, similarity.Clusters, 1, "A threshold of 0 should put every manager in the same cluster")
+
+	for _, req := range []*grpc_fpl.SimilarityReq{
+		{LeagueCode: 313},
+		{LeagueCode: 313, Gameweek: 5, Threshold: 1.5},
+		{LeagueCode: 313, Gameweek: 5, SampleSize: 1000},
+		{LeagueCode: 313, Gameweek: 5, Cohort: "creators"},
+	} {
+		_, err := myFPLServer.GetSimilarity(ctx, req)
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "Request %v should be invalid", req)
+	}
+
+	entries := make([]int64, 201)
+	for i := range entries {
+		entries[i] = int64(i + 1)
+	}
+	cohorts, err := server.NewCohortStore(server.Cohort{Name: "everyone", Entries: entries})
+	require.Nil(t, err)
+	myFPLServer.Cohorts = cohorts
+	_, err = myFPLServer.GetSimilarity(ctx, &grpc_fpl.SimilarityReq{Cohort: "everyone", Gameweek: 5})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err), "A cohort too big to compare should be invalid")
+}
+
+func TestGetSimilarityTemplate(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testObj := mock_server.NewMockScraper(mockCtrl)
+
+	//Managers 1 and 2 have different players with the same name, who are each picked by a third of the managers
+	testObj.EXPECT().GetPlayerMapping(gomock.Any()).Return(map[int64]string{267: "Messi", 10: "Fernandes", 11: "Fernandes"}, nil)
+	testObj.EXPECT().GetParticipantsInLeague(gomock.Any(), 313).Return(&[]int64{1, 2, 3}, nil)
+	testObj.EXPECT().GetPicksForParticipants(gomock.Any(), 5, &[]int64{1, 2, 3}).Return(map[int64]*server.ParticipantTeamInfo{
+		1: picksOf(267, 10),
+		2: picksOf(267, 11),
+		3: picksOf(267),
+	}, nil)
+	myFPLServer := &server.MyFPLServer{Scraper: testObj}
+
+	similarity, err := myFPLServer.GetSimilarity(context.Background(), &grpc_fpl.SimilarityReq{LeagueCode: 313, Gameweek: 5, HasThreshold: true})
+	require.Nil(t, err, "Error %v was supposed to be nil ", err)
+	require.Len(t, similarity.Clusters, 1)
+	assert.Equal(t, []string{"Messi"}, similarity.Clusters[0].Template, "Players with the same name should be counted apart")
+}
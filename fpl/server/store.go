@@ -0,0 +1,28 @@
+package server
+
+type leagueSample struct {
+	leagueCode int
+	sampleSize int
+}
+
+//NewMemoryStore creates an empty in-memory store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		leagues: make(map[leagueSample]*LeagueData),
+	}
+}
+
+//Get returns the last data stored for a league, scraped from the given number of top participants
+func (m *MemoryStore) Get(leagueCode, sampleSize int) (*LeagueData, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	leagueData, ok := m.leagues[leagueSample{leagueCode, sampleSize}]
+	return leagueData, ok
+}
+
+//Set replaces the data stored for a league. The data must not be modified afterwards, as it is shared with readers
+func (m *MemoryStore) Set(leagueData *LeagueData) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.leagues[leagueSample{leagueData.LeagueCode, leagueData.SampleSize}] = leagueData
+}
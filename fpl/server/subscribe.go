@@ -0,0 +1,377 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	defaultRefreshInterval = 2 * time.Minute
+	updateHistorySize      = 1000
+	subscriberBuffer       = 64
+)
+
+//Subscribe is the gRPC method to receive updates for a league whenever the background refresh notices a change.
+//A client that reconnects with the cursor of the last update it received gets every update it missed first
+func (s *MyFPLServer) Subscribe(req *grpc_fpl.SubscribeReq, stream grpc_fpl.FPL_SubscribeServer) error {
+	if req.LeagueCode <= 0 {
+		return status.Errorf(codes.InvalidArgument, "invalid league %v", req.LeagueCode)
+	}
+	ctx := stream.Context()
+	hub := s.updateHub()
+	updates, missed, err := hub.subscribe(req.LeagueCode, req.Cursor)
+	if err != nil {
+		return status.Errorf(codes.OutOfRange, "unable to resume subscription for league %v : %v", req.LeagueCode, err)
+	}
+	defer hub.unsubscribe(req.LeagueCode, updates)
+
+	cursor := req.Cursor
+	send := func(update *grpc_fpl.Update) error {
+		cursor = update.Cursor
+		update = filterUpdate(update, req.Players)
+		if update == nil {
+			return nil
+		}
+		return stream.Send(update)
+	}
+
+	s.logger(ctx).WithFields(logrus.Fields{
+		"league": req.LeagueCode,
+		"cursor": req.Cursor,
+		"missed": len(missed),
+	}).Debug("subscribed")
+	for _, update := range missed {
+		if err := send(update); err != nil {
+			return err
+		}
+	}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-s.quitting():
+			return status.Errorf(codes.Unavailable, "server is shutting down, resubscribe from cursor %v", cursor)
+		case update, ok := <-updates:
+			if !ok {
+				return status.Errorf(codes.Aborted, "subscriber fell behind, resubscribe from cursor %v", cursor)
+			}
+			if err := send(update); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+//updateHub returns the hub for subscriptions, creating it on first use
+func (s *MyFPLServer) updateHub() *updateHub {
+	s.hubOnce.Do(func() {
+		s.hub = newUpdateHub(s.watchLeague)
+	})
+	return s.hub
+}
+
+//leagueState is what the background refresh of a league saw last time, so that it only publishes changes
+type leagueState struct {
+	gameweek     int
+	participants []int64
+	ownership    map[string]int
+	picks        map[int64]*ParticipantTeamInfo
+	livePoints   map[int64]int64
+}
+
+//watchLeague refreshes a league in the background until stop is closed
+func (s *MyFPLServer) watchLeague(leagueCode int64, stop <-chan struct{}) {
+	interval := s.RefreshInterval
+	if interval <= 0 {
+		interval = defaultRefreshInterval
+	}
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+
+	ctx := withLogger(context.Background(), s.logger(context.Background()).WithField("league", leagueCode))
+	state := &leagueState{}
+	for {
+		select {
+		case <-stop:
+			return
+		case <-timer.C:
+		}
+
+		refreshCtx, span := s.tracer().Start(ctx, "refreshLeague", trace.WithAttributes(attribute.Int64("league", leagueCode)))
+		updates, err := s.refreshLeague(refreshCtx, leagueCode, state)
+		endSpan(span, err)
+		if err != nil {
+			s.logger(ctx).WithError(err).Warn("error while refreshing league")
+		} else {
+			s.logger(ctx).WithField("updates", len(updates)).Debug("refreshed league")
+			s.updateHub().publish(leagueCode, updates...)
+		}
+		timer.Reset(interval)
+	}
+}
+
+//refreshLeague scrapes the current gameweek of a league and returns an update for everything that changed since state
+func (s *MyFPLServer) refreshLeague(ctx context.Context, leagueCode int64, state *leagueState) ([]*grpc_fpl.Update, error) {
+	events, err := s.Scraper.GetEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+	gameweek := currentGameweek(events)
+	if gameweek == 0 {
+		return nil, nil
+	}
+
+	playerMap, err := s.Scraper.GetPlayerMapping(ctx)
+	if err != nil {
+		return nil, err
+	}
+	participants, err := s.Scraper.GetParticipantsInLeague(ctx, int(leagueCode))
+	if err != nil {
+		return nil, err
+	}
+	topLeagueParticipants := topParticipants(participants, defaultSampleSize)
+
+	//Picks are locked once the deadline has passed, so they are only fetched again for a new gameweek or when the
+	//top participants change
+	picks := state.picks
+	if gameweek != state.gameweek || !reflect.DeepEqual(topLeagueParticipants, state.participants) {
+		picks, err = s.Scraper.GetPicksForParticipants(ctx, gameweek, &topLeagueParticipants)
+		if err != nil {
+			return nil, err
+		}
+	}
+	ownership := countPicks(playerMap, picks, topLeagueParticipants)
+	livePoints, err := s.Scraper.GetLivePoints(ctx, gameweek)
+	if err != nil {
+		return nil, err
+	}
+	managers := calculateLiveStandings(playerMap, picks, livePoints)
+
+	var updates []*grpc_fpl.Update
+	now := time.Now().Unix()
+	changed := changedPlayers(state.ownership, ownership)
+	if gameweek != state.gameweek || len(changed) > 0 {
+		updateType := grpc_fpl.UpdateType_OWNERSHIP_CHANGED
+		if gameweek != state.gameweek {
+			updateType = grpc_fpl.UpdateType_NEW_GAMEWEEK
+		}
+		updates = append(updates, &grpc_fpl.Update{
+			Type:            updateType,
+			LeagueCode:      leagueCode,
+			Gameweek:        int64(gameweek),
+			PlayerOccurance: toPlayerOccuranceResult(ownership),
+			ChangedPlayers:  changed,
+			UpdatedAt:       now,
+		})
+	}
+
+	managerPoints := make(map[int64]int64)
+	for _, manager := range managers {
+		managerPoints[manager.Entry] = manager.LivePoints
+	}
+	if !reflect.DeepEqual(managerPoints, state.livePoints) {
+		updates = append(updates, &grpc_fpl.Update{
+			Type:       grpc_fpl.UpdateType_LIVE_POINTS,
+			LeagueCode: leagueCode,
+			Gameweek:   int64(gameweek),
+			Managers:   managers,
+			UpdatedAt:  now,
+		})
+	}
+
+	state.gameweek = gameweek
+	state.participants = topLeagueParticipants
+	state.ownership = ownership
+	state.picks = picks
+	state.livePoints = managerPoints
+	return updates, nil
+}
+
+//currentGameweek returns the gameweek whose deadline has most recently passed, or 0 before the season starts
+func currentGameweek(events []Event) int {
+	for _, event := range events {
+		if event.IsCurrent {
+			return event.ID
+		}
+	}
+	return 0
+}
+
+//changedPlayers returns the players whose occurance differs between two snapshots, in alphabetical order
+func changedPlayers(before, after map[string]int) []string {
+	var changed []string
+	for player, occurance := range after {
+		if before[player] != occurance {
+			changed = append(changed, player)
+		}
+	}
+	for player := range before {
+		if _, ok := after[player]; !ok {
+			changed = append(changed, player)
+		}
+	}
+	sort.Strings(changed)
+	return changed
+}
+
+func toPlayerOccuranceResult(playerOccuranceForGameweek map[string]int) map[string]int32 {
+	playerOccuranceResult := make(map[string]int32)
+	for player, occurance := range playerOccuranceForGameweek {
+		playerOccuranceResult[player] = int32(occurance)
+	}
+	return playerOccuranceResult
+}
+
+//filterUpdate narrows an ownership update down to the players a subscriber asked for.
+//It returns nil when none of those players changed, and leaves the shared update untouched
+func filterUpdate(update *grpc_fpl.Update, players []string) *grpc_fpl.Update {
+	if len(players) == 0 || update.Type == grpc_fpl.UpdateType_LIVE_POINTS {
+		return update
+	}
+
+	wanted := make(map[string]bool)
+	for _, player := range players {
+		wanted[player] = true
+	}
+	filtered := &grpc_fpl.Update{
+		Cursor:          update.Cursor,
+		Type:            update.Type,
+		LeagueCode:      update.LeagueCode,
+		Gameweek:        update.Gameweek,
+		PlayerOccurance: make(map[string]int32),
+		UpdatedAt:       update.UpdatedAt,
+	}
+	for player, occurance := range update.PlayerOccurance {
+		if wanted[player] {
+			filtered.PlayerOccurance[player] = occurance
+		}
+	}
+	for _, player := range update.ChangedPlayers {
+		if wanted[player] {
+			filtered.ChangedPlayers = append(filtered.ChangedPlayers, player)
+		}
+	}
+	if update.Type == grpc_fpl.UpdateType_OWNERSHIP_CHANGED && len(filtered.ChangedPlayers) == 0 {
+		return nil
+	}
+	return filtered
+}
+
+//updateHub fans out league updates to subscribers. It keeps a bounded history of updates so that
+//a reconnecting client can resume from its cursor, and runs one background watch per subscribed league
+type updateHub struct {
+	mu          sync.Mutex
+	cursor      int64
+	history     []*grpc_fpl.Update
+	subscribers map[int64]map[chan *grpc_fpl.Update]bool
+	watchers    map[int64]chan struct{}
+	watch       func(int64, <-chan struct{})
+}
+
+func newUpdateHub(watch func(int64, <-chan struct{})) *updateHub {
+	return &updateHub{
+		subscribers: make(map[int64]map[chan *grpc_fpl.Update]bool),
+		watchers:    make(map[int64]chan struct{}),
+		watch:       watch,
+	}
+}
+
+//subscribe registers a subscriber for a league, and returns the updates it missed since cursor.
+//A zero cursor returns the latest update of each type instead, so that new subscribers start from the current state
+func (h *updateHub) subscribe(leagueCode, cursor int64) (chan *grpc_fpl.Update, []*grpc_fpl.Update, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if cursor > h.cursor {
+		return nil, nil, fmt.Errorf("cursor %v is ahead of the server, which may have restarted", cursor)
+	}
+	if cursor > 0 && len(h.history) > 0 && cursor+1 < h.history[0].Cursor {
+		return nil, nil, fmt.Errorf("cursor %v is too old, the oldest update kept is %v", cursor, h.history[0].Cursor)
+	}
+
+	var missed []*grpc_fpl.Update
+	if cursor > 0 {
+		for _, update := range h.history {
+			if update.LeagueCode == leagueCode && update.Cursor > cursor {
+				missed = append(missed, update)
+			}
+		}
+	} else {
+		latest := make(map[grpc_fpl.UpdateType]bool)
+		for i := len(h.history) - 1; i >= 0; i-- {
+			update := h.history[i]
+			if update.LeagueCode == leagueCode && !latest[update.Type] {
+				latest[update.Type] = true
+				missed = append([]*grpc_fpl.Update{update}, missed...)
+			}
+		}
+	}
+
+	updates := make(chan *grpc_fpl.Update, subscriberBuffer)
+	if h.subscribers[leagueCode] == nil {
+		h.subscribers[leagueCode] = make(map[chan *grpc_fpl.Update]bool)
+	}
+	h.subscribers[leagueCode][updates] = true
+
+	if _, ok := h.watchers[leagueCode]; !ok {
+		stop := make(chan struct{})
+		h.watchers[leagueCode] = stop
+		go h.watch(leagueCode, stop)
+	}
+	return updates, missed, nil
+}
+
+func (h *updateHub) unsubscribe(leagueCode int64, updates chan *grpc_fpl.Update) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.removeLocked(leagueCode, updates)
+}
+
+//removeLocked drops a subscriber, and stops watching the league once nobody is subscribed to it
+func (h *updateHub) removeLocked(leagueCode int64, updates chan *grpc_fpl.Update) {
+	delete(h.subscribers[leagueCode], updates)
+	if len(h.subscribers[leagueCode]) > 0 {
+		return
+	}
+	delete(h.subscribers, leagueCode)
+	if stop, ok := h.watchers[leagueCode]; ok {
+		close(stop)
+		delete(h.watchers, leagueCode)
+	}
+}
+
+//publish gives the updates of a league their cursors and hands them to its subscribers.
+//A subscriber that falls too far behind is dropped, and can resubscribe from its last cursor
+func (h *updateHub) publish(leagueCode int64, updates ...*grpc_fpl.Update) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for _, update := range updates {
+		h.cursor++
+		update.Cursor = h.cursor
+		h.history = append(h.history, update)
+		if len(h.history) > updateHistorySize {
+			h.history = h.history[len(h.history)-updateHistorySize:]
+		}
+
+		for subscriber := range h.subscribers[leagueCode] {
+			select {
+			case subscriber <- update:
+			default:
+				close(subscriber)
+				h.removeLocked(leagueCode, subscriber)
+			}
+		}
+	}
+}
@@ -0,0 +1,154 @@
+package server_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/go-fantasy/fpl/mock"
+	"github.com/go-fantasy/fpl/server"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+type mockUpdateStream struct {
+	grpc.ServerStream
+	ctx    context.Context
+	cancel context.CancelFunc
+	want   int
+	sent   []*grpc_fpl.Update
+}
+
+func newMockUpdateStream(want int) *mockUpdateStream {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second*5)
+	return &mockUpdateStream{ctx: ctx, cancel: cancel, want: want}
+}
+
+func (x *mockUpdateStream) Context() context.Context {
+	return x.ctx
+}
+
+func (x *mockUpdateStream) Send(m *grpc_fpl.Update) error {
+	x.sent = append(x.sent, m)
+	if len(x.sent) == x.want {
+		x.cancel()
+	}
+	return nil
+}
+
+func newSubscribeServer(t *testing.T) *server.MyFPLServer {
+	mockCtrl := gomock.NewController(t)
+	testObj := mock_server.NewMockScraper(mockCtrl)
+
+	playerMap := map[int64]string{267: "Messi", 247: "Ronaldo", 454: "Salah"}
+	testObj.EXPECT().GetEvents(gomock.Any()).Return([]server.Event{{ID: 1, IsCurrent: true}}, nil).AnyTimes()
+	testObj.EXPECT().GetPlayerMapping(gomock.Any()).Return(playerMap, nil).AnyTimes()
+
+	//The first refresh sees managers 1 and 2 on Messi, every later one sees manager 3 on Salah instead of manager 2
+	var mu sync.Mutex
+	refreshes := 0
+	testObj.EXPECT().GetParticipantsInLeague(gomock.Any(), 313).
+		DoAndReturn(func(context.Context, int) (*[]int64, error) {
+			mu.Lock()
+			defer mu.Unlock()
+			refreshes++
+			if refreshes == 1 {
+				return &[]int64{1, 2}, nil
+			}
+			return &[]int64{1, 3}, nil
+		}).AnyTimes()
+
+	picks := map[int64]*server.ParticipantTeamInfo{
+		1: {TeamPlayers: []server.TeamPlayers{{Element: 267, Multiplier: 2, IsCaptain: true}}},
+		2: {TeamPlayers: []server.TeamPlayers{{Element: 247, Multiplier: 2, IsCaptain: true}, {Element: 267, Multiplier: 1}}},
+		3: {TeamPlayers: []server.TeamPlayers{{Element: 247, Multiplier: 2, IsCaptain: true}, {Element: 454, Multiplier: 1}}},
+	}
+	//Ownership is counted from the picks, nothing else is fetched for it
+	testObj.EXPECT().GetPicksForParticipants(gomock.Any(), 1, gomock.Any()).
+		DoAndReturn(func(ctx context.Context, gameweek int, participants *[]int64) (map[int64]*server.ParticipantTeamInfo, error) {
+			participantPicks := make(map[int64]*server.ParticipantTeamInfo)
+			for _, participant := range *participants {
+				participantPicks[participant] = picks[participant]
+			}
+			return participantPicks, nil
+		}).AnyTimes()
+	testObj.EXPECT().GetLivePoints(gomock.Any(), 1).Return(map[int64]int{267: 5, 247: 2}, nil).AnyTimes()
+
+	return &server.MyFPLServer{
+		Scraper:         testObj,
+		RefreshInterval: time.Millisecond * 10,
+	}
+}
+
+func TestSubscribe(t *testing.T) {
+	myFPLServer := newSubscribeServer(t)
+
+	stream := newMockUpdateStream(4)
+	err := myFPLServer.Subscribe(&grpc_fpl.SubscribeReq{LeagueCode: 313}, stream)
+	assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, 4, len(stream.sent))
+
+	assert.Equal(t, grpc_fpl.UpdateType_NEW_GAMEWEEK, stream.sent[0].Type)
+	assert.Equal(t, int64(1), stream.sent[0].Gameweek)
+	assert.Equal(t, int32(2), stream.sent[0].PlayerOccurance["Messi"])
+
+	assert.Equal(t, grpc_fpl.UpdateType_LIVE_POINTS, stream.sent[1].Type)
+	assert.Equal(t, 2, len(stream.sent[1].Managers))
+	assert.Equal(t, int64(10), stream.sent[1].Managers[0].LivePoints)
+
+	assert.Equal(t, grpc_fpl.UpdateType_OWNERSHIP_CHANGED, stream.sent[2].Type)
+	assert.Equal(t, []string{"Messi", "Salah"}, stream.sent[2].ChangedPlayers)
+	assert.Equal(t, grpc_fpl.UpdateType_LIVE_POINTS, stream.sent[3].Type)
+	assert.Equal(t, int64(3), stream.sent[3].Managers[1].Entry)
+
+	for i := 1; i < len(stream.sent); i++ {
+		assert.True(t, stream.sent[i].Cursor > stream.sent[i-1].Cursor, "Cursors should be increasing")
+	}
+
+	//Resuming from the first cursor replays what came after it
+	resumed := newMockUpdateStream(3)
+	err = myFPLServer.Subscribe(&grpc_fpl.SubscribeReq{LeagueCode: 313, Cursor: stream.sent[0].Cursor}, resumed)
+	assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+	assert.Equal(t, stream.sent[1:], resumed.sent)
+}
+
+func TestSubscribeForPlayers(t *testing.T) {
+	myFPLServer := newSubscribeServer(t)
+
+	stream := newMockUpdateStream(3)
+	err := myFPLServer.Subscribe(&grpc_fpl.SubscribeReq{LeagueCode: 313, Players: []string{"Salah"}}, stream)
+	assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+
+	ownershipUpdates := 0
+	for _, update := range stream.sent {
+		if update.Type == grpc_fpl.UpdateType_LIVE_POINTS {
+			continue
+		}
+		ownershipUpdates++
+		for player := range update.PlayerOccurance {
+			assert.Equal(t, "Salah", player)
+		}
+	}
+	assert.Equal(t, 2, ownershipUpdates)
+}
+
+func TestSubscribeWithCursorAhead(t *testing.T) {
+	myFPLServer := newSubscribeServer(t)
+
+	err := myFPLServer.Subscribe(&grpc_fpl.SubscribeReq{LeagueCode: 313, Cursor: 100}, newMockUpdateStream(1))
+	assert.NotNil(t, err)
+}
+
+func TestSubscribeInvalidLeague(t *testing.T) {
+	myFPLServer := newSubscribeServer(t)
+
+	for _, leagueCode := range []int64{0, -1} {
+		err := myFPLServer.Subscribe(&grpc_fpl.SubscribeReq{LeagueCode: leagueCode}, newMockUpdateStream(1))
+		assert.Equal(t, codes.InvalidArgument, status.Code(err), "League %v should be invalid", leagueCode)
+	}
+}
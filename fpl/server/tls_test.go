@@ -0,0 +1,174 @@
+package server_test
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-fantasy/fpl/client"
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/go-fantasy/fpl/mock"
+	"github.com/go-fantasy/fpl/server"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+//testCA is a self-signed CA issuing the certificates of a test, written as PEM files to dir
+type testCA struct {
+	t    *testing.T
+	dir  string
+	cert *x509.Certificate
+	key  *ecdsa.PrivateKey
+	file string
+}
+
+func newTestCA(t *testing.T, dir, name string) *testCA {
+	ca := &testCA{t: t, dir: dir}
+	ca.cert, ca.key = ca.issue(name, &x509.Certificate{
+		IsCA:                  true,
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+	})
+	ca.file = filepath.Join(dir, name+".pem")
+	writePEM(t, ca.file, "CERTIFICATE", ca.cert.Raw)
+	return ca
+}
+
+//issue creates a certificate signed by the CA, or self-signed while the CA itself is being created
+func (ca *testCA) issue(name string, template *x509.Certificate) (*x509.Certificate, *ecdsa.PrivateKey) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.Nil(ca.t, err)
+	serial, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	require.Nil(ca.t, err)
+
+	template.SerialNumber = serial
+	template.Subject = pkix.Name{CommonName: name}
+	template.NotBefore = time.Now().Add(-time.Hour)
+	template.NotAfter = time.Now().Add(time.Hour)
+	parent, signer := template, key
+	if ca.cert != nil {
+		parent, signer = ca.cert, ca.key
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, signer)
+	require.Nil(ca.t, err)
+	cert, err := x509.ParseCertificate(der)
+	require.Nil(ca.t, err)
+	return cert, key
+}
+
+//issueFiles writes a certificate for name and its key to PEM files, returning their paths
+func (ca *testCA) issueFiles(name string, usage x509.ExtKeyUsage, dnsNames ...string) (string, string) {
+	cert, key := ca.issue(name, &x509.Certificate{
+		KeyUsage:    x509.KeyUsageDigitalSignature,
+		ExtKeyUsage: []x509.ExtKeyUsage{usage},
+		DNSNames:    dnsNames,
+	})
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	require.Nil(ca.t, err)
+
+	certFile, keyFile := filepath.Join(ca.dir, name+".pem"), filepath.Join(ca.dir, name+"-key.pem")
+	writePEM(ca.t, certFile, "CERTIFICATE", cert.Raw)
+	writePEM(ca.t, keyFile, "EC PRIVATE KEY", keyDER)
+	return certFile, keyFile
+}
+
+func writePEM(t *testing.T, file, blockType string, der []byte) {
+	err := ioutil.WriteFile(file, pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der}), 0600)
+	require.Nil(t, err)
+}
+
+func TestTLS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fpl-tls")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	serverCA := newTestCA(t, dir, "server-ca")
+	serverCert, serverKey := serverCA.issueFiles("server", x509.ExtKeyUsageServerAuth, "localhost", "fpl.example.com")
+	clientCA := newTestCA(t, dir, "client-ca")
+	clientCert, clientKey := clientCA.issueFiles("client", x509.ExtKeyUsageClientAuth)
+	otherCA := newTestCA(t, dir, "other-ca")
+	otherCert, otherKey := otherCA.issueFiles("other", x509.ExtKeyUsageClientAuth)
+
+	tests := []struct {
+		name         string
+		clientCAFile string
+		options      []client.Option
+		expectError  bool
+	}{
+		{"Insecure client", "", nil, true},
+		{"TLS", "", []client.Option{client.WithCA(serverCA.file)}, false},
+		{"TLS with server name", "", []client.Option{client.WithCA(serverCA.file), client.WithServerName("fpl.example.com")}, false},
+		{"TLS with wrong server name", "", []client.Option{client.WithCA(serverCA.file), client.WithServerName("other.example.com")}, true},
+		{"TLS with untrusted server", "", []client.Option{client.WithCA(clientCA.file)}, true},
+		{"mTLS", clientCA.file, []client.Option{client.WithCA(serverCA.file), client.WithClientCertificate(clientCert, clientKey)}, false},
+		{"mTLS without client certificate", clientCA.file, []client.Option{client.WithCA(serverCA.file)}, true},
+		{"mTLS with untrusted client certificate", clientCA.file, []client.Option{client.WithCA(serverCA.file), client.WithClientCertificate(otherCert, otherKey)}, true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			mockCtrl := gomock.NewController(t)
+			defer mockCtrl.Finish()
+			testObj := mock_server.NewMockScraper(mockCtrl)
+			testObj.EXPECT().GetPlayerMapping(gomock.Any()).Return(map[int64]string{267: "Messi"}, nil).AnyTimes()
+
+			tlsConfig, err := server.LoadTLSConfig(serverCert, serverKey, test.clientCAFile)
+			require.Nil(t, err)
+			myFPLServer := &server.MyFPLServer{Scraper: testObj, TLSConfig: tlsConfig}
+
+			lis, err := net.Listen("tcp", "localhost:0")
+			require.Nil(t, err)
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+			go myFPLServer.Serve(ctx, lis)
+
+			port := lis.Addr().(*net.TCPAddr).Port
+			grpcClient, cleanup, err := client.New(append(test.options, client.WithAddress(fmt.Sprintf("localhost:%v", port)))...)
+			require.Nil(t, err)
+			defer cleanup()
+
+			callCtx, callCancel := context.WithTimeout(context.Background(), time.Second*5)
+			defer callCancel()
+			numPlayers, err := grpcClient.GetNumberOfPlayers(callCtx, &grpc_fpl.NumPlayerRequest{})
+			if test.expectError {
+				assert.NotNil(t, err, "Call was supposed to fail")
+				return
+			}
+			if assert.Nil(t, err, "Error %v was supposed to be nil ", err) {
+				assert.Equal(t, int64(1), numPlayers.NumPlayers)
+			}
+		})
+	}
+}
+
+func TestLoadTLSConfigErrors(t *testing.T) {
+	dir, err := ioutil.TempDir("", "fpl-tls")
+	require.Nil(t, err)
+	defer os.RemoveAll(dir)
+
+	ca := newTestCA(t, dir, "ca")
+	cert, key := ca.issueFiles("server", x509.ExtKeyUsageServerAuth, "localhost")
+
+	_, err = server.LoadTLSConfig(filepath.Join(dir, "missing.pem"), key, "")
+	assert.NotNil(t, err)
+	_, err = server.LoadTLSConfig(cert, key, filepath.Join(dir, "missing.pem"))
+	assert.NotNil(t, err)
+	_, err = server.LoadTLSConfig(cert, key, key)
+	assert.NotNil(t, err, "A key isn't a CA bundle")
+
+	_, _, err = client.New(client.WithCA(key))
+	assert.NotNil(t, err, "A key isn't a CA bundle")
+}
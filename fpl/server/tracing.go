@@ -0,0 +1,115 @@
+package server
+
+import (
+	"context"
+	"io"
+
+	"github.com/pkg/errors"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	otelcodes "go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+//TracerName is the name of the tracer creating the spans of the server and the scraper
+const TracerName = "github.com/go-fantasy/fpl/server"
+
+//NewTracerProvider creates a tracer provider writing every span as JSON to w, so that traces can be inspected
+//locally. Shutdown should be called on it before exiting, to flush the spans that are still buffered
+func NewTracerProvider(w io.Writer) (*sdktrace.TracerProvider, error) {
+	exporter, err := stdouttrace.New(stdouttrace.WithWriter(w))
+	if err != nil {
+		return nil, errors.Errorf("error creating trace exporter : %v", err)
+	}
+	return sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(resource.NewSchemaless(semconv.ServiceName("fpl-server"))),
+	), nil
+}
+
+//tracer returns the tracer of the server, from the global provider when no TracerProvider is set
+func (s *MyFPLServer) tracer() trace.Tracer {
+	if s.TracerProvider != nil {
+		return s.TracerProvider.Tracer(TracerName)
+	}
+	return otel.GetTracerProvider().Tracer(TracerName)
+}
+
+//startSpan starts a child of the span in ctx, using the same provider. Without a span in ctx nothing is recorded
+func startSpan(ctx context.Context, name string, attributes ...attribute.KeyValue) (context.Context, trace.Span) {
+	tracer := trace.SpanFromContext(ctx).TracerProvider().Tracer(TracerName)
+	return tracer.Start(ctx, name, trace.WithAttributes(attributes...))
+}
+
+//endSpan records err on span, if any, and ends it
+func endSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(otelcodes.Error, err.Error())
+	}
+	span.End()
+}
+
+//unaryTracingInterceptor and streamTracingInterceptor start a span for every call, continuing the trace of the
+//caller when its context was propagated in the gRPC metadata
+func (s *MyFPLServer) unaryTracingInterceptor(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+	ctx, span := s.startCallSpan(ctx, info.FullMethod)
+	resp, err := handler(ctx, req)
+	endCallSpan(span, err)
+	return resp, err
+}
+
+func (s *MyFPLServer) streamTracingInterceptor(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+	ctx, span := s.startCallSpan(stream.Context(), info.FullMethod)
+	err := handler(srv, &contextStream{ServerStream: stream, ctx: ctx})
+	endCallSpan(span, err)
+	return err
+}
+
+func (s *MyFPLServer) startCallSpan(ctx context.Context, method string) (context.Context, trace.Span) {
+	md, _ := metadata.FromIncomingContext(ctx)
+	ctx = propagation.TraceContext{}.Extract(ctx, metadataCarrier(md))
+	return s.tracer().Start(ctx, method,
+		trace.WithSpanKind(trace.SpanKindServer),
+		trace.WithAttributes(
+			semconv.RPCSystemGRPC,
+			attribute.String("rpc.method", method),
+		),
+	)
+}
+
+func endCallSpan(span trace.Span, err error) {
+	span.SetAttributes(semconv.RPCGRPCStatusCodeKey.Int(int(status.Code(err))))
+	endSpan(span, err)
+}
+
+//metadataCarrier lets the trace context be read from gRPC metadata
+type metadataCarrier metadata.MD
+
+func (m metadataCarrier) Get(key string) string {
+	values := metadata.MD(m).Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
+func (m metadataCarrier) Set(key, value string) {
+	metadata.MD(m).Set(key, value)
+}
+
+func (m metadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	return keys
+}
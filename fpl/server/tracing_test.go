@@ -0,0 +1,84 @@
+package server_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/go-fantasy/fpl/mock"
+	"github.com/go-fantasy/fpl/server"
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func spansByName(recorder *tracetest.SpanRecorder) map[string]sdktrace.ReadOnlySpan {
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	return spans
+}
+
+func TestTracingContinuesIncomingTrace(t *testing.T) {
+	mockCtrl := gomock.NewController(t)
+	defer mockCtrl.Finish()
+	testObj := mock_server.NewMockScraper(mockCtrl)
+	testObj.EXPECT().GetPlayerMapping(gomock.Any()).Return(map[int64]string{267: "Messi"}, nil).Times(1)
+	testObj.EXPECT().GetParticipantsInLeague(gomock.Any(), 313).Return(&[]int64{1, 2}, nil).Times(1)
+	testObj.EXPECT().GetTeamInfoForParticipant(gomock.Any(), gomock.Any(), 3, gomock.Any()).Return(map[string]int{"Messi": 2}, nil).Times(1)
+
+	recorder := tracetest.NewSpanRecorder()
+	myFPLServer := &server.MyFPLServer{
+		Scraper:        testObj,
+		TracerProvider: sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)),
+	}
+	lis := bufconn.Listen(1024 * 1024)
+	go myFPLServer.Serve(context.Background(), lis)
+	defer myFPLServer.Stop()
+
+	conn, err := dialBufconn(lis)
+	assert.Nil(t, err)
+	defer conn.Close()
+
+	traceID := "4bf92f3577b34da6a3ce929d0e0e4736"
+	ctx := metadata.AppendToOutgoingContext(context.Background(), "traceparent", "00-"+traceID+"-00f067aa0ba902b7-01")
+	_, err = grpc_fpl.NewFPLClient(conn).GetDataForGameweek(ctx, &grpc_fpl.GameweekReq{LeagueCode: 313, Gameweek: 3})
+	assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+
+	spans := spansByName(recorder)
+	call, ok := spans["/grpc.FPL/GetDataForGameweek"]
+	if assert.True(t, ok, "The call should have a span") {
+		assert.Equal(t, traceID, call.SpanContext().TraceID().String())
+		assert.Equal(t, "00f067aa0ba902b7", call.Parent().SpanID().String())
+	}
+	scrape, ok := spans["scrapeGameweek"]
+	if assert.True(t, ok, "The scrape should have a span") {
+		assert.Equal(t, call.SpanContext().SpanID(), scrape.Parent().SpanID())
+	}
+}
+
+func TestTracingOfRequestsToFPL(t *testing.T) {
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("{}"))
+	}))
+	defer ts.Close()
+
+	recorder := tracetest.NewSpanRecorder()
+	ctx, parent := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test").Start(context.Background(), "parent")
+
+	client := &server.MyFPLClient{HttpClient: ts.Client()}
+	_, err := client.MakeRequest(ctx, ts.URL+"/drf/entry/42/event/3/picks")
+	assert.Nil(t, err, "Error %v was supposed to be nil ", err)
+	parent.End()
+
+	span, ok := spansByName(recorder)["GET /drf/entry/:id/event/:id/picks"]
+	if assert.True(t, ok, "The request should have a span") {
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID())
+	}
+}
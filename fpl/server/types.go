@@ -1,44 +1,221 @@
 package server
 
 import (
+	"context"
+	"crypto/tls"
+	"io"
+	"net"
 	"net/http"
+	"sync"
+	"time"
 
-	"github.com/go-fantasy/fpl/grpc"
+	grpc_fpl "github.com/go-fantasy/fpl/grpc"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/trace"
+	"golang.org/x/sync/singleflight"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
 )
 
 //FPLServer is the main interface for the application
 type FPLServer interface {
-	grpc.FPLServer
-	Start(string) error
+	grpc_fpl.FPLServer
+	Start(context.Context, string) error
+	Serve(context.Context, net.Listener) error
+	Stop()
+	GracefulStop(context.Context) error
 }
 
 //Scraper is the main scraping interface for the FPL app
 type Scraper interface {
-	GetTeamInfoForParticipant(map[int64]string, int, *[]int64) (map[string]int, error)
-	GetPlayerMapping() (map[int64]string, error)
-	GetParticipantsInLeague(int) (*[]int64, error)
-	WriteToFile(map[int]map[string]int, int) (string, error)
+	GetTeamInfoForParticipant(context.Context, map[int64]string, int, *[]int64) (map[string]int, error)
+	GetPlayerMapping(context.Context) (map[int64]string, error)
+	GetEvents(context.Context) ([]Event, error)
+	GetFixtures(context.Context, int) ([]Fixture, error)
+	GetParticipantsInLeague(context.Context, int) (*[]int64, error)
+	GetPicksForParticipants(context.Context, int, *[]int64) (map[int64]*ParticipantTeamInfo, error)
+	GetLivePoints(context.Context, int) (map[int64]int, error)
+}
+
+//Store keeps scraped league data so that RPCs don't have to wait for the FPL site
+type Store interface {
+	Get(int, int) (*LeagueData, bool)
+	Set(*LeagueData)
+}
+
+//CohortStore holds the cohorts RPCs can look at instead of the top participants of a league
+type CohortStore interface {
+	Get(string) (Cohort, bool)
+	Set(Cohort)
+	Delete(string) bool
+	List() []Cohort
+}
+
+//KeyStore holds the API keys callers authenticate with
+type KeyStore interface {
+	Lookup(string) (*APIKey, bool)
+	List() []APIKey
+}
+
+//Exporter writes the ownership of players in every gameweek of a league in some format, see DefaultExporters
+type Exporter interface {
+	Export(io.Writer, *OwnershipTable) error
+	ContentType() string
 }
 
 //Client is the interface for making API calls to FPL site
 type Client interface {
-	MakeRequest(string) ([]byte, error)
+	MakeRequest(context.Context, string) ([]byte, error)
 }
 
-//MyFPLServer is my implementation of the FPL server
+//MyFPLServer is my implementation of the FPL server. Its fields are configuration that is set before Start
+//and only read afterwards, everything an RPC scrapes stays local to that RPC, so concurrent calls don't interfere
 type MyFPLServer struct {
-	PlayerMap          map[int64]string
-	LeagueParticipants *[]int64
-	PlayerOccurances   map[int]map[string]int
-	Scraper            Scraper
+	Scraper Scraper
+	Store   Store
+	//WatchedLeagues are kept fresh in the Store by the background scheduler
+	WatchedLeagues []int
+	//CacheTTL is how long data scraped for a league that isn't watched is served from the Store
+	CacheTTL time.Duration
+	//RefreshInterval is how often leagues with subscribers are scraped again in the background
+	RefreshInterval time.Duration
+	//ShutdownTimeout is how long in-flight calls get to finish once the context given to Start is cancelled
+	ShutdownTimeout time.Duration
+	//Metrics collects what the server does, it can be nil
+	Metrics *Metrics
+	//MetricsPort is the port of the HTTP server exposing Metrics to Prometheus, no server is started when empty
+	MetricsPort string
+	//DashboardPort is the port of the HTTP server of the web dashboard, see DashboardHandler. No dashboard is
+	//served when empty
+	DashboardPort string
+	//Logger logs what the server does, with the fields of each call. The standard logrus logger is used when nil
+	Logger logrus.FieldLogger
+	//TracerProvider creates the spans of every call. The global provider is used when nil
+	TracerProvider trace.TracerProvider
+	//HealthCheck registers the gRPC health service, which reports NOT_SERVING once the FPL site has been
+	//unreachable for UnhealthyAfter, probing it every HealthCheckInterval
+	HealthCheck         bool
+	HealthCheckInterval time.Duration
+	UnhealthyAfter      time.Duration
+	//KeyStore makes every FPL call authenticate with an API key, subject to the quota and rate limit of the key.
+	//Any caller is served when nil
+	KeyStore KeyStore
+	//QuotaPeriod is the period API key quotas are counted over, a day when not set
+	QuotaPeriod time.Duration
+	//Cohorts are the named groups of entries that RPCs can look at instead of a league. There are no cohorts when
+	//nil, New starts with an empty store
+	Cohorts CohortStore
+	//TLSConfig serves gRPC over TLS when set, see LoadTLSConfig. Connections are insecure otherwise
+	TLSConfig *tls.Config
+	//Reflection registers gRPC server reflection, so that tools like grpcurl can list and call the RPCs
+	Reflection bool
+	//Exporters add to or replace DefaultExporters for the formats GetDataForAllGameweeks can send
+	Exporters map[grpc_fpl.ExportFormat]Exporter
+
+	hub         *updateHub
+	hubOnce     sync.Once
+	scrapes     singleflight.Group
+	usage       *usageTracker
+	usageOnce   sync.Once
+	exports     *resumableExports
+	exportsOnce sync.Once
+
+	mu         sync.Mutex
+	grpcServer *grpc.Server
+	health     *health.Server
+	quit       chan struct{}
+	quitOnce   sync.Once
+	stopOnce   sync.Once
+}
+
+//LeagueData is what gets scraped for every gameweek of a league, or of a cohort when Cohort is set
+type LeagueData struct {
+	LeagueCode       int
+	Cohort           string
+	SampleSize       int
+	PlayerMap        map[int64]string
+	Participants     []int64
+	PlayerOccurances map[int]map[string]int
+	FetchedAt        time.Time
+}
+
+//OwnershipTable is what exporters write, a row for every player with how many participants owned them in
+//each of Gameweeks
+type OwnershipTable struct {
+	LeagueCode int
+	Cohort     string
+	SampleSize int
+	FetchedAt  time.Time
+	Gameweeks  []int
+	Rows       []OwnershipRow
+}
+
+//OwnershipRow is the ownership of a player, Owners having a count for each gameweek of the table
+type OwnershipRow struct {
+	Player string
+	Owners []int
+}
+
+//MemoryStore is my in-memory implementation of the Store interface
+type MemoryStore struct {
+	mu      sync.RWMutex
+	leagues map[leagueSample]*LeagueData
+}
+
+//APIKey is a key callers send in the x-api-key metadata. Keys without a Quota or RateLimit aren't limited
+type APIKey struct {
+	Name string `json:"name"`
+	Key  string `json:"key"`
+	//Admin keys can call GetUsage, DefineCohort and DeleteCohort
+	Admin bool `json:"admin"`
+	//Quota is how many calls the key can make every QuotaPeriod of the server
+	Quota int64 `json:"quota"`
+	//RateLimit is how many calls per second the key can make, with bursts of up to Burst calls
+	RateLimit float64 `json:"rateLimit"`
+	Burst     int     `json:"burst"`
+}
+
+//MemoryKeyStore is my in-memory implementation of the KeyStore interface
+type MemoryKeyStore struct {
+	keys map[string]APIKey
+}
+
+//Cohort is a named group of FPL entries, like well-known managers, looked at like the top participants of a league
+type Cohort struct {
+	Name    string  `json:"name"`
+	Entries []int64 `json:"entries"`
+}
+
+//MemoryCohortStore is my in-memory implementation of the CohortStore interface
+type MemoryCohortStore struct {
+	mu      sync.RWMutex
+	cohorts map[string]Cohort
 }
 
 //MyFPLScraper is my implementation of the FPL server scraper interface
 type MyFPLScraper struct {
 	Client
+	//Logger is used when the context of a call doesn't carry a logger
+	Logger logrus.FieldLogger
 }
 
 //MyFPLClient is my implementation of the FPL client interface
 type MyFPLClient struct {
 	HttpClient *http.Client
+	//Metrics counts the requests made to the FPL site, it can be nil
+	Metrics *Metrics
+	//Logger is used when the context of a request doesn't carry a logger
+	Logger logrus.FieldLogger
+}
+
+//Metrics collects Prometheus metrics for the server and the scraper.
+//All its methods can be called on a nil *Metrics, which records nothing
+type Metrics struct {
+	registry         *prometheus.Registry
+	rpcRequests      *prometheus.CounterVec
+	rpcDuration      *prometheus.HistogramVec
+	upstreamRequests *prometheus.CounterVec
+	scrapeDuration   *prometheus.HistogramVec
+	cacheRequests    *prometheus.CounterVec
 }